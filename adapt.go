@@ -0,0 +1,130 @@
+package clock
+
+import (
+	"io"
+	"time"
+
+	"github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/realtime"
+	"github.com/noodlebox/clock/relativetime"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+// Impl is a generic interface for a concrete Clock implementation, whose
+// methods return its own Timer and Ticker types, TM and TK. Go does not
+// allow such an implementation to satisfy Clock directly, so use [Adapt]
+// to wrap it.
+type Impl[T Time[T, D], D Duration, TM Timer[T, D], TK Ticker[T, D]] interface {
+	Now() T
+	Since(t T) D
+	Until(t T) D
+	Sleep(d D)
+	After(d D) <-chan T
+	AfterFunc(d D, f func()) TM
+	NewTimer(d D) TM
+	NewTicker(d D) TK
+	Tick(d D) <-chan T
+	DurationFactory[D]
+}
+
+// LocatedImpl is a generic interface for a concrete LocatedClock
+// implementation. Use [AdaptLocated] to wrap it.
+type LocatedImpl[T LocatedTime[T, D], D Duration, TM Timer[T, D], TK Ticker[T, D]] interface {
+	Impl[T, D, TM, TK]
+	Calendar[T]
+}
+
+// Ensure that each implementation supplied by subpackages may be adapted.
+var (
+	_ LocatedImpl[time.Time, time.Duration, *realtime.Timer, *realtime.Ticker]              = realtime.Clock{}
+	_ LocatedImpl[time.Time, time.Duration, *mocktime.Timer, *mocktime.Ticker]              = mocktime.Clock{}
+	_ Impl[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer, *steppedtime.Ticker] = (*steppedtime.Clock)(nil)
+
+	_ Impl[time.Time, time.Duration, *relativetime.Timer[time.Time, time.Duration], *relativetime.Ticker[time.Time, time.Duration]] = (*relativetime.Clock[time.Time, time.Duration, *realtime.Timer])(nil)
+
+	_ TimeCompare[steppedtime.Time, steppedtime.Duration] = steppedtime.Time(0)
+
+	_ MissedTicker[time.Time, time.Duration]               = (*realtime.Ticker)(nil)
+	_ MissedTicker[time.Time, time.Duration]               = (*mocktime.Ticker)(nil)
+	_ MissedTicker[steppedtime.Time, steppedtime.Duration] = (*steppedtime.Ticker)(nil)
+
+	_ PausableTicker[time.Time, time.Duration]               = (*realtime.Ticker)(nil)
+	_ PausableTicker[time.Time, time.Duration]               = (*mocktime.Ticker)(nil)
+	_ PausableTicker[steppedtime.Time, steppedtime.Duration] = (*steppedtime.Ticker)(nil)
+
+	_ PausableTimer[time.Time, time.Duration]               = (*realtime.Timer)(nil)
+	_ PausableTimer[time.Time, time.Duration]               = (*mocktime.Timer)(nil)
+	_ PausableTimer[steppedtime.Time, steppedtime.Duration] = (*steppedtime.Timer)(nil)
+
+	_ PostponableTimer[time.Time, time.Duration]               = (*realtime.Timer)(nil)
+	_ PostponableTimer[time.Time, time.Duration]               = (*mocktime.Timer)(nil)
+	_ PostponableTimer[steppedtime.Time, steppedtime.Duration] = (*steppedtime.Timer)(nil)
+
+	_ io.Closer = realtime.Clock{}
+	_ io.Closer = mocktime.Clock{}
+	_ io.Closer = (*steppedtime.Clock)(nil)
+	_ io.Closer = (*relativetime.Clock[time.Time, time.Duration, *realtime.Timer])(nil)
+)
+
+type adapter[T Time[T, D], D Duration, TM Timer[T, D], TK Ticker[T, D]] struct {
+	Impl[T, D, TM, TK]
+}
+
+func (c adapter[T, D, TM, TK]) AfterFunc(d D, f func()) Timer[T, D] {
+	return c.Impl.AfterFunc(d, f)
+}
+
+func (c adapter[T, D, TM, TK]) NewTimer(d D) Timer[T, D] {
+	return c.Impl.NewTimer(d)
+}
+
+func (c adapter[T, D, TM, TK]) NewTicker(d D) Ticker[T, D] {
+	return c.Impl.NewTicker(d)
+}
+
+type locatedAdapter[T LocatedTime[T, D], D Duration, TM Timer[T, D], TK Ticker[T, D]] struct {
+	LocatedImpl[T, D, TM, TK]
+}
+
+func (c locatedAdapter[T, D, TM, TK]) AfterFunc(d D, f func()) Timer[T, D] {
+	return c.LocatedImpl.AfterFunc(d, f)
+}
+
+func (c locatedAdapter[T, D, TM, TK]) NewTimer(d D) Timer[T, D] {
+	return c.LocatedImpl.NewTimer(d)
+}
+
+func (c locatedAdapter[T, D, TM, TK]) NewTicker(d D) Ticker[T, D] {
+	return c.LocatedImpl.NewTicker(d)
+}
+
+// Adapt returns a Clock backed by the implementation c.
+func Adapt[T Time[T, D], D Duration, TM Timer[T, D], TK Ticker[T, D]](c Impl[T, D, TM, TK]) Clock[T, D] {
+	return adapter[T, D, TM, TK]{c}
+}
+
+// AdaptLocated returns a LocatedClock backed by the implementation c.
+func AdaptLocated[T LocatedTime[T, D], D Duration, TM Timer[T, D], TK Ticker[T, D]](c LocatedImpl[T, D, TM, TK]) LocatedClock[T, D] {
+	return locatedAdapter[T, D, TM, TK]{c}
+}
+
+// FromRealtime returns a StdClock backed by c.
+func FromRealtime(c realtime.Clock) StdClock {
+	return AdaptLocated[time.Time, time.Duration, *realtime.Timer, *realtime.Ticker](c)
+}
+
+// FromMocktime returns a StdClock backed by c, which may track any
+// reference clock, as one created by mocktime.NewClockOn.
+func FromMocktime[RT relativetime.RTimer[mocktime.Duration]](c mocktime.ClockOn[RT]) StdClock {
+	return AdaptLocated[time.Time, time.Duration, *mocktime.Timer, *mocktime.Ticker](c)
+}
+
+// FromSteppedtime returns a Clock backed by c.
+func FromSteppedtime(c *steppedtime.Clock) Clock[steppedtime.Time, steppedtime.Duration] {
+	return Adapt[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer, *steppedtime.Ticker](c)
+}
+
+// FromRelativetime returns a Clock backed by c.
+func FromRelativetime[T Time[T, D], D Duration, RT relativetime.RTimer[D]](c *relativetime.Clock[T, D, RT]) Clock[T, D] {
+	return Adapt[T, D, *relativetime.Timer[T, D], *relativetime.Ticker[T, D]](c)
+}
@@ -0,0 +1,75 @@
+package clock_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/realtime"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+func TestFromSteppedtime(t *testing.T) {
+	s := steppedtime.NewClock()
+	var c Clock[steppedtime.Time, steppedtime.Duration] = FromSteppedtime(s)
+	tm := c.NewTimer(steppedtime.Second)
+	s.Step(steppedtime.Second)
+	select {
+	case <-tm.C():
+	default:
+		t.Errorf("Timer did not fire")
+	}
+}
+
+func TestFromMocktime(t *testing.T) {
+	m := mocktime.NewClock()
+	var c StdClock = FromMocktime(m)
+	tk := c.NewTicker(mocktime.Hour)
+	defer tk.Stop()
+	m.Step(mocktime.Hour)
+	select {
+	case <-tk.C():
+	case <-realtime.NewClock().After(realtime.Second):
+		t.Errorf("Ticker did not tick")
+	}
+	if got := c.Date(2009, mocktime.November, 10, 23, 0, 0, 0, mocktime.UTC); got.Year() != 2009 {
+		t.Errorf("Date returned %v", got)
+	}
+	start := c.Date(2009, mocktime.January, 31, 0, 0, 0, 0, mocktime.UTC)
+	if got, want := c.AddDate(start, 0, 1, 0), c.Date(2009, mocktime.March, 3, 0, 0, 0, 0, mocktime.UTC); !got.Equal(want) {
+		t.Errorf("AddDate(%v, 0, 1, 0) = %v, want %v", start, got, want)
+	}
+}
+
+func TestFromMocktimeOn(t *testing.T) {
+	s := steppedtime.NewClock()
+	epoch := mocktime.Unix(0, 0)
+	m := mocktime.NewClockOn(mocktime.SteppedReference(s, epoch), epoch)
+	var c StdClock = FromMocktime(m)
+	tm := c.NewTimer(mocktime.Hour)
+	m.Step(mocktime.Hour)
+	select {
+	case <-tm.C():
+	default:
+		t.Errorf("Timer did not fire")
+	}
+	if got, want := c.Now(), epoch.Add(mocktime.Hour); !got.Equal(want) {
+		t.Errorf("Now() = %v, want %v", got, want)
+	}
+}
+
+func TestDurationFactory(t *testing.T) {
+	var c Clock[steppedtime.Time, steppedtime.Duration] = FromSteppedtime(steppedtime.NewClock())
+	f, ok := c.(DurationFactory[steppedtime.Duration])
+	if !ok {
+		t.Fatalf("adapted Clock does not implement DurationFactory")
+	}
+	if got := f.Minutes(1.5); got != 90*steppedtime.Second {
+		t.Errorf("Minutes(1.5) = %v, want 90s", got)
+	}
+
+	var r Clock[mocktime.Time, mocktime.Duration] = FromRelativetime(mocktime.NewClock().Clock)
+	if got := r.(DurationFactory[mocktime.Duration]).Milliseconds(250); got != 250*mocktime.Millisecond {
+		t.Errorf("Milliseconds(250) = %v, want 250ms", got)
+	}
+}
@@ -0,0 +1,264 @@
+// Package bench runs identical workloads against each clock implementation,
+// reporting their costs side by side, so that changes to their
+// synchronization may be compared on equal terms.
+package bench
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/realtime"
+	"github.com/noodlebox/clock/relativetime"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+// Target describes a clock to be measured by Run.
+type Target[T clock.Time[T, D], D clock.Duration] struct {
+	Name  string
+	Clock clock.Clock[T, D]
+
+	Unit D         // Duration of timers and periods of tickers
+	Step func(d D) // Advances the clock by d; if nil, Clock.Sleep is used
+}
+
+func (t Target[T, D]) step() {
+	if t.Step == nil {
+		t.Clock.Sleep(t.Unit)
+		return
+	}
+	t.Step(t.Unit)
+}
+
+// Realtime returns a Target for a realtime Clock, with a unit of one
+// microsecond. As it cannot be stepped, workloads on it wait in real time.
+func Realtime() Target[realtime.Time, realtime.Duration] {
+	return Target[realtime.Time, realtime.Duration]{
+		Name:  "realtime",
+		Clock: clock.FromRealtime(realtime.Clock{}),
+		Unit:  realtime.Microsecond,
+	}
+}
+
+// Mocktime returns a Target for a new, stopped mocktime Clock.
+func Mocktime() Target[mocktime.Time, mocktime.Duration] {
+	c := mocktime.NewClock()
+	c.Stop()
+	return Target[mocktime.Time, mocktime.Duration]{
+		Name:  "mocktime",
+		Clock: clock.FromMocktime(c),
+		Unit:  mocktime.Millisecond,
+		Step:  c.Step,
+	}
+}
+
+// Relativetime returns a Target for a new relativetime Clock, running at
+// the same rate as a steppedtime Clock used as its reference. Stepping the
+// Target steps the reference.
+func Relativetime() Target[steppedtime.Time, steppedtime.Duration] {
+	ref := steppedtime.NewClock()
+	c := relativetime.NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	c.Start()
+	return Target[steppedtime.Time, steppedtime.Duration]{
+		Name:  "relativetime",
+		Clock: clock.FromRelativetime(c),
+		Unit:  steppedtime.Millisecond,
+		Step:  ref.Step,
+	}
+}
+
+// Steppedtime returns a Target for a new steppedtime Clock.
+func Steppedtime() Target[steppedtime.Time, steppedtime.Duration] {
+	c := steppedtime.NewClock()
+	return Target[steppedtime.Time, steppedtime.Duration]{
+		Name:  "steppedtime",
+		Clock: clock.FromSteppedtime(c),
+		Unit:  steppedtime.Millisecond,
+		Step:  c.Step,
+	}
+}
+
+// FanoutTickers is the number of tickers used by the TickerFanout workload.
+const FanoutTickers = 64
+
+// A Workload is a named operation to be repeated on each Target.
+type Workload struct {
+	Name string
+	op   int
+}
+
+const (
+	opNow = iota
+	opTimerChurn
+	opTickerFanout
+)
+
+// Workloads supplied by this package.
+var (
+	// NowLoop calls Now in a tight loop.
+	NowLoop = Workload{"now", opNow}
+	// TimerChurn creates a timer, steps the clock past it, and receives
+	// from its channel.
+	TimerChurn = Workload{"timer-churn", opTimerChurn}
+	// TickerFanout steps the clock by one period of FanoutTickers tickers,
+	// draining whatever ticks they have delivered. Ticks are not awaited,
+	// as some implementations reschedule a ticker only after its tick is
+	// received.
+	TickerFanout = Workload{"ticker-fanout", opTickerFanout}
+)
+
+// Workloads returns every Workload supplied by this package.
+func Workloads() []Workload {
+	return []Workload{NowLoop, TimerChurn, TickerFanout}
+}
+
+// run performs n operations of workload w on target.
+func run[T clock.Time[T, D], D clock.Duration](target Target[T, D], w Workload, n int) {
+	c := target.Clock
+	switch w.op {
+	case opNow:
+		for i := 0; i < n; i++ {
+			c.Now()
+		}
+	case opTimerChurn:
+		for i := 0; i < n; i++ {
+			tm := c.NewTimer(target.Unit)
+			target.step()
+			<-tm.C()
+		}
+	case opTickerFanout:
+		tks := make([]clock.Ticker[T, D], FanoutTickers)
+		for i := range tks {
+			tks[i] = c.NewTicker(target.Unit)
+		}
+		for i := 0; i < n; i++ {
+			target.step()
+			for _, tk := range tks {
+				select {
+				case <-tk.C():
+				default:
+				}
+			}
+		}
+		for _, tk := range tks {
+			tk.Stop()
+		}
+	default:
+		panic(fmt.Sprintf("bench: unknown workload %q", w.Name))
+	}
+}
+
+// Result holds the cost of running a Workload on a Target.
+type Result struct {
+	Target   string
+	Workload string
+	N        int           // Number of operations
+	Elapsed  time.Duration // Real time taken
+	Allocs   uint64        // Heap allocations made
+}
+
+// NsPerOp returns the real time taken per operation, in nanoseconds.
+func (r Result) NsPerOp() float64 {
+	if r.N <= 0 {
+		return 0
+	}
+	return float64(r.Elapsed.Nanoseconds()) / float64(r.N)
+}
+
+// AllocsPerOp returns the heap allocations made per operation.
+func (r Result) AllocsPerOp() float64 {
+	if r.N <= 0 {
+		return 0
+	}
+	return float64(r.Allocs) / float64(r.N)
+}
+
+// Run performs n operations of each of workloads on target, returning a
+// Result for each.
+func Run[T clock.Time[T, D], D clock.Duration](target Target[T, D], n int, workloads ...Workload) []Result {
+	results := make([]Result, 0, len(workloads))
+	var before, after runtime.MemStats
+	for _, w := range workloads {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		run(target, w, n)
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		results = append(results, Result{
+			Target:   target.Name,
+			Workload: w.Name,
+			N:        n,
+			Elapsed:  elapsed,
+			Allocs:   after.Mallocs - before.Mallocs,
+		})
+	}
+	return results
+}
+
+// Compare performs n operations of each of workloads on a new Target for
+// every implementation, returning the Results in the order they ran.
+func Compare(n int, workloads ...Workload) []Result {
+	var results []Result
+	results = append(results, Run(Realtime(), n, workloads...)...)
+	results = append(results, Run(Mocktime(), n, workloads...)...)
+	results = append(results, Run(Relativetime(), n, workloads...)...)
+	results = append(results, Run(Steppedtime(), n, workloads...)...)
+	return results
+}
+
+// Benchmark runs each of workloads on target as a sub-benchmark of b, for
+// use with the standard benchmark tooling.
+func Benchmark[T clock.Time[T, D], D clock.Duration](b *testing.B, target Target[T, D], workloads ...Workload) {
+	for _, w := range workloads {
+		w := w
+		b.Run(w.Name+"/"+target.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			run(target, w, b.N)
+		})
+	}
+}
+
+// Report writes results to w as a table, with a row for each workload and
+// a column for each target, in the order they first appear.
+func Report(w io.Writer, results []Result) error {
+	var targets, workloads []string
+	cells := make(map[[2]string]Result)
+	seen := make(map[string]bool)
+	for _, r := range results {
+		if !seen["t:"+r.Target] {
+			seen["t:"+r.Target] = true
+			targets = append(targets, r.Target)
+		}
+		if !seen["w:"+r.Workload] {
+			seen["w:"+r.Workload] = true
+			workloads = append(workloads, r.Workload)
+		}
+		cells[[2]string{r.Workload, r.Target}] = r
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(tw, "workload\t")
+	for _, t := range targets {
+		fmt.Fprintf(tw, "%s\t", t)
+	}
+	fmt.Fprintln(tw)
+	for _, wl := range workloads {
+		fmt.Fprintf(tw, "%s\t", wl)
+		for _, t := range targets {
+			r, ok := cells[[2]string{wl, t}]
+			if !ok {
+				fmt.Fprint(tw, "-\t")
+				continue
+			}
+			fmt.Fprintf(tw, "%.0f ns/op %.1f allocs/op\t", r.NsPerOp(), r.AllocsPerOp())
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,31 @@
+package bench_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/noodlebox/clock/bench"
+)
+
+func TestCompare(t *testing.T) {
+	results := Compare(10, Workloads()...)
+	if got, want := len(results), 4*len(Workloads()); got != want {
+		t.Fatalf("Compare returned %d results, want %d", got, want)
+	}
+
+	var b strings.Builder
+	if err := Report(&b, results); err != nil {
+		t.Fatal(err)
+	}
+	report := b.String()
+	for _, name := range []string{"realtime", "mocktime", "relativetime", "steppedtime", "timer-churn"} {
+		if !strings.Contains(report, name) {
+			t.Errorf("report is missing %q:\n%s", name, report)
+		}
+	}
+}
+
+func BenchmarkRealtime(b *testing.B)     { Benchmark(b, Realtime(), Workloads()...) }
+func BenchmarkMocktime(b *testing.B)     { Benchmark(b, Mocktime(), Workloads()...) }
+func BenchmarkRelativetime(b *testing.B) { Benchmark(b, Relativetime(), Workloads()...) }
+func BenchmarkSteppedtime(b *testing.B)  { Benchmark(b, Steppedtime(), Workloads()...) }
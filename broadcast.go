@@ -0,0 +1,87 @@
+package clock
+
+import (
+	"sync"
+)
+
+// A BroadcastTimer represents a single event delivered to every subscriber,
+// from one underlying Timer on a Clock, rather than one Timer for each of
+// them, easing the load on the Clock when many goroutines wait on the same
+// instant. For a periodic event, see Metronome. A BroadcastTimer must be
+// created with NewBroadcastTimer.
+type BroadcastTimer[T Time[T, D], D Duration] struct {
+	c     Clock[T, D]
+	tm    Timer[T, D]
+	subs  []chan T
+	fired bool // Whether the timer has fired since it was last reset
+	at    T    // Time at which it fired
+
+	mu sync.Mutex // Protects subs, fired, and at
+}
+
+// NewBroadcastTimer returns a new BroadcastTimer on c that will send the
+// current time to each subscriber after at least duration d.
+func NewBroadcastTimer[T Time[T, D], D Duration](c Clock[T, D], d D) *BroadcastTimer[T, D] {
+	b := &BroadcastTimer[T, D]{c: c}
+	b.mu.Lock()
+	b.tm = c.AfterFunc(d, b.fire)
+	b.mu.Unlock()
+	return b
+}
+
+func (b *BroadcastTimer[T, D]) fire() {
+	now := b.c.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fired, b.at = true, now
+	for _, ch := range b.subs {
+		select {
+		case ch <- now:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a new channel on which the time will be sent once the
+// timer fires. If it has already fired, and not been reset since, the time
+// at which it did is sent at once. As with a Timer, the channel has a buffer
+// of one, so sending never blocks.
+func (b *BroadcastTimer[T, D]) Subscribe() <-chan T {
+	ch := make(chan T, 1)
+	b.mu.Lock()
+	b.subs = append(b.subs, ch)
+	if b.fired {
+		ch <- b.at
+	}
+	b.mu.Unlock()
+	return ch
+}
+
+// Unsubscribe stops delivery to ch, which must have been returned by
+// Subscribe. As with Timer.Stop, the channel is not closed.
+func (b *BroadcastTimer[T, D]) Unsubscribe(ch <-chan T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, s := range b.subs {
+		if s == ch {
+			b.subs = append(b.subs[:i], b.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Reset changes the timer to fire after duration d, for every subscriber.
+// It returns true if the timer had been active, false if it had fired or
+// been stopped.
+func (b *BroadcastTimer[T, D]) Reset(d D) bool {
+	b.mu.Lock()
+	b.fired = false
+	b.mu.Unlock()
+	return b.tm.Reset(d)
+}
+
+// Stop prevents the timer from firing. It returns true if the call stops
+// the timer, false if it had already fired or been stopped.
+func (b *BroadcastTimer[T, D]) Stop() bool {
+	return b.tm.Stop()
+}
@@ -0,0 +1,38 @@
+package clock_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+func TestBroadcastTimer(t *testing.T) {
+	s := steppedtime.NewClock()
+	b := NewBroadcastTimer(FromSteppedtime(s), steppedtime.Second)
+	subs := []<-chan steppedtime.Time{b.Subscribe(), b.Subscribe(), b.Subscribe()}
+	b.Unsubscribe(subs[2])
+
+	s.Step(steppedtime.Second)
+	want := steppedtime.Time(steppedtime.Second)
+	for i, ch := range subs[:2] {
+		if got := <-ch; got != want {
+			t.Errorf("subscriber %d got %v, want %v", i, got, want)
+		}
+	}
+	if got := <-b.Subscribe(); got != want {
+		t.Errorf("late subscriber got %v, want %v", got, want)
+	}
+	select {
+	case <-subs[2]:
+		t.Errorf("time delivered after Unsubscribe")
+	default:
+	}
+
+	if b.Reset(steppedtime.Second) {
+		t.Errorf("Reset() = true after firing")
+	}
+	if !b.Stop() {
+		t.Errorf("Stop() = false after Reset")
+	}
+}
@@ -0,0 +1,164 @@
+package clock
+
+import (
+	"time"
+)
+
+// Duration is an interface for the minimal API needed for a Duration
+// implementation.
+type Duration interface {
+	Seconds() float64
+}
+
+// Time is a generic interface for the minimal API needed for a Time
+// implementation.
+type Time[T any, D Duration] interface {
+	Add(D) T
+	Sub(T) D
+	After(T) bool
+	Before(T) bool
+	Equal(T) bool
+	IsZero() bool
+}
+
+// TimeCompare is a generic interface for a Time implementation that also
+// supports three-way comparison, such as steppedtime.Time, or [time.Time] as
+// of Go 1.20. Compare returns -1 if the receiver is before its argument, +1
+// if after, and 0 if they're the same instant.
+type TimeCompare[T any, D Duration] interface {
+	Time[T, D]
+	Compare(T) int
+}
+
+// LocatedTime is a generic interface for a Time implementation that also
+// represents an instant on a calendar in some Location, such as [time.Time].
+type LocatedTime[T any, D Duration] interface {
+	Time[T, D]
+
+	In(loc *time.Location) T
+	Location() *time.Location
+	UTC() T
+	Local() T
+	Date() (year int, month time.Month, day int)
+	AddDate(years, months, days int) T
+	Clock() (hour, min, sec int)
+	Weekday() time.Weekday
+	YearDay() int
+	Unix() int64
+	UnixMilli() int64
+	UnixMicro() int64
+	UnixNano() int64
+	Truncate(d D) T
+	Round(d D) T
+	Format(layout string) string
+}
+
+// Timer is a generic interface for a single event, as created by a Clock's
+// NewTimer or AfterFunc methods.
+type Timer[T any, D any] interface {
+	C() <-chan T
+	Reset(d D) bool
+	Stop() bool
+}
+
+// Ticker is a generic interface for a periodic event, as created by a
+// Clock's NewTicker method.
+type Ticker[T any, D any] interface {
+	C() <-chan T
+	Reset(d D)
+	Stop()
+}
+
+// MissedTicker is a generic interface for a Ticker that also reports the
+// number of ticks it dropped for slow receivers, so that they may detect and
+// compensate for them. Tickers supplied by every implementation in this
+// module implement it, though those of realtime count their drops only if
+// created by NewForwardingTicker.
+type MissedTicker[T any, D any] interface {
+	Ticker[T, D]
+	Missed() int
+}
+
+// PausableTicker is a generic interface for a Ticker that may be suspended
+// and later resumed in the same phase. Tickers supplied by every
+// implementation in this module implement it, though those of realtime keep
+// their phase only if created by NewForwardingTicker.
+type PausableTicker[T any, D any] interface {
+	Ticker[T, D]
+	Pause()
+	Resume()
+}
+
+// PausableTimer is a generic interface for a Timer that may be suspended
+// and later resumed with the time it had left. Timers supplied by every
+// implementation in this module implement it.
+type PausableTimer[T any, D any] interface {
+	Timer[T, D]
+	Pause() bool
+	Resume() bool
+}
+
+// PostponableTimer is a generic interface for a Timer whose deadline may be
+// shifted in place. Timers supplied by every implementation in this module
+// implement it.
+type PostponableTimer[T any, D any] interface {
+	Timer[T, D]
+	Postpone(delta D) bool
+	Advance(delta D) bool
+}
+
+// DurationFactory is a generic interface for constructing Duration values
+// from counts of common units, as provided by every Clock implementation in
+// this module. Clocks returned by [Adapt] and the helpers built on it
+// implement it, so that generic code may obtain one from a Clock by a type
+// assertion, without switching on the type of D.
+type DurationFactory[D any] interface {
+	Nanoseconds(n int64) D
+	Microseconds(n int64) D
+	Milliseconds(n int64) D
+	Seconds(n float64) D
+	Minutes(n float64) D
+	Hours(n float64) D
+}
+
+// Clock is a generic interface for the API shared by all Clock
+// implementations, modeled after the package-level functions of [time].
+// Implementations supplied by subpackages return their own concrete Timer
+// and Ticker types, and so must be adapted to satisfy Clock with [Adapt] or
+// one of the helpers built on it, such as [FromRealtime].
+type Clock[T Time[T, D], D Duration] interface {
+	Now() T
+	Since(t T) D
+	Until(t T) D
+	Sleep(d D)
+	After(d D) <-chan T
+	AfterFunc(d D, f func()) Timer[T, D]
+	NewTimer(d D) Timer[T, D]
+	NewTicker(d D) Ticker[T, D]
+	Tick(d D) <-chan T
+}
+
+// Calendar is the set of Location-dependent methods provided by a
+// LocatedClock, for constructing and parsing calendar times.
+type Calendar[T any] interface {
+	Date(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location) T
+	AddDate(t T, years, months, days int) T
+	Parse(layout, value string) (T, error)
+	ParseInLocation(layout, value string, loc *time.Location) (T, error)
+	Unix(sec int64, nsec int64) T
+	UnixMilli(msec int64) T
+	UnixMicro(usec int64) T
+	FixedZone(name string, offset int) *time.Location
+	LoadLocation(name string) (*time.Location, error)
+	LoadLocationFromTZData(name string, data []byte) (*time.Location, error)
+}
+
+// LocatedClock is a generic interface for a Clock whose Time values are
+// located on a calendar, such as those using [time.Time].
+type LocatedClock[T LocatedTime[T, D], D Duration] interface {
+	Clock[T, D]
+	Calendar[T]
+}
+
+// StdClock is the instantiation of LocatedClock using the types from [time].
+type StdClock = LocatedClock[time.Time, time.Duration]
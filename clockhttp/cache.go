@@ -0,0 +1,162 @@
+package clockhttp
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheState is the state of a cached response with respect to its
+// freshness, as reported by Freshness.State.
+type CacheState int
+
+const (
+	// Fresh means the response may be served without revalidation.
+	Fresh CacheState = iota
+	// StaleRevalidating means the response is stale, but within its
+	// stale-while-revalidate window, so it may still be served while it is
+	// revalidated in the background.
+	StaleRevalidating
+	// Stale means the response must be revalidated before it is served.
+	Stale
+)
+
+func (s CacheState) String() string {
+	switch s {
+	case Fresh:
+		return "fresh"
+	case StaleRevalidating:
+		return "stale-while-revalidate"
+	case Stale:
+		return "stale"
+	}
+	return "CacheState(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Freshness describes how long a cached response may be served, computed
+// from its headers as described by RFC 9111, section 4.2, but measured by a
+// Clock, so that a cache layer may be validated under a simulated clock.
+// Heuristic freshness is not applied, so a response without an explicit
+// lifetime is stale as soon as it is stored.
+type Freshness struct {
+	Received             time.Time     // When the response was stored, by the Clock
+	InitialAge           time.Duration // Age of the response when stored
+	Lifetime             time.Duration // Freshness lifetime, from max-age or Expires
+	StaleWhileRevalidate time.Duration // Window past Lifetime in which it may still be served
+	NoCache              bool          // Whether it must always be revalidated
+}
+
+// NewFreshness returns the Freshness of a response with headers h, stored
+// at the current time on c. Its lifetime is given by the max-age directive
+// of its Cache-Control header, or failing that, by the difference between
+// its Expires and Date headers, and its initial age by its Age and Date
+// headers.
+func NewFreshness(c Clock, h http.Header) Freshness {
+	now := c.Now()
+	f := Freshness{Received: now}
+	cc := parseCacheControl(h.Values("Cache-Control"))
+
+	date, dateErr := http.ParseTime(h.Get("Date"))
+	if dateErr == nil {
+		if apparent := now.Sub(date); apparent > 0 {
+			f.InitialAge = apparent
+		}
+	}
+	if age, err := strconv.ParseInt(h.Get("Age"), 10, 64); err == nil && age >= 0 {
+		if d := time.Duration(age) * time.Second; d > f.InitialAge {
+			f.InitialAge = d
+		}
+	}
+
+	if d, ok := cc.seconds("max-age"); ok {
+		f.Lifetime = d
+	} else if exp, err := http.ParseTime(h.Get("Expires")); err == nil {
+		if dateErr != nil {
+			date = now
+		}
+		if d := exp.Sub(date); d > 0 {
+			f.Lifetime = d
+		}
+	}
+	if d, ok := cc.seconds("stale-while-revalidate"); ok {
+		f.StaleWhileRevalidate = d
+	}
+	_, f.NoCache = cc["no-cache"]
+	if _, ok := cc["must-revalidate"]; ok {
+		f.StaleWhileRevalidate = 0
+	}
+	return f
+}
+
+// Age returns the current age of the response, as of the current time on c.
+func (f Freshness) Age(c Clock) time.Duration {
+	return f.InitialAge + c.Since(f.Received)
+}
+
+// TTL returns how much longer the response stays fresh, as of the current
+// time on c, or a value <= 0 if it is already stale.
+func (f Freshness) TTL(c Clock) time.Duration {
+	if f.NoCache {
+		return 0
+	}
+	return f.Lifetime - f.Age(c)
+}
+
+// ExpiresAt returns the time on the Clock at which the response becomes
+// stale.
+func (f Freshness) ExpiresAt() time.Time {
+	if f.NoCache {
+		return f.Received
+	}
+	return f.Received.Add(f.Lifetime - f.InitialAge)
+}
+
+// State returns whether the response is fresh, stale but still usable while
+// revalidating, or stale, as of the current time on c.
+func (f Freshness) State(c Clock) CacheState {
+	if f.NoCache {
+		return Stale
+	}
+	switch ttl := f.TTL(c); {
+	case ttl > 0:
+		return Fresh
+	case -ttl < f.StaleWhileRevalidate:
+		return StaleRevalidating
+	}
+	return Stale
+}
+
+// Directives of a Cache-Control header, with their arguments.
+type cacheControl map[string]string
+
+func parseCacheControl(values []string) cacheControl {
+	cc := make(cacheControl)
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name == "" {
+				continue
+			}
+			cc[strings.ToLower(name)] = strings.Trim(arg, `"`)
+		}
+	}
+	return cc
+}
+
+// Return the argument of a directive as a count of seconds.
+func (cc cacheControl) seconds(name string) (time.Duration, bool) {
+	arg, ok := cc[name]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	if n > int64(math.MaxInt64/time.Second) {
+		return math.MaxInt64, true
+	}
+	return time.Duration(n) * time.Second, true
+}
@@ -0,0 +1,55 @@
+package clockhttp_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/noodlebox/clock"
+	. "github.com/noodlebox/clock/clockhttp"
+	"github.com/noodlebox/clock/mocktime"
+)
+
+func TestFreshness(t *testing.T) {
+	mc := mocktime.NewClockAt(time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC))
+	c := clock.FromMocktime(mc)
+
+	h := http.Header{}
+	h.Set("Date", mc.Now().Add(-10*time.Second).Format(http.TimeFormat))
+	h.Set("Cache-Control", "public, max-age=60, stale-while-revalidate=30")
+	f := NewFreshness(c, h)
+	if f.InitialAge != 10*time.Second || f.Lifetime != time.Minute {
+		t.Errorf("NewFreshness() = %+v, want an initial age of 10s and lifetime of 1m", f)
+	}
+
+	for _, tt := range []struct {
+		step time.Duration
+		want CacheState
+	}{
+		{49 * time.Second, Fresh},
+		{time.Second, StaleRevalidating},
+		{29 * time.Second, StaleRevalidating},
+		{time.Second, Stale},
+	} {
+		mc.Step(tt.step)
+		if got := f.State(c); got != tt.want {
+			t.Errorf("State() = %v at age %v, want %v", got, f.Age(c), tt.want)
+		}
+	}
+
+	// Expires, relative to Date, and overridden by max-age
+	h = http.Header{}
+	h.Set("Date", mc.Now().Format(http.TimeFormat))
+	h.Set("Expires", mc.Now().Add(time.Hour).Format(http.TimeFormat))
+	if f := NewFreshness(c, h); f.Lifetime != time.Hour || !f.ExpiresAt().Equal(mc.Now().Add(time.Hour)) {
+		t.Errorf("NewFreshness() = %+v, want a lifetime of 1h", f)
+	}
+	h.Set("Cache-Control", "max-age=5")
+	if f := NewFreshness(c, h); f.Lifetime != 5*time.Second {
+		t.Errorf("NewFreshness() = %+v, want max-age to override Expires", f)
+	}
+	h.Set("Cache-Control", "no-cache, max-age=5")
+	if f := NewFreshness(c, h); f.State(c) != Stale {
+		t.Errorf("State() = %v with no-cache, want %v", f.State(c), Stale)
+	}
+}
@@ -0,0 +1,85 @@
+package clockhttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Transport is an [http.RoundTripper] limiting the time taken by each
+// request it makes through Base, as measured by Clock, like the Timeout
+// field of [http.Client]. The limit covers reading the response body, so a
+// request is only cancelled once its body is closed, fully read, or the
+// time limit passes. A Transport with a Timeout of zero imposes no limit.
+type Transport struct {
+	Base    http.RoundTripper // Used to make requests, or http.DefaultTransport if nil
+	Clock   Clock
+	Timeout time.Duration
+}
+
+// NewClient returns an http.Client using a Transport that limits each
+// request to timeout, as measured by c.
+func NewClient(c Clock, timeout time.Duration) *http.Client {
+	return &http.Client{Transport: &Transport{Clock: c, Timeout: timeout}}
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// RoundTrip makes the request through Base, cancelling it if it is not
+// complete within the time limit.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Timeout <= 0 {
+		return t.base().RoundTrip(req)
+	}
+	ctx, cancel := WithTimeout(req.Context(), t.Clock, t.Timeout)
+	resp, err := t.base().RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if ctx.Err() == context.DeadlineExceeded {
+			err = &timeoutError{err}
+		}
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// An error for a request that timed out, satisfying [net.Error].
+type timeoutError struct {
+	err error
+}
+
+func (e *timeoutError) Error() string {
+	return "clockhttp: request timed out: " + e.err.Error()
+}
+
+func (e *timeoutError) Unwrap() error   { return e.err }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
+
+// A response body that releases the timer limiting its request once closed
+// or fully read.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.cancel()
+	}
+	return n, err
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,131 @@
+package clockhttp_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/noodlebox/clock"
+	. "github.com/noodlebox/clock/clockhttp"
+	"github.com/noodlebox/clock/mocktime"
+)
+
+// Wait for a timer to be scheduled on mc, then step to it.
+func stepToNext(mc mocktime.Clock) {
+	for mc.NextAt().IsZero() {
+		runtime.Gosched()
+	}
+	mc.Set(mc.NextAt())
+}
+
+func TestTimeoutHandler(t *testing.T) {
+	mc := mocktime.NewClock()
+	release, errc := make(chan struct{}), make(chan error)
+	h := TimeoutHandler(clock.FromMocktime(mc), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, err := w.Write([]byte("late"))
+		errc <- err
+	}), 5*time.Second, "too slow")
+
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+		close(done)
+	}()
+	stepToNext(mc)
+	<-done
+	close(release)
+	if err := <-errc; err != http.ErrHandlerTimeout {
+		t.Errorf("Write() after timeout = %v, want %v", err, http.ErrHandlerTimeout)
+	}
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Code = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	if got := rec.Body.String(); got != "too slow" {
+		t.Errorf("Body = %q, want %q", got, "too slow")
+	}
+
+	// A handler finishing in time is unaffected
+	h = TimeoutHandler(clock.FromMocktime(mc), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("ok"))
+	}), 5*time.Second, "")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	if rec.Code != http.StatusTeapot || rec.Body.String() != "ok" {
+		t.Errorf("got %d %q, want %d %q", rec.Code, rec.Body.String(), http.StatusTeapot, "ok")
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestTransport(t *testing.T) {
+	mc := mocktime.NewClock()
+	client := &http.Client{Transport: &Transport{
+		Base: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			if dl, ok := r.Context().Deadline(); !ok || !dl.Equal(mc.Now().Add(time.Minute)) {
+				t.Errorf("Deadline() = %v, %v, want %v", dl, ok, mc.Now().Add(time.Minute))
+			}
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		}),
+		Clock:   clock.FromMocktime(mc),
+		Timeout: time.Minute,
+	}}
+
+	errc := make(chan error)
+	go func() {
+		_, err := client.Get("http://example.invalid/")
+		errc <- err
+	}()
+	stepToNext(mc)
+	err := <-errc
+	var ne net.Error
+	if !errors.As(err, &ne) || !ne.Timeout() {
+		t.Errorf("Get() = %v, want a timeout", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get() = %v, want it to wrap %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	mc := mocktime.NewClock()
+	start := mc.Now()
+	wrote := make(chan struct{})
+	h := IdleTimeout(clock.FromMocktime(mc), 10*time.Second, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-wrote
+		w.Write([]byte("progress"))
+		wrote <- struct{}{}
+		<-r.Context().Done()
+		if err := r.Context().Err(); err != context.Canceled {
+			t.Errorf("Err() = %v, want %v", err, context.Canceled)
+		}
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+		close(done)
+	}()
+	for mc.NextAt().IsZero() {
+		runtime.Gosched()
+	}
+	mc.Step(6 * time.Second)
+	wrote <- struct{}{}
+	<-wrote
+	// The write restarted the timeout
+	if got, want := mc.NextAt(), start.Add(16*time.Second); !got.Equal(want) {
+		t.Errorf("NextAt() = %v, want %v", got, want)
+	}
+	stepToNext(mc)
+	<-done
+}
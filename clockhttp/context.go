@@ -0,0 +1,63 @@
+// Package clockhttp provides timeouts for [net/http] servers and clients,
+// and freshness checks for cached responses, measured by a Clock, so that
+// such behavior may be tested under a simulated clock, such as one from
+// mocktime, without sleeping in real time.
+package clockhttp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/noodlebox/clock"
+)
+
+// Clock is the interface a clock must satisfy to measure timeouts, which
+// are given as [time.Duration] values by [net/http].
+type Clock = clock.Clock[time.Time, time.Duration]
+
+// A context done once a Timer on a Clock fires, or its parent is done.
+type timeoutCtx struct {
+	context.Context
+	deadline time.Time
+	err      error // Set to context.DeadlineExceeded if the timer fired first
+
+	mu sync.Mutex // Protects err
+}
+
+func (ctx *timeoutCtx) Deadline() (time.Time, bool) {
+	if d, ok := ctx.Context.Deadline(); ok && d.Before(ctx.deadline) {
+		return d, true
+	}
+	return ctx.deadline, true
+}
+
+func (ctx *timeoutCtx) Err() error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.err != nil {
+		return ctx.err
+	}
+	return ctx.Context.Err()
+}
+
+// WithTimeout is like [context.WithTimeout], but the timeout is measured by
+// c, and the returned context reports a deadline in c's time. As with
+// context.WithTimeout, cancel should be called once the context is no
+// longer needed, to release associated resources.
+func WithTimeout(parent context.Context, c Clock, d time.Duration) (context.Context, context.CancelFunc) {
+	inner, cancelInner := context.WithCancel(parent)
+	ctx := &timeoutCtx{Context: inner, deadline: c.Now().Add(d)}
+	tm := c.AfterFunc(d, func() {
+		ctx.mu.Lock()
+		if inner.Err() == nil {
+			ctx.err = context.DeadlineExceeded
+		}
+		ctx.mu.Unlock()
+		cancelInner()
+	})
+	return ctx, func() {
+		tm.Stop()
+		cancelInner()
+	}
+}
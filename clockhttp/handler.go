@@ -0,0 +1,109 @@
+package clockhttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TimeoutHandler is like [http.TimeoutHandler], but the time limit is
+// measured by c. It runs h with a request whose context is done once dt has
+// elapsed on c, and if h has not returned by then, responds with a 503
+// Service Unavailable error and msg in its body, or a default message if
+// msg is empty. Until h returns, its response is buffered, and after such a
+// timeout, writes by h to its ResponseWriter return [http.ErrHandlerTimeout].
+func TimeoutHandler(c Clock, h http.Handler, dt time.Duration, msg string) http.Handler {
+	if msg == "" {
+		msg = "<html><head><title>Timeout</title></head><body><h1>Timeout</h1></body></html>"
+	}
+	return &timeoutHandler{c: c, h: h, dt: dt, msg: msg}
+}
+
+type timeoutHandler struct {
+	c   Clock
+	h   http.Handler
+	dt  time.Duration
+	msg string
+}
+
+func (th *timeoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := WithTimeout(r.Context(), th.c, th.dt)
+	defer cancel()
+	r = r.WithContext(ctx)
+
+	tw := &timeoutWriter{h: make(http.Header), code: http.StatusOK}
+	done := make(chan struct{})
+	panicc := make(chan any, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				panicc <- p
+			}
+		}()
+		th.h.ServeHTTP(tw, r)
+		close(done)
+	}()
+
+	select {
+	case p := <-panicc:
+		panic(p)
+	case <-done:
+		tw.mu.Lock()
+		defer tw.mu.Unlock()
+		dst := w.Header()
+		for k, vv := range tw.h {
+			dst[k] = vv
+		}
+		w.WriteHeader(tw.code)
+		w.Write(tw.buf.Bytes())
+	case <-ctx.Done():
+		tw.mu.Lock()
+		defer tw.mu.Unlock()
+		switch err := ctx.Err(); err {
+		case context.DeadlineExceeded:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, th.msg)
+			tw.err = http.ErrHandlerTimeout
+		default:
+			tw.err = err
+		}
+	}
+}
+
+// Buffers a response until the handler returns, or the timeout passes.
+type timeoutWriter struct {
+	h     http.Header
+	buf   bytes.Buffer
+	code  int
+	wrote bool
+	err   error // Returned by writes once the response is abandoned
+
+	mu sync.Mutex
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.h
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.err != nil {
+		return 0, tw.err
+	}
+	tw.wrote = true
+	return tw.buf.Write(p)
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.err != nil || tw.wrote {
+		return
+	}
+	tw.wrote = true
+	tw.code = code
+}
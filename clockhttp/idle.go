@@ -0,0 +1,110 @@
+package clockhttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/noodlebox/clock"
+)
+
+// IdleTimeout returns a handler that runs h with a request whose context is
+// cancelled once d passes on c without any progress, that is, without h
+// reading from the request body or writing to its ResponseWriter. Each such
+// read or write restarts the timeout, so that slow but steady transfers may
+// take as long as they need, while a stalled client or handler is cut off.
+// Once cancelled, reads from the request body return the context's error.
+func IdleTimeout(c Clock, d time.Duration, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		it := &idleTimer{ctx: ctx, d: d}
+		it.mu.Lock()
+		it.tm = c.AfterFunc(d, func() {
+			it.mu.Lock()
+			it.expired = true
+			it.mu.Unlock()
+			cancel()
+		})
+		it.mu.Unlock()
+		defer it.tm.Stop()
+
+		r = r.WithContext(ctx)
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = &idleBody{ReadCloser: r.Body, it: it}
+		}
+		h.ServeHTTP(&idleWriter{ResponseWriter: w, it: it}, r)
+	})
+}
+
+// Restarts an idle timeout on each sign of progress.
+type idleTimer struct {
+	ctx     context.Context
+	d       time.Duration
+	tm      clock.Timer[time.Time, time.Duration]
+	expired bool // Set once the timeout passes, after which it stays done
+
+	mu sync.Mutex // Protects tm and expired
+}
+
+// Restart the timeout, unless it has already passed.
+func (it *idleTimer) touch() {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if !it.expired {
+		it.tm.Reset(it.d)
+	}
+}
+
+// A request body restarting an idle timeout on each read.
+type idleBody struct {
+	io.ReadCloser
+	it *idleTimer
+}
+
+func (b *idleBody) Read(p []byte) (int, error) {
+	if err := b.it.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.it.touch()
+	}
+	return n, err
+}
+
+// A ResponseWriter restarting an idle timeout on each write.
+type idleWriter struct {
+	http.ResponseWriter
+	it *idleTimer
+}
+
+func (w *idleWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	if n > 0 {
+		w.it.touch()
+	}
+	return n, err
+}
+
+func (w *idleWriter) WriteHeader(code int) {
+	w.ResponseWriter.WriteHeader(code)
+	w.it.touch()
+}
+
+// Flush sends any buffered data to the client, if the underlying
+// ResponseWriter supports it.
+func (w *idleWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter, for use by
+// [http.ResponseController].
+func (w *idleWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
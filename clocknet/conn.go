@@ -0,0 +1,110 @@
+// Package clocknet adapts network connections to enforce deadlines measured
+// by a Clock, so that network I/O respects a simulated clock's notion of
+// timeouts, even as it runs scaled, paused, or stepped relative to real
+// time.
+package clocknet
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/noodlebox/clock"
+)
+
+// Clock is the interface a clock must satisfy to enforce deadlines, which
+// are given as [time.Time] values by [net.Conn].
+type Clock = clock.Clock[time.Time, time.Duration]
+
+// A deadline far enough in the past to fail any operation at once.
+var past = time.Unix(1, 0)
+
+// WrapConn returns a Conn reading from and writing to conn, whose
+// deadlines are given in c's time. Rather than pass a deadline on to conn,
+// which would measure it in real time, a Timer on c is armed for it, and
+// only once that Timer fires is conn's deadline moved into the past,
+// failing any blocked or later operation with a timeout error, as
+// [os.ErrDeadlineExceeded]. Until then, conn is left without a deadline, so
+// that a paused clock never times out. Setting a new deadline, or a zero
+// one, stops any pending Timer and clears conn's deadline again.
+func WrapConn(c Clock, conn net.Conn) *Conn {
+	cc := &Conn{Conn: conn}
+	cc.rd = deadline{c: c, set: conn.SetReadDeadline}
+	cc.wd = deadline{c: c, set: conn.SetWriteDeadline}
+	return cc
+}
+
+// Conn is a net.Conn whose deadlines are measured by a Clock, as returned
+// by WrapConn.
+type Conn struct {
+	net.Conn
+	rd, wd deadline
+}
+
+// SetDeadline sets both the read and write deadlines, as measured by the
+// Clock. A zero value for t means I/O operations will not time out.
+func (c *Conn) SetDeadline(t time.Time) error {
+	if err := c.rd.reset(t); err != nil {
+		return err
+	}
+	return c.wd.reset(t)
+}
+
+// SetReadDeadline sets the deadline for future and pending Read calls, as
+// measured by the Clock. A zero value for t means Read will not time out.
+func (c *Conn) SetReadDeadline(t time.Time) error { return c.rd.reset(t) }
+
+// SetWriteDeadline sets the deadline for future and pending Write calls, as
+// measured by the Clock. A zero value for t means Write will not time out.
+func (c *Conn) SetWriteDeadline(t time.Time) error { return c.wd.reset(t) }
+
+// Close closes the connection, stopping any pending deadline Timers.
+func (c *Conn) Close() error {
+	c.rd.reset(time.Time{})
+	c.wd.reset(time.Time{})
+	return c.Conn.Close()
+}
+
+// Unwrap returns the underlying connection.
+func (c *Conn) Unwrap() net.Conn { return c.Conn }
+
+// Deadline for one direction of a connection.
+type deadline struct {
+	c     Clock
+	set   func(time.Time) error // Sets the underlying deadline
+	timer clock.Timer[time.Time, time.Duration]
+	gen   uint64 // Incremented on each reset, to ignore stale timers
+
+	mu sync.Mutex // Protects timer and gen, and serializes calls to set
+}
+
+// Set the deadline. A zero value for t means no deadline.
+func (d *deadline) reset(t time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.gen++
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if t.IsZero() {
+		return d.set(time.Time{})
+	}
+	dur := d.c.Until(t)
+	if dur <= 0 {
+		return d.set(past)
+	}
+	if err := d.set(time.Time{}); err != nil {
+		return err
+	}
+	gen := d.gen
+	d.timer = d.c.AfterFunc(dur, func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if d.gen == gen {
+			d.set(past)
+		}
+	})
+	return nil
+}
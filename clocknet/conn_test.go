@@ -0,0 +1,56 @@
+package clocknet_test
+
+import (
+	"errors"
+	"net"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/noodlebox/clock"
+	. "github.com/noodlebox/clock/clocknet"
+	"github.com/noodlebox/clock/mocktime"
+)
+
+func TestWrapConn(t *testing.T) {
+	m := mocktime.NewClock()
+	m.Stop()
+	a, b := net.Pipe()
+	defer b.Close()
+	c := WrapConn(clock.FromMocktime(m), a)
+	defer c.Close()
+
+	c.SetReadDeadline(m.Now().Add(time.Hour))
+	errc := make(chan error)
+	go func() {
+		_, err := c.Read(make([]byte, 1))
+		errc <- err
+	}()
+
+	// Real time passing has no effect on a stopped clock
+	select {
+	case err := <-errc:
+		t.Fatalf("Read() = %v before the deadline", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	for m.NextAt().IsZero() {
+		runtime.Gosched()
+	}
+	m.Step(time.Hour)
+	if err := <-errc; !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Read() = %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+
+	// A deadline already passed fails at once, and clearing it recovers
+	c.SetWriteDeadline(m.Now().Add(-time.Second))
+	if _, err := c.Write([]byte("x")); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Write() = %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+	c.SetDeadline(time.Time{})
+	go b.Read(make([]byte, 1))
+	if _, err := c.Write([]byte("x")); err != nil {
+		t.Errorf("Write() after clearing deadline = %v", err)
+	}
+}
@@ -0,0 +1,4 @@
+// Package clocktest provides utilities for testing code that uses the
+// interfaces defined in [github.com/noodlebox/clock], such as recording
+// traces of timer activity for comparison against golden files.
+package clocktest
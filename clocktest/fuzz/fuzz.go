@@ -0,0 +1,174 @@
+// Package fuzz provides a property-based harness that applies random
+// sequences of timer operations to a clock, checking that timers fire
+// neither early nor late with respect to a simple model, and that the
+// queue of pending timers stays consistent.
+package fuzz
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/clocktest"
+	"github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/relativetime"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+// Target describes a clock to be exercised by Run. The clock must only
+// advance when stepped, and its timers must deliver values on their
+// channels before Step returns.
+type Target[T clock.Time[T, D], D clock.Duration] struct {
+	Name  string
+	Clock clock.Clock[T, D]
+
+	Step        func(d D)           // Advances the clock by d
+	Nanoseconds func(n int64) D     // Returns a duration of n nanoseconds
+	SetScale    func(scale float64) // Optional
+	NextAt      func() T            // Optional, checked against the model
+	Check       func() error        // Optional, checks internal consistency
+}
+
+// Steppedtime returns a Target for a new steppedtime Clock.
+func Steppedtime() Target[steppedtime.Time, steppedtime.Duration] {
+	c := steppedtime.NewClock()
+	return Target[steppedtime.Time, steppedtime.Duration]{
+		Name:        "steppedtime",
+		Clock:       clock.FromSteppedtime(c),
+		Step:        c.Step,
+		Nanoseconds: c.Nanoseconds,
+		Check:       c.CheckQueue,
+	}
+}
+
+// Relativetime returns a Target for a new, stopped relativetime Clock on a
+// steppedtime reference, exercised directly rather than through mocktime.
+func Relativetime() Target[steppedtime.Time, steppedtime.Duration] {
+	ref := steppedtime.NewClock()
+	c := relativetime.NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	return Target[steppedtime.Time, steppedtime.Duration]{
+		Name:        "relativetime",
+		Clock:       clock.FromRelativetime(c),
+		Step:        c.Step,
+		Nanoseconds: ref.Nanoseconds,
+		SetScale:    c.SetScale,
+		NextAt:      c.NextAt,
+		Check:       c.CheckQueue,
+	}
+}
+
+// Mocktime returns a Target for a new, stopped mocktime Clock.
+func Mocktime() Target[mocktime.Time, mocktime.Duration] {
+	c := mocktime.NewClock()
+	return Target[mocktime.Time, mocktime.Duration]{
+		Name:        "mocktime",
+		Clock:       clock.FromMocktime(c),
+		Step:        c.Step,
+		Nanoseconds: c.Nanoseconds,
+		SetScale:    c.SetScale,
+		NextAt:      c.NextAt,
+		Check:       c.CheckQueue,
+	}
+}
+
+type timer[T clock.Time[T, D], D clock.Duration] struct {
+	t      clock.Timer[T, D]
+	when   T
+	active bool
+}
+
+// Run applies n random operations, chosen deterministically from seed, to
+// target, reporting the first violated invariant as a fatal error on t,
+// along with the sequence of operations leading up to it.
+func Run[T clock.Time[T, D], D clock.Duration](t clocktest.TB, target Target[T, D], seed int64, n int) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	c := target.Clock
+	var (
+		timers []*timer[T, D]
+		log    []string
+	)
+	fail := func(format string, args ...any) {
+		t.Helper()
+		t.Fatalf("%s (seed %d): %s\noperations:\n\t%s", target.Name, seed, fmt.Sprintf(format, args...), strings.Join(log, "\n\t"))
+	}
+	duration := func() (int64, D) {
+		n := rng.Int63n(1000) + 1
+		return n, target.Nanoseconds(n)
+	}
+
+	for i := 0; i < n; i++ {
+		switch op := rng.Intn(10); {
+		case op < 3 || len(timers) == 0:
+			n, d := duration()
+			log = append(log, fmt.Sprintf("NewTimer(%dns) -> #%d", n, len(timers)))
+			timers = append(timers, &timer[T, D]{
+				t:      c.NewTimer(d),
+				when:   c.Now().Add(d),
+				active: true,
+			})
+		case op < 5:
+			j := rng.Intn(len(timers))
+			n, d := duration()
+			log = append(log, fmt.Sprintf("#%d.Reset(%dns)", j, n))
+			tm := timers[j]
+			if active := tm.t.Reset(d); active != tm.active {
+				fail("Reset returned %v, want %v", active, tm.active)
+			}
+			tm.when, tm.active = c.Now().Add(d), true
+		case op < 7:
+			j := rng.Intn(len(timers))
+			log = append(log, fmt.Sprintf("#%d.Stop()", j))
+			tm := timers[j]
+			if active := tm.t.Stop(); active != tm.active {
+				fail("Stop returned %v, want %v", active, tm.active)
+			}
+			tm.active = false
+		case op < 8 && target.SetScale != nil:
+			scale := float64(rng.Intn(4)) / 2
+			log = append(log, fmt.Sprintf("SetScale(%v)", scale))
+			target.SetScale(scale)
+		default:
+			n, d := duration()
+			log = append(log, fmt.Sprintf("Step(%dns)", n))
+			target.Step(d)
+		}
+
+		if target.Check != nil {
+			if err := target.Check(); err != nil {
+				fail("inconsistent queue: %v", err)
+			}
+		}
+		now := c.Now()
+		var next *T
+		for j, tm := range timers {
+			select {
+			case at := <-tm.t.C():
+				if !tm.active {
+					fail("inactive timer #%d fired at %v", j, at)
+				}
+				if at.Before(tm.when) {
+					fail("timer #%d fired early at %v, want %v", j, at, tm.when)
+				}
+				tm.active = false
+			default:
+				if tm.active && !tm.when.After(now) {
+					fail("timer #%d did not fire at %v, now %v", j, tm.when, now)
+				}
+			}
+			if tm.active && (next == nil || tm.when.Before(*next)) {
+				next = &tm.when
+			}
+		}
+		if target.NextAt != nil {
+			got := target.NextAt()
+			switch {
+			case next == nil && !got.IsZero():
+				fail("NextAt returned %v with no active timers", got)
+			case next != nil && !got.Equal(*next):
+				fail("NextAt returned %v, want %v", got, *next)
+			}
+		}
+	}
+}
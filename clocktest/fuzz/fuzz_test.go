@@ -0,0 +1,37 @@
+package fuzz_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/noodlebox/clock/clocktest/fuzz"
+)
+
+func FuzzSteppedtime(f *testing.F) {
+	for seed := int64(0); seed < 8; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		Run(t, Steppedtime(), seed, 200)
+	})
+}
+
+func FuzzMocktime(f *testing.F) {
+	for seed := int64(0); seed < 8; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		Run(t, Mocktime(), seed, 200)
+	})
+}
+
+func FuzzRelativetime(f *testing.F) {
+	// Spread timers over several shards
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	for seed := int64(0); seed < 8; seed++ {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		Run(t, Relativetime(), seed, 200)
+	})
+}
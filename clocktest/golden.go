@@ -0,0 +1,88 @@
+package clocktest
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// UpdateEnv names an environment variable which, when set to a non-empty
+// value, causes golden files to be rewritten rather than compared.
+const UpdateEnv = "CLOCKTEST_UPDATE"
+
+// TB is the subset of [testing.TB] used to report failures.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// Golden compares the trace recorded by r against the golden file at path,
+// reporting an error on t with a line-by-line diff if they differ. If the
+// environment variable named by UpdateEnv is set, the golden file is
+// written with the current trace instead.
+func (r *Recorder[T, D]) Golden(t TB, path string) {
+	t.Helper()
+	got := r.String()
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("clocktest: updating golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("clocktest: golden file %s does not exist; set %s=1 to create it", path, UpdateEnv)
+	} else if err != nil {
+		t.Fatalf("clocktest: reading golden file: %v", err)
+	}
+	if d := Diff(string(want), got); d != "" {
+		t.Errorf("clocktest: trace differs from %s (-want +got):\n%s", path, d)
+	}
+}
+
+// Diff returns a line-by-line diff between want and got, with removed lines
+// prefixed by "-" and added lines prefixed by "+", or an empty string if
+// they are equal.
+func Diff(want, got string) string {
+	if want == got {
+		return ""
+	}
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// Longest common subsequence, by suffix
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			out.WriteString(" " + a[i] + "\n")
+			i, j = i+1, j+1
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			out.WriteString("+" + b[j] + "\n")
+			j++
+		default:
+			out.WriteString("-" + a[i] + "\n")
+			i++
+		}
+	}
+	return out.String()
+}
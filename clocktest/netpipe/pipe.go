@@ -0,0 +1,239 @@
+// Package netpipe provides an in-memory, full duplex network connection
+// whose deadlines are enforced by a Clock, so that code handling timeouts
+// may be tested under a simulated clock without waiting in real time.
+package netpipe
+
+import (
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/noodlebox/clock"
+)
+
+// Clock is the interface a clock must satisfy to enforce deadlines, which
+// are given as [time.Time] values by [net.Conn].
+type Clock = clock.Clock[time.Time, time.Duration]
+
+// Maximum number of bytes written but not yet read, in each direction.
+const bufferSize = 64 << 10
+
+// Pipe creates a pair of connected net.Conn values, each reading what the
+// other writes. Writes are buffered, up to a limit, after which they block
+// until the peer reads. Deadlines are measured and enforced by c.
+func Pipe(c Clock) (net.Conn, net.Conn) {
+	a, b := newBuffer(), newBuffer()
+	return newConn(c, a, b), newConn(c, b, a)
+}
+
+// One direction of a pipe.
+type buffer struct {
+	data   []byte
+	rDone  bool          // Closed by the reader
+	wDone  bool          // Closed by the writer
+	notify chan struct{} // Closed and replaced on any change
+
+	mu sync.Mutex // Protects all fields
+}
+
+func newBuffer() *buffer {
+	return &buffer{notify: make(chan struct{})}
+}
+
+// Wake anyone waiting on a change. Callers must hold the lock.
+func (b *buffer) signal() {
+	close(b.notify)
+	b.notify = make(chan struct{})
+}
+
+// Deadline for an operation, modeled after the one used by [net.Pipe].
+type deadline struct {
+	c      Clock
+	timer  clock.Timer[time.Time, time.Duration]
+	cancel chan struct{} // Closed once the deadline has passed
+
+	mu sync.Mutex // Protects timer and cancel
+}
+
+func newDeadline(c Clock) *deadline {
+	return &deadline{c: c, cancel: make(chan struct{})}
+}
+
+// Set the deadline. A zero value for t means no deadline.
+func (d *deadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil && !d.timer.Stop() && !isClosed(d.cancel) {
+		// The callback may be on its way to close cancel, or never run at
+		// all if the clock was closed, so rather than wait for it, leave it
+		// a channel no longer used. Anyone waiting on that one wakes to
+		// check again.
+		d.cancel = make(chan struct{})
+	}
+	d.timer = nil
+
+	closed := isClosed(d.cancel)
+	if t.IsZero() {
+		if closed {
+			d.cancel = make(chan struct{})
+		}
+		return
+	}
+	if dur := d.c.Until(t); dur > 0 {
+		if closed {
+			d.cancel = make(chan struct{})
+		}
+		cancel := d.cancel
+		d.timer = d.c.AfterFunc(dur, func() { close(cancel) })
+		return
+	}
+	if !closed {
+		close(d.cancel)
+	}
+}
+
+// Return a channel that is closed once the deadline has passed.
+func (d *deadline) wait() chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.cancel
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+type addr struct{}
+
+func (addr) Network() string { return "pipe" }
+func (addr) String() string  { return "pipe" }
+
+type conn struct {
+	rx, tx *buffer
+	rd, wd *deadline
+
+	done chan struct{} // Closed by Close
+	once sync.Once
+}
+
+func newConn(c Clock, rx, tx *buffer) *conn {
+	return &conn{
+		rx:   rx,
+		tx:   tx,
+		rd:   newDeadline(c),
+		wd:   newDeadline(c),
+		done: make(chan struct{}),
+	}
+}
+
+func (c *conn) Read(p []byte) (int, error) {
+	for {
+		switch {
+		case isClosed(c.done):
+			return 0, io.ErrClosedPipe
+		case isClosed(c.rd.wait()):
+			return 0, os.ErrDeadlineExceeded
+		}
+
+		c.rx.mu.Lock()
+		if len(c.rx.data) > 0 {
+			n := copy(p, c.rx.data)
+			c.rx.data = c.rx.data[n:]
+			c.rx.signal()
+			c.rx.mu.Unlock()
+			return n, nil
+		}
+		if c.rx.wDone {
+			c.rx.mu.Unlock()
+			return 0, io.EOF
+		}
+		notify := c.rx.notify
+		c.rx.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-c.rd.wait():
+		case <-c.done:
+		}
+	}
+}
+
+func (c *conn) Write(p []byte) (n int, err error) {
+	for {
+		switch {
+		case isClosed(c.done):
+			return n, io.ErrClosedPipe
+		case isClosed(c.wd.wait()):
+			return n, os.ErrDeadlineExceeded
+		}
+
+		c.tx.mu.Lock()
+		if c.tx.rDone {
+			c.tx.mu.Unlock()
+			return n, io.ErrClosedPipe
+		}
+		if k := bufferSize - len(c.tx.data); k > 0 {
+			if k > len(p) {
+				k = len(p)
+			}
+			c.tx.data = append(c.tx.data, p[:k]...)
+			p = p[k:]
+			n += k
+			c.tx.signal()
+		}
+		if len(p) == 0 {
+			c.tx.mu.Unlock()
+			return n, nil
+		}
+		notify := c.tx.notify
+		c.tx.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-c.wd.wait():
+		case <-c.done:
+		}
+	}
+}
+
+func (c *conn) Close() error {
+	c.once.Do(func() {
+		close(c.done)
+		c.rx.mu.Lock()
+		c.rx.rDone = true
+		c.rx.signal()
+		c.rx.mu.Unlock()
+		c.tx.mu.Lock()
+		c.tx.wDone = true
+		c.tx.signal()
+		c.tx.mu.Unlock()
+	})
+	return nil
+}
+
+func (c *conn) LocalAddr() net.Addr  { return addr{} }
+func (c *conn) RemoteAddr() net.Addr { return addr{} }
+
+func (c *conn) SetDeadline(t time.Time) error {
+	c.rd.set(t)
+	c.wd.set(t)
+	return nil
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	c.rd.set(t)
+	return nil
+}
+
+func (c *conn) SetWriteDeadline(t time.Time) error {
+	c.wd.set(t)
+	return nil
+}
@@ -0,0 +1,103 @@
+package netpipe_test
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/noodlebox/clock"
+	. "github.com/noodlebox/clock/clocktest/netpipe"
+	"github.com/noodlebox/clock/mocktime"
+)
+
+func TestPipe(t *testing.T) {
+	a, b := Pipe(clock.FromMocktime(mocktime.NewClock()))
+	go func() {
+		a.Write([]byte("hello"))
+		a.Close()
+	}()
+	got, err := io.ReadAll(b)
+	if err != nil || string(got) != "hello" {
+		t.Errorf("ReadAll() = %q, %v; want %q, nil", got, err, "hello")
+	}
+	if _, err := b.Write([]byte("x")); err != io.ErrClosedPipe {
+		t.Errorf("Write to closed peer = %v, want %v", err, io.ErrClosedPipe)
+	}
+}
+
+func TestReadDeadline(t *testing.T) {
+	m := mocktime.NewClock()
+	m.Stop()
+	a, _ := Pipe(clock.FromMocktime(m))
+	a.SetReadDeadline(m.Now().Add(mocktime.Second))
+
+	errc := make(chan error)
+	go func() {
+		_, err := a.Read(make([]byte, 1))
+		errc <- err
+	}()
+	m.Step(mocktime.Second)
+	err := <-errc
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("Read() = %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+	if ne, ok := err.(net.Error); !ok || !ne.Timeout() {
+		t.Errorf("Read() error %v is not a timeout", err)
+	}
+
+	// Extending the deadline allows reads again
+	a.SetReadDeadline(m.Now().Add(mocktime.Second))
+	go func() {
+		_, err := a.Read(make([]byte, 1))
+		errc <- err
+	}()
+	select {
+	case err := <-errc:
+		t.Errorf("Read() = %v before the extended deadline", err)
+	default:
+	}
+	a.SetReadDeadline(m.Now())
+	if err := <-errc; !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Read() = %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+}
+
+func TestWriteDeadline(t *testing.T) {
+	m := mocktime.NewClock()
+	m.Stop()
+	a, _ := Pipe(clock.FromMocktime(m))
+	a.SetWriteDeadline(m.Now().Add(mocktime.Second))
+
+	errc := make(chan error)
+	go func() {
+		// Larger than the buffer, so blocks until the deadline
+		_, err := a.Write(make([]byte, 1<<20))
+		errc <- err
+	}()
+	m.Step(mocktime.Second)
+	if err := <-errc; !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Write() = %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+}
+
+func TestDeadlineAfterClockClose(t *testing.T) {
+	m := mocktime.NewClock()
+	a, b := Pipe(clock.FromMocktime(m))
+	a.SetReadDeadline(m.Now().Add(mocktime.Hour))
+	m.Close() // Cancels the deadline's timer without running its callback
+
+	// Must neither hang nor leave the deadline in effect
+	a.SetReadDeadline(time.Time{})
+	go b.Write([]byte("x"))
+	if n, err := a.Read(make([]byte, 1)); n != 1 || err != nil {
+		t.Errorf("Read() = %d, %v; want 1, nil", n, err)
+	}
+	a.SetWriteDeadline(m.Now().Add(mocktime.Hour))
+	a.SetWriteDeadline(m.Now().Add(-mocktime.Hour))
+	if _, err := a.Write([]byte("x")); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Write() = %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+}
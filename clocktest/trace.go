@@ -0,0 +1,316 @@
+package clocktest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/noodlebox/clock"
+)
+
+// Event is a single entry in a trace recorded by a Recorder.
+type Event[T clock.Time[T, D], D clock.Duration] struct {
+	Seq    int    // Order in which the event was recorded
+	At     T      // Time of the event; the deadline, for fired events
+	Kind   string // One of "new", "reset", "stop", or "fire"
+	ID     int    // Identifies the timer or ticker
+	Label  string // Label of the Recorder that created the timer
+	Ticker bool   // True for events on a ticker
+	D      D      // Duration given to new or reset timers and tickers
+}
+
+type trace[T clock.Time[T, D], D clock.Duration] struct {
+	c      clock.Clock[T, D]
+	start  T
+	events []Event[T, D]
+	nextID int
+
+	mu sync.Mutex
+}
+
+func (tr *trace[T, D]) record(e Event[T, D]) {
+	tr.mu.Lock()
+	e.Seq = len(tr.events)
+	tr.events = append(tr.events, e)
+	tr.mu.Unlock()
+}
+
+func (tr *trace[T, D]) newID() (id int) {
+	tr.mu.Lock()
+	tr.nextID++
+	id = tr.nextID
+	tr.mu.Unlock()
+	return
+}
+
+// Recorder wraps a Clock, recording a trace of the creation, resetting,
+// stopping, and firing of the timers and tickers created with it. It
+// satisfies [clock.Clock], so it may be passed directly to the code under
+// test. Timers and tickers are implemented using AfterFunc on the wrapped
+// clock, so that their firing may be observed.
+type Recorder[T clock.Time[T, D], D clock.Duration] struct {
+	*trace[T, D]
+	label string
+}
+
+// NewRecorder returns a Recorder wrapping c, with its trace starting at the
+// current time on c.
+func NewRecorder[T clock.Time[T, D], D clock.Duration](c clock.Clock[T, D]) *Recorder[T, D] {
+	return &Recorder[T, D]{trace: &trace[T, D]{c: c, start: c.Now()}}
+}
+
+// WithLabel returns a Recorder sharing a trace with r, but labeling the
+// timers and tickers it creates with label.
+func (r *Recorder[T, D]) WithLabel(label string) *Recorder[T, D] {
+	return &Recorder[T, D]{r.trace, label}
+}
+
+// Events returns the events recorded so far, in canonical order: sorted by
+// time, then by timer, then in the order they were recorded. Fired events
+// are recorded at their deadline rather than the time they were observed,
+// so the order is deterministic as long as the code under test is.
+func (r *Recorder[T, D]) Events() []Event[T, D] {
+	r.mu.Lock()
+	events := append([]Event[T, D](nil), r.events...)
+	r.mu.Unlock()
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if !a.At.Equal(b.At) {
+			return a.At.Before(b.At)
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Seq < b.Seq
+	})
+	return events
+}
+
+// String formats the recorded events in canonical order, one per line, with
+// times given relative to the start of the trace.
+func (r *Recorder[T, D]) String() string {
+	var b strings.Builder
+	for _, e := range r.Events() {
+		kind := "timer"
+		if e.Ticker {
+			kind = "ticker"
+		}
+		fmt.Fprintf(&b, "%v %s %s#%d", e.At.Sub(r.start), e.Kind, kind, e.ID)
+		if e.Label != "" {
+			fmt.Fprintf(&b, " %q", e.Label)
+		}
+		if e.Kind == "new" || e.Kind == "reset" {
+			fmt.Fprintf(&b, " %v", e.D)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Now returns the current time on the wrapped clock.
+func (r *Recorder[T, D]) Now() T { return r.c.Now() }
+
+// Since returns the time elapsed since t on the wrapped clock.
+func (r *Recorder[T, D]) Since(t T) D { return r.c.Since(t) }
+
+// Until returns the duration until t on the wrapped clock.
+func (r *Recorder[T, D]) Until(t T) D { return r.c.Until(t) }
+
+// Sleep pauses the current goroutine for at least the duration d, recording
+// it as a timer. A negative or zero duration causes Sleep to return
+// immediately.
+func (r *Recorder[T, D]) Sleep(d D) {
+	if d.Seconds() <= 0 {
+		return
+	}
+	<-r.NewTimer(d).C()
+}
+
+// After is equivalent to r.NewTimer(d).C().
+func (r *Recorder[T, D]) After(d D) <-chan T {
+	return r.NewTimer(d).C()
+}
+
+// Tick is equivalent to r.NewTicker(d).C(), but returns nil if d <= 0.
+func (r *Recorder[T, D]) Tick(d D) <-chan T {
+	if d.Seconds() <= 0 {
+		return nil
+	}
+	return r.NewTicker(d).C()
+}
+
+// NewTimer creates a new recorded Timer that will send the current time on
+// its channel after at least duration d.
+func (r *Recorder[T, D]) NewTimer(d D) clock.Timer[T, D] {
+	ch := make(chan T, 1)
+	return r.newTimer(d, ch, func(now T) {
+		select {
+		case ch <- now:
+		default:
+		}
+	})
+}
+
+// AfterFunc creates a new recorded Timer that calls f after at least
+// duration d. Unlike most clocks, it does not start a goroutine of its own:
+// f is called directly from the function the recorded clock calls when its
+// underlying Timer fires, and so in whichever goroutine that clock runs it.
+func (r *Recorder[T, D]) AfterFunc(d D, f func()) clock.Timer[T, D] {
+	return r.newTimer(d, nil, func(T) { f() })
+}
+
+// NewTicker creates a new recorded Ticker that will send the current time
+// on its channel after each period d. The duration d must be greater than
+// zero; if not, NewTicker will panic.
+func (r *Recorder[T, D]) NewTicker(d D) clock.Ticker[T, D] {
+	if d.Seconds() <= 0 {
+		panic("non-positive interval for clocktest.Recorder.NewTicker")
+	}
+	ch := make(chan T, 1)
+	t := &recTicker[T, D]{recTimer[T, D]{
+		r:      r,
+		id:     r.newID(),
+		c:      ch,
+		ticker: true,
+	}}
+	t.f = func(now T) {
+		select {
+		case ch <- now:
+		default:
+		}
+	}
+	t.mu.Lock()
+	t.arm(d)
+	t.mu.Unlock()
+	return t
+}
+
+func (r *Recorder[T, D]) newTimer(d D, ch chan T, f func(T)) *recTimer[T, D] {
+	t := &recTimer[T, D]{
+		r:  r,
+		id: r.newID(),
+		c:  ch,
+		f:  f,
+	}
+	t.mu.Lock()
+	t.arm(d)
+	t.mu.Unlock()
+	return t
+}
+
+type recTimer[T clock.Time[T, D], D clock.Duration] struct {
+	r      *Recorder[T, D]
+	id     int
+	c      chan T
+	f      func(T)
+	ticker bool
+
+	t      clock.Timer[T, D] // Underlying timer, created by AfterFunc
+	when   T
+	period D
+	active bool
+
+	mu sync.Mutex
+}
+
+func (t *recTimer[T, D]) event(kind string, at T, d D) {
+	t.r.record(Event[T, D]{
+		At:     at,
+		Kind:   kind,
+		ID:     t.id,
+		Label:  t.r.label,
+		Ticker: t.ticker,
+		D:      d,
+	})
+}
+
+// arm records and schedules the timer to fire after d. Callers must hold
+// the lock.
+func (t *recTimer[T, D]) arm(d D) {
+	now := t.r.c.Now()
+	kind := "reset"
+	if t.t == nil {
+		kind = "new"
+	}
+	t.event(kind, now, d)
+	if t.ticker {
+		t.period = d
+	}
+	t.schedule(now.Add(d))
+}
+
+// schedule sets the underlying timer to fire at when. Callers must hold the
+// lock.
+func (t *recTimer[T, D]) schedule(when T) {
+	t.when, t.active = when, true
+	d := t.r.c.Until(when)
+	if t.t == nil {
+		t.t = t.r.c.AfterFunc(d, t.fire)
+	} else {
+		t.t.Reset(d)
+	}
+}
+
+func (t *recTimer[T, D]) fire() {
+	t.mu.Lock()
+	now := t.r.c.Now()
+	if !t.active || now.Before(t.when) {
+		// Stopped or reset since this call was triggered
+		t.mu.Unlock()
+		return
+	}
+	var zero D
+	t.event("fire", t.when, zero)
+	if t.ticker {
+		next := t.when.Add(t.period)
+		if !next.After(now) {
+			// Drop ticks to make up for a large step
+			next = now.Add(t.period)
+		}
+		t.schedule(next)
+	} else {
+		t.active = false
+	}
+	f := t.f
+	t.mu.Unlock()
+	f(now)
+}
+
+func (t *recTimer[T, D]) C() <-chan T {
+	return t.c
+}
+
+func (t *recTimer[T, D]) Reset(d D) (active bool) {
+	t.mu.Lock()
+	active = t.active
+	t.arm(d)
+	t.mu.Unlock()
+	return
+}
+
+func (t *recTimer[T, D]) Stop() (active bool) {
+	t.mu.Lock()
+	active = t.active
+	t.active = false
+	t.t.Stop()
+	var zero D
+	t.event("stop", t.r.c.Now(), zero)
+	t.mu.Unlock()
+	return
+}
+
+type recTicker[T clock.Time[T, D], D clock.Duration] struct {
+	recTimer[T, D]
+}
+
+func (t *recTicker[T, D]) Reset(d D) {
+	if d.Seconds() <= 0 {
+		panic("non-positive interval for clocktest.Recorder.Ticker.Reset")
+	}
+	t.recTimer.Reset(d)
+}
+
+func (t *recTicker[T, D]) Stop() {
+	t.recTimer.Stop()
+}
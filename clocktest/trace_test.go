@@ -0,0 +1,39 @@
+package clocktest_test
+
+import (
+	"testing"
+
+	"github.com/noodlebox/clock"
+	. "github.com/noodlebox/clock/clocktest"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+func TestRecorder(t *testing.T) {
+	s := steppedtime.NewClock()
+	r := NewRecorder(clock.FromSteppedtime(s))
+
+	tk := r.WithLabel("poll").NewTicker(3 * steppedtime.Second)
+	tm := r.WithLabel("timeout").NewTimer(10 * steppedtime.Second)
+	for i := 1; i <= 7; i++ {
+		s.Step(steppedtime.Second)
+		if i%3 == 0 {
+			<-tk.C()
+			tm.Reset(10 * steppedtime.Second)
+		}
+	}
+	tk.Stop()
+	tm.Stop()
+
+	r.Golden(t, "testdata/recorder.golden")
+}
+
+func TestDiff(t *testing.T) {
+	want := "a\nb\nc\n"
+	got := "a\nc\nd\n"
+	if d, expected := Diff(want, got), " a\n-b\n c\n+d\n"; d != expected {
+		t.Errorf("Diff() =\n%s\nwant\n%s", d, expected)
+	}
+	if d := Diff(want, want); d != "" {
+		t.Errorf("Diff() of equal strings = %q, want empty", d)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build go1.20
+
+package clock
+
+import (
+	"time"
+)
+
+// Ensure that time.Time supports three-way comparison, where available.
+var _ TimeCompare[time.Time, time.Duration] = time.Time{}
@@ -0,0 +1,25 @@
+package clock_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+// latest returns the latest of ts, using only the generic interface.
+func latest[T TimeCompare[T, D], D Duration](ts ...T) (max T) {
+	for i, t := range ts {
+		if i == 0 || t.Compare(max) > 0 {
+			max = t
+		}
+	}
+	return
+}
+
+func TestTimeCompare(t *testing.T) {
+	ts := []steppedtime.Time{2, 3, 1}
+	if got := latest[steppedtime.Time, steppedtime.Duration](ts...); got != 3 {
+		t.Errorf("latest(%v) = %v, want 3", ts, got)
+	}
+}
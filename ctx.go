@@ -0,0 +1,112 @@
+package clock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/noodlebox/clock/internal/ctxkey"
+	"github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/realtime"
+)
+
+// NewContext returns a copy of ctx carrying c, to be retrieved with
+// FromContext. This allows a library to use the clock chosen by its caller
+// without requiring it as a parameter.
+func NewContext(ctx context.Context, c StdClock) context.Context {
+	return context.WithValue(ctx, ctxkey.Key{}, c)
+}
+
+// FromContext returns the clock carried by ctx, as set by NewContext, or by
+// [mocktime.NewContext], which shares its key, or a clock backed by
+// [realtime] if there is none.
+func FromContext(ctx context.Context) StdClock {
+	switch c := ctx.Value(ctxkey.Key{}).(type) {
+	case StdClock:
+		return c
+	case mocktime.Clock:
+		return FromMocktime(c)
+	}
+	return FromRealtime(realtime.Clock{})
+}
+
+// After is like the After method of c, but the underlying Timer is stopped
+// once ctx is done or stop is called, whichever comes first. Once stop has
+// returned, the returned channel holds no value and receives none, even if
+// the timer had already fired. As with a [context.CancelFunc], stop should
+// be called once the channel is no longer needed, though the resources
+// associated with it are also released once the timer fires or ctx is
+// done. It is safe to call more than once.
+func After[T Time[T, D], D Duration](ctx context.Context, c Clock[T, D], d D) (ch <-chan T, stop func()) {
+	tm := c.NewTimer(d)
+	out := make(chan T, 1)
+	done := make(chan struct{})
+	var (
+		mu      sync.Mutex
+		stopped bool
+	)
+	stop = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped {
+			return
+		}
+		stopped = true
+		close(done)
+		tm.Stop()
+		select {
+		case <-out:
+		default:
+		}
+	}
+	go func() {
+		select {
+		case v := <-tm.C():
+			mu.Lock()
+			if !stopped {
+				out <- v
+			}
+			mu.Unlock()
+		case <-ctx.Done():
+			tm.Stop()
+		case <-done:
+		}
+	}()
+	return out, stop
+}
+
+// Sleep is like the Sleep method of c, but returns early if ctx is done
+// first, with the error from ctx. Otherwise, it returns nil.
+func Sleep[T Time[T, D], D Duration](ctx context.Context, c Clock[T, D], d D) error {
+	if d.Seconds() <= 0 {
+		return nil
+	}
+
+	tm := c.NewTimer(d)
+	defer tm.Stop()
+	select {
+	case <-tm.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Tick is like the Tick method of c, but the underlying Ticker is stopped
+// once ctx is done, so that it may be recovered by the garbage collector.
+// It returns nil if d <= 0.
+func Tick[T Time[T, D], D Duration](ctx context.Context, c Clock[T, D], d D) <-chan T {
+	if d.Seconds() <= 0 {
+		return nil
+	}
+
+	tk := c.NewTicker(d)
+	if ctx.Done() == nil {
+		// Never done, so there is nothing to wait for
+		return tk.C()
+	}
+	go func() {
+		<-ctx.Done()
+		tk.Stop()
+	}()
+	return tk.C()
+}
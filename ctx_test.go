@@ -0,0 +1,90 @@
+package clock_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+func TestSleepCanceled(t *testing.T) {
+	c := FromSteppedtime(steppedtime.NewClock())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Sleep(ctx, c, steppedtime.Hour); err != context.Canceled {
+		t.Errorf("Sleep() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	s := steppedtime.NewClock()
+	c := FromSteppedtime(s)
+	errc := make(chan error)
+	go func() { errc <- Sleep(context.Background(), c, steppedtime.Second) }()
+	for {
+		select {
+		case err := <-errc:
+			if err != nil {
+				t.Errorf("Sleep() = %v, want nil", err)
+			}
+			return
+		default:
+			s.Step(steppedtime.Second)
+		}
+	}
+}
+
+func TestAfterStop(t *testing.T) {
+	s := steppedtime.NewClock()
+	c := FromSteppedtime(s)
+	ch, stop := After(context.Background(), c, steppedtime.Second)
+	stop()
+	stop()
+	s.Step(steppedtime.Second)
+	select {
+	case <-ch:
+		t.Errorf("After delivered a value after stop")
+	default:
+	}
+}
+
+func TestAfter(t *testing.T) {
+	s := steppedtime.NewClock()
+	c := FromSteppedtime(s)
+	ch, stop := After(context.Background(), c, steppedtime.Second)
+	defer stop()
+	s.Step(steppedtime.Second)
+	if got := <-ch; got != steppedtime.Time(steppedtime.Second) {
+		t.Errorf("After delivered %v, want %v", got, steppedtime.Time(steppedtime.Second))
+	}
+
+	// A value already delivered but not received is discarded by stop
+	ch, stop = After(context.Background(), c, steppedtime.Second)
+	s.Step(steppedtime.Second)
+	for len(ch) == 0 {
+		runtime.Gosched()
+	}
+	stop()
+	select {
+	case <-ch:
+		t.Errorf("After held a value after stop")
+	default:
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := context.Background()
+	if d := time.Since(FromContext(ctx).Now()); d < -time.Minute || d > time.Minute {
+		t.Errorf("FromContext(ctx) defaulted to a clock %v behind real time", d)
+	}
+	m := mocktime.NewClockAt(time.Unix(0, 0))
+	m.Stop()
+	ctx = NewContext(ctx, FromMocktime(m))
+	if got := FromContext(ctx).Now(); !got.Equal(time.Unix(0, 0)) {
+		t.Errorf("FromContext(ctx).Now() = %v, want the mock clock's time", got)
+	}
+}
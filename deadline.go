@@ -0,0 +1,115 @@
+package clock
+
+import (
+	"sync"
+)
+
+// A Deadline is a two-phase deadline on a Clock: a soft warning, followed
+// by a hard expiry, as for request-handling code that logs once 80% of its
+// budget is spent and cancels at 100%. Both phases share one Timer on the
+// Clock, re-armed for the expiry once the warning passes. A Deadline must
+// be created with NewDeadline.
+type Deadline[T Time[T, D], D Duration] struct {
+	c                Clock[T, D]
+	tm               Timer[T, D]
+	warnAt, hardAt   T
+	onWarn, onExpire func()
+	phase            int // Next phase due: 0 for the warning, 1 for the expiry, or 2 if none
+
+	mu sync.Mutex
+}
+
+// NewDeadline returns a Deadline on c that calls onWarn once duration warn
+// has elapsed, then onExpire once duration hard has elapsed, each in its own
+// goroutine. Either function may be nil. If warn is not before hard, there
+// is no warning, and only onExpire is called. Should the Clock jump past
+// both at once, onWarn is called before onExpire, and otherwise, onExpire is
+// not held up by a slow onWarn.
+func NewDeadline[T Time[T, D], D Duration](c Clock[T, D], warn, hard D, onWarn, onExpire func()) *Deadline[T, D] {
+	if onWarn == nil {
+		onWarn = func() {}
+	}
+	if onExpire == nil {
+		onExpire = func() {}
+	}
+	dl := &Deadline[T, D]{c: c, onWarn: onWarn, onExpire: onExpire}
+	dl.mu.Lock()
+	defer dl.mu.Unlock()
+	dl.arm(warn, hard)
+	return dl
+}
+
+// arm schedules both phases, relative to the current time. Callers must
+// hold the lock.
+func (dl *Deadline[T, D]) arm(warn, hard D) {
+	now := dl.c.Now()
+	dl.warnAt, dl.hardAt = now.Add(warn), now.Add(hard)
+	dl.phase = 0
+	d := warn
+	if !dl.warnAt.Before(dl.hardAt) {
+		dl.phase, d = 1, hard
+	}
+	if dl.tm == nil {
+		dl.tm = dl.c.AfterFunc(d, dl.fire)
+	} else {
+		dl.tm.Reset(d)
+	}
+}
+
+func (dl *Deadline[T, D]) fire() {
+	dl.mu.Lock()
+	now := dl.c.Now()
+	switch {
+	case dl.phase == 0 && !now.Before(dl.warnAt):
+		if now.Before(dl.hardAt) {
+			dl.phase = 1
+			dl.tm.Reset(dl.hardAt.Sub(now))
+			dl.mu.Unlock()
+			dl.onWarn()
+			return
+		}
+		// Both are already due, so keep them in order
+		dl.phase = 2
+		dl.mu.Unlock()
+		dl.onWarn()
+		dl.onExpire()
+	case dl.phase == 1 && !now.Before(dl.hardAt):
+		dl.phase = 2
+		dl.mu.Unlock()
+		dl.onExpire()
+	default:
+		// Stale, from before a call to Reset or Stop
+		dl.mu.Unlock()
+	}
+}
+
+// Reset re-arms dl to warn after duration warn and expire after duration
+// hard, as with NewDeadline, whether or not either phase has already
+// passed. It returns true if dl had not yet expired or been stopped.
+func (dl *Deadline[T, D]) Reset(warn, hard D) (active bool) {
+	dl.mu.Lock()
+	defer dl.mu.Unlock()
+	active = dl.phase < 2
+	dl.arm(warn, hard)
+	return
+}
+
+// Stop prevents dl from warning or expiring, if it has not already. It
+// returns true if the call stops the expiry, false if dl has already
+// expired or been stopped.
+func (dl *Deadline[T, D]) Stop() (active bool) {
+	dl.mu.Lock()
+	defer dl.mu.Unlock()
+	active = dl.phase < 2
+	dl.phase = 2
+	dl.tm.Stop()
+	return
+}
+
+// Remaining returns the time left until dl expires, which is negative once
+// it has passed.
+func (dl *Deadline[T, D]) Remaining() D {
+	dl.mu.Lock()
+	defer dl.mu.Unlock()
+	return dl.c.Until(dl.hardAt)
+}
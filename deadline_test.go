@@ -0,0 +1,68 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/mocktime"
+)
+
+func TestDeadline(t *testing.T) {
+	m := mocktime.NewClock()
+	events := make(chan string, 4)
+	dl := NewDeadline[time.Time, time.Duration](FromMocktime(m), 8*time.Second, 10*time.Second,
+		func() { events <- "warn" },
+		func() { events <- "expire" },
+	)
+	expect := func(want string) {
+		t.Helper()
+		select {
+		case got := <-events:
+			if got != want {
+				t.Fatalf("got %q, want %q", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no %q", want)
+		}
+	}
+	expectNone := func() {
+		t.Helper()
+		select {
+		case got := <-events:
+			t.Fatalf("got unexpected %q", got)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	m.Step(7 * time.Second)
+	expectNone()
+	m.Step(time.Second)
+	expect("warn")
+	if got := dl.Remaining(); got != 2*time.Second {
+		t.Errorf("Remaining() = %v after the warning, want 2s", got)
+	}
+	m.Step(2 * time.Second)
+	expect("expire")
+	if dl.Stop() {
+		t.Errorf("Stop() = true after expiry")
+	}
+
+	// Jumping past both keeps them in order
+	if dl.Reset(8*time.Second, 10*time.Second) {
+		t.Errorf("Reset() = true after expiry")
+	}
+	m.Step(time.Minute)
+	expect("warn")
+	expect("expire")
+
+	// Stopped after the warning, it never expires
+	dl.Reset(time.Second, 2*time.Second)
+	m.Step(time.Second)
+	expect("warn")
+	if !dl.Stop() {
+		t.Errorf("Stop() = false before expiry")
+	}
+	m.Step(time.Minute)
+	expectNone()
+}
@@ -6,4 +6,8 @@
 // may track its own flow of time, appropriately triggering Timers and
 // Tickers created with them. There are several implementations supplied by
 // subpackages.
+//
+// This package defines generic interfaces describing the API shared by
+// these implementations, along with adapters allowing each of them to
+// satisfy those interfaces.
 package clock
@@ -0,0 +1,46 @@
+package clock
+
+import (
+	"time"
+
+	"github.com/noodlebox/clock/internal/durations"
+)
+
+// MustParseDuration is like [time.ParseDuration], but panics if s cannot be
+// parsed, for durations fixed in configuration code or tests. The Clock
+// types of realtime, steppedtime, relativetime, and mocktime each provide it
+// as a method, as with ParseDuration.
+func MustParseDuration(s string) time.Duration {
+	return durations.MustParse(s)
+}
+
+// ParseISODuration parses an ISO 8601 duration, such as "P1DT2H30M" or
+// "PT0.5S", as found in configuration files and external APIs, optionally
+// preceded by a sign. Days are taken as 24 hours and weeks as 7 days; years
+// and months, whose lengths vary, are rejected. The Clock types of realtime,
+// steppedtime, relativetime, and mocktime each provide it as a method, as
+// with ParseDuration, though the Clock interface does not require it.
+func ParseISODuration(s string) (time.Duration, error) {
+	return durations.ParseISO(s)
+}
+
+// FormatISODuration formats d as an ISO 8601 duration, such as "P1DT2H30M",
+// as accepted by ParseISODuration. The Clock types of realtime,
+// steppedtime, relativetime, and mocktime each provide it as a method.
+func FormatISODuration(d time.Duration) string {
+	return durations.FormatISO(d)
+}
+
+// DurationOf returns the sum of the given counts of units, written as
+// alternating pairs of a count and a unit, such as:
+//
+//	DurationOf(1, time.Hour, 30, time.Minute) // 1h30m0s
+//
+// Counts are themselves Durations, so a fractional constant count, such as
+// 1.5, fails to compile, but nothing tells a count from a unit, so swapping
+// them goes unnoticed. DurationOf panics if given an odd number of values,
+// or if the sum overflows. The Clock types of realtime, steppedtime,
+// relativetime, and mocktime each provide it as a method.
+func DurationOf(pairs ...time.Duration) time.Duration {
+	return durations.Of(pairs...)
+}
@@ -0,0 +1,71 @@
+package clock_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock"
+)
+
+func TestDurationOf(t *testing.T) {
+	if got, want := DurationOf(1, time.Hour, 30, time.Minute), 90*time.Minute; got != want {
+		t.Errorf("DurationOf(1, Hour, 30, Minute) = %v, want %v", got, want)
+	}
+	if got, want := MustParseDuration("1h30m"), 90*time.Minute; got != want {
+		t.Errorf("MustParseDuration(\"1h30m\") = %v, want %v", got, want)
+	}
+
+	for name, f := range map[string]func(){
+		"odd count": func() { DurationOf(1, time.Hour, 30) },
+		"overflow":  func() { DurationOf(1<<40, time.Hour) },
+		"bad parse": func() { MustParseDuration("90 minutes") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestISODuration(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		d    time.Duration
+		want string // Canonical form, if different
+	}{
+		{"PT0S", 0, ""},
+		{"P1DT2H30M", 26*time.Hour + 30*time.Minute, ""},
+		{"PT36H", 36 * time.Hour, "P1DT12H"},
+		{"P2W", 14 * 24 * time.Hour, "P14D"},
+		{"PT0.5S", 500 * time.Millisecond, ""},
+		{"PT1,25S", 1250 * time.Millisecond, "PT1.25S"},
+		{"PT1.5M", 90 * time.Second, "PT1M30S"},
+		{"-PT1M", -time.Minute, ""},
+		{"PT0.000000001S", time.Nanosecond, ""},
+		{"P200DT0.000000001S", 200*24*time.Hour + time.Nanosecond, ""},
+		{"P106751DT23H47M16.854775807S", math.MaxInt64, ""},
+	} {
+		d, err := ParseISODuration(tt.s)
+		if err != nil || d != tt.d {
+			t.Errorf("ParseISODuration(%q) = %v, %v; want %v, nil", tt.s, d, err, tt.d)
+		}
+		want := tt.want
+		if want == "" {
+			want = tt.s
+		}
+		if got := FormatISODuration(tt.d); got != want {
+			t.Errorf("FormatISODuration(%v) = %q, want %q", tt.d, got, want)
+		}
+	}
+
+	for _, s := range []string{"", "P", "PT", "P1DT", "1D", "P1Y", "P1M", "PT1.5M30S", "PT1H2H", "PT1S1M", "P-1D", "PT1e3S", "P1000000000D", "P106752D", "PT.S", "PTInfS"} {
+		if d, err := ParseISODuration(s); err == nil {
+			t.Errorf("ParseISODuration(%q) = %v, want an error", s, d)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package clock
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/realtime"
+)
+
+// Environment variables consulted by FromEnv.
+const (
+	EnvMode  = "CLOCK_MODE"  // One of "real" (the default), "relative", or "mock"
+	EnvStart = "CLOCK_START" // Initial time, in RFC 3339 format
+	EnvScale = "CLOCK_SCALE" // Scaling factor, as a decimal number
+)
+
+// FromEnv returns a StdClock configured by environment variables, allowing
+// the flow of time to be adjusted without code changes. The mode is chosen
+// by CLOCK_MODE:
+//
+//   - "real" (or unset): a realtime clock. CLOCK_START and CLOCK_SCALE must
+//     not be set.
+//   - "relative": a clock running at CLOCK_SCALE times real time (default
+//     1), starting at CLOCK_START (default now).
+//   - "mock": as with "relative", but stopped, so that it only advances
+//     when explicitly stepped or started.
+//
+// Relative and mock clocks are backed by a [mocktime.Clock].
+func FromEnv() (StdClock, error) {
+	mode := os.Getenv(EnvMode)
+	start, hasStart := os.LookupEnv(EnvStart)
+	scale, hasScale := os.LookupEnv(EnvScale)
+
+	switch mode {
+	case "", "real":
+		if hasStart || hasScale {
+			return nil, fmt.Errorf("clock: %s and %s require %s=relative or %s=mock", EnvStart, EnvScale, EnvMode, EnvMode)
+		}
+		return FromRealtime(realtime.NewClock()), nil
+	case "relative", "mock":
+	default:
+		return nil, fmt.Errorf("clock: invalid %s %q", EnvMode, mode)
+	}
+
+	c := mocktime.NewClock()
+	if hasStart {
+		at, err := time.Parse(time.RFC3339Nano, start)
+		if err != nil {
+			return nil, fmt.Errorf("clock: invalid %s: %w", EnvStart, err)
+		}
+		c.Set(at)
+	}
+	if hasScale {
+		s, err := strconv.ParseFloat(scale, 64)
+		if err != nil {
+			return nil, fmt.Errorf("clock: invalid %s: %w", EnvScale, err)
+		}
+		c.SetScale(s)
+	}
+	if mode == "relative" {
+		c.Start()
+	}
+	return FromMocktime(c), nil
+}
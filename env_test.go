@@ -0,0 +1,48 @@
+package clock_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv(EnvMode, "mock")
+	t.Setenv(EnvStart, "2020-01-01T00:00:00Z")
+	t.Setenv(EnvScale, "10")
+
+	c, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() returned error: %v", err)
+	}
+	want := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if got := c.Now(); !got.Equal(want) {
+		t.Errorf("Now() = %v, want %v", got, want)
+	}
+}
+
+func TestFromEnvInvalid(t *testing.T) {
+	for _, env := range []map[string]string{
+		{EnvMode: "bogus"},
+		{EnvMode: "real", EnvScale: "10"},
+		{EnvMode: "relative", EnvScale: "fast"},
+		{EnvMode: "mock", EnvStart: "yesterday"},
+	} {
+		// Each case in its own subtest, so that its variables are restored
+		// before the next
+		t.Run(env[EnvMode], func(t *testing.T) {
+			for _, k := range []string{EnvMode, EnvStart, EnvScale} {
+				t.Setenv(k, "") // Restored once the subtest is done
+				os.Unsetenv(k)
+			}
+			for k, v := range env {
+				t.Setenv(k, v)
+			}
+			if _, err := FromEnv(); err == nil {
+				t.Errorf("FromEnv() with %v succeeded, want error", env)
+			}
+		})
+	}
+}
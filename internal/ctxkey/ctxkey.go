@@ -0,0 +1,6 @@
+// Package ctxkey provides the key under which a context carries a clock,
+// shared by the root package and mocktime, which it imports.
+package ctxkey
+
+// Key is the context key for a clock.
+type Key struct{}
@@ -0,0 +1,39 @@
+// Package daily finds the next occurrence of a time of day, for clocks
+// implementing SleepUntilNext.
+package daily
+
+import (
+	"fmt"
+	"time"
+)
+
+// Next returns the first time after now at which the wall clock in loc
+// reads clockTime, given as "15:04" or "15:04:05". If loc is nil, the
+// location of now is used. The day is advanced on the calendar rather than
+// by adding 24 hours, so the result keeps to the wall clock across changes
+// to daylight saving time. A time of day skipped by such a change is
+// normalized as by [time.Date], landing as far past the gap as it was into
+// it.
+func Next(now time.Time, clockTime string, loc *time.Location) (time.Time, error) {
+	var tod time.Time
+	var err error
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if tod, err = time.Parse(layout, clockTime); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time of day %q: want HH:MM or HH:MM:SS", clockTime)
+	}
+	if loc == nil {
+		loc = now.Location()
+	}
+	y, m, d := now.In(loc).Date()
+	h, mi, sec := tod.Clock()
+	for i := 0; ; i++ {
+		next := time.Date(y, m, d+i, h, mi, sec, 0, loc)
+		if next.After(now) {
+			return next, nil
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Package durations provides the helpers for writing Duration values shared
+// by the root package and each Clock implementation, all of which use
+// [time.Duration].
+package durations
+
+import (
+	"math"
+	"time"
+)
+
+// MustParse is like time.ParseDuration, but panics if s cannot be parsed.
+func MustParse(s string) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Of returns the sum of counts of units, given as alternating pairs of a
+// count and a unit. It panics if given an odd number of values, or if the
+// sum overflows.
+func Of(pairs ...time.Duration) time.Duration {
+	if len(pairs)%2 != 0 {
+		panic("DurationOf: count without a unit")
+	}
+	var sum time.Duration
+	for i := 0; i < len(pairs); i += 2 {
+		n, unit := pairs[i], pairs[i+1]
+		d := n * unit
+		if unit != 0 && d/unit != n {
+			panic("DurationOf: duration out of range")
+		}
+		if (d > 0 && sum > math.MaxInt64-d) || (d < 0 && sum < math.MinInt64-d) {
+			panic("DurationOf: duration out of range")
+		}
+		sum += d
+	}
+	return sum
+}
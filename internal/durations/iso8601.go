@@ -0,0 +1,177 @@
+package durations
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Designators of the components of an ISO 8601 duration, with the length
+// of each, in order. Years and months vary in length, so they are rejected.
+var (
+	dateUnits = []isoUnit{{'W', 7 * 24 * time.Hour}, {'D', 24 * time.Hour}}
+	timeUnits = []isoUnit{{'H', time.Hour}, {'M', time.Minute}, {'S', time.Second}}
+)
+
+type isoUnit struct {
+	designator byte
+	length     time.Duration
+}
+
+// ParseISO parses an ISO 8601 duration, such as "P1DT2H30M" or "PT0.5S",
+// optionally preceded by a sign. Days are taken as 24 hours and weeks as 7
+// days; years and months, whose lengths vary, are rejected. Only the last
+// component may have a fraction, separated by either '.' or ','.
+func ParseISO(s string) (time.Duration, error) {
+	orig := s
+	fail := func() (time.Duration, error) {
+		return 0, errors.New("clock: invalid ISO 8601 duration " + strconv.Quote(orig))
+	}
+
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "" || s[0] != 'P' {
+		return fail()
+	}
+	s = s[1:]
+	datePart, timePart, hasTime := strings.Cut(s, "T")
+	if (datePart == "" && !hasTime) || (hasTime && timePart == "") {
+		return fail()
+	}
+
+	var sum uint64 // In nanoseconds
+	last := false  // Whether a fraction has ended the duration
+	for _, part := range []struct {
+		s     string
+		units []isoUnit
+	}{{datePart, dateUnits}, {timePart, timeUnits}} {
+		rest, units := part.s, part.units
+		for rest != "" {
+			if last {
+				return fail()
+			}
+			i := strings.IndexAny(rest, "WDHMSYwdhmsy")
+			if i <= 0 {
+				return fail()
+			}
+			num, d := strings.Replace(rest[:i], ",", ".", 1), rest[i]
+			rest = rest[i+1:]
+			for len(units) > 0 && units[0].designator != d {
+				units = units[1:]
+			}
+			if len(units) == 0 {
+				return fail()
+			}
+			var frac string
+			num, frac, last = strings.Cut(num, ".")
+			n, ok := parseISOComponent(num, frac, uint64(units[0].length))
+			if !ok || n > math.MaxInt64-sum {
+				return fail()
+			}
+			sum += n
+			units = units[1:]
+		}
+	}
+	d := time.Duration(sum)
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// Return the length in nanoseconds of a component of an ISO 8601 duration,
+// with whole and fractional parts given as strings of decimal digits, of
+// units of the given length. The whole part is multiplied exactly, so that
+// no nanosecond is lost however long the duration; only the fraction, less
+// than one unit, is carried in floating point.
+func parseISOComponent(whole, frac string, length uint64) (uint64, bool) {
+	if (whole == "" && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return 0, false
+	}
+	var n uint64
+	if whole != "" {
+		w, err := strconv.ParseUint(whole, 10, 64)
+		if err != nil || w > math.MaxInt64/length {
+			return 0, false
+		}
+		n = w * length
+	}
+	if frac != "" {
+		f, err := strconv.ParseFloat("0."+frac, 64)
+		if err != nil {
+			return 0, false
+		}
+		n += uint64(math.Round(f * float64(length)))
+	}
+	return n, true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MustParseISO is like ParseISO, but panics if s cannot be parsed.
+func MustParseISO(s string) time.Duration {
+	d, err := ParseISO(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// FormatISO formats d as an ISO 8601 duration, such as "P1DT2H30M" or
+// "PT0.5S", using days of 24 hours, but never weeks, years, or months. A
+// negative duration is preceded by '-', and zero is "PT0S".
+func FormatISO(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	u := uint64(d)
+	if d < 0 {
+		b.WriteByte('-')
+		u = -u
+	}
+	b.WriteByte('P')
+	day, hour, min := uint64(24*time.Hour), uint64(time.Hour), uint64(time.Minute)
+	if days := u / day; days > 0 {
+		b.WriteString(strconv.FormatUint(days, 10))
+		b.WriteByte('D')
+		u %= day
+	}
+	if u == 0 {
+		return b.String()
+	}
+	b.WriteByte('T')
+	if hours := u / hour; hours > 0 {
+		b.WriteString(strconv.FormatUint(hours, 10))
+		b.WriteByte('H')
+		u %= hour
+	}
+	if mins := u / min; mins > 0 {
+		b.WriteString(strconv.FormatUint(mins, 10))
+		b.WriteByte('M')
+		u %= min
+	}
+	if u > 0 {
+		sec := uint64(time.Second)
+		b.WriteString(strconv.FormatUint(u/sec, 10))
+		if frac := u % sec; frac > 0 {
+			f := strconv.FormatUint(frac+sec, 10)[1:] // Zero padded to 9 digits
+			b.WriteByte('.')
+			b.WriteString(strings.TrimRight(f, "0"))
+		}
+		b.WriteByte('S')
+	}
+	return b.String()
+}
@@ -0,0 +1,9 @@
+// Package receive lets mocktime set up receivers on a relativetime Clock for
+// its assertion helpers, without relativetime exporting a method for them.
+package receive
+
+// Ready stands ready on behalf of c, a *relativetime.Clock, to receive a
+// value sent on ch by one of its Timers or Tickers, until recv is called, as
+// the receive method of c does. It is set by relativetime as it is
+// initialized.
+var Ready func(c, ch any) (recv func() (v any, ok bool))
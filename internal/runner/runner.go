@@ -0,0 +1,78 @@
+// Package runner provides a way to run functions in their own goroutines
+// with a bound on how many may run at once, for clocks calling functions
+// passed to AfterFunc.
+package runner
+
+import (
+	"sync"
+)
+
+// A Runner runs functions in new goroutines, queueing them while a limit on
+// concurrently running functions has been reached. The zero value of a
+// Runner is ready to use, with no limit.
+type Runner struct {
+	limit   int
+	running int
+	queue   []func()
+
+	mu sync.Mutex // Protects all fields
+}
+
+// SetLimit sets the maximum number of functions that may run at once. If n
+// <= 0, there is no limit. Functions already queued start as soon as the
+// new limit allows.
+func (r *Runner) SetLimit(n int) {
+	r.mu.Lock()
+	r.limit = n
+	for len(r.queue) > 0 && (r.limit <= 0 || r.running < r.limit) {
+		f := r.pop()
+		r.running++
+		go r.loop(f)
+	}
+	r.mu.Unlock()
+}
+
+// Limit returns the maximum number of functions that may run at once, or 0
+// if there is no limit.
+func (r *Runner) Limit() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.limit
+}
+
+// Go runs f in its own goroutine, or queues it to be run once fewer than
+// the limit are running. It never blocks.
+func (r *Runner) Go(f func()) {
+	r.mu.Lock()
+	if r.limit > 0 && r.running >= r.limit {
+		r.queue = append(r.queue, f)
+		r.mu.Unlock()
+		return
+	}
+	r.running++
+	r.mu.Unlock()
+	go r.loop(f)
+}
+
+// Run f, then whatever is queued until nothing remains.
+func (r *Runner) loop(f func()) {
+	for {
+		f()
+		r.mu.Lock()
+		if len(r.queue) == 0 || (r.limit > 0 && r.running > r.limit) {
+			r.running--
+			r.mu.Unlock()
+			return
+		}
+		f = r.pop()
+		r.mu.Unlock()
+	}
+}
+
+// Callers must hold the lock.
+func (r *Runner) pop() func() {
+	f := r.queue[0]
+	r.queue[0] = nil
+	r.queue = r.queue[1:]
+	return f
+}
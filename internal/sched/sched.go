@@ -0,0 +1,106 @@
+// Package sched calls a function at each of the times given by a schedule,
+// re-arming a single timer on a clock, for clocks implementing ScheduleFunc.
+package sched
+
+import (
+	"sync"
+)
+
+// Time is the subset of a Time implementation needed by a Chain.
+type Time[T, D any] interface {
+	Sub(T) D
+	After(T) bool
+}
+
+// Schedule gives the times at which a Chain calls its function.
+type Schedule[T any] interface {
+	Next(after T) (T, bool)
+}
+
+// Timer is the subset of a Timer implementation needed by a Chain.
+type Timer[D any] interface {
+	Reset(d D) bool
+	Stop() bool
+}
+
+// A Chain calls a function at each time given by a Schedule, until the
+// Schedule ends or the Chain is stopped. Each call is passed the time it was
+// due, rather than the time it was made. Calls are made one at a time, in
+// order, and one that falls behind is made as soon as possible, so that none
+// are skipped.
+type Chain[T Time[T, D], D any, TM Timer[D]] struct {
+	s    Schedule[T]
+	f    func(T)
+	now  func() T
+	tm   TM
+	when T    // Time of the next call
+	done bool // Whether the Schedule ended or the Chain was stopped
+
+	mu sync.Mutex
+}
+
+// Start returns a Chain calling f at each time given by s, after the current
+// time as given by now, using timers created by afterFunc.
+func Start[T Time[T, D], D any, TM Timer[D]](now func() T, afterFunc func(D, func()) TM, s Schedule[T], f func(T)) *Chain[T, D, TM] {
+	c := &Chain[T, D, TM]{s: s, f: f, now: now}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	start := now()
+	next, ok := s.Next(start)
+	if !ok || !next.After(start) {
+		c.done = true
+		return c
+	}
+	c.when = next
+	c.tm = afterFunc(next.Sub(start), c.fire)
+	return c
+}
+
+// Make the call, then re-arm the timer for the following one, so that calls
+// never overlap and are made in order. A Schedule that fails to advance ends
+// the Chain, rather than looping.
+func (c *Chain[T, D, TM]) fire() {
+	c.mu.Lock()
+	done, at := c.done, c.when
+	c.mu.Unlock()
+	if done {
+		return
+	}
+	c.f(at)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done {
+		return
+	}
+	if next, ok := c.s.Next(at); ok && next.After(at) {
+		c.when = next
+		c.tm.Reset(next.Sub(c.now()))
+	} else {
+		c.done = true
+	}
+}
+
+// Next returns the time of the next call, and false if there are none.
+func (c *Chain[T, D, TM]) Next() (next T, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done {
+		return next, false
+	}
+	return c.when, true
+}
+
+// Stop prevents any further calls. It returns true if the call stops the
+// Chain, false if it had already ended or been stopped. A call already in
+// progress is not waited for.
+func (c *Chain[T, D, TM]) Stop() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done {
+		return false
+	}
+	c.done = true
+	c.tm.Stop()
+	return true
+}
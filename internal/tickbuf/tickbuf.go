@@ -0,0 +1,107 @@
+// Package tickbuf provides a queue for delivering ticks to a channel in
+// order, without blocking the sender or dropping values, for use by lossless
+// tickers.
+package tickbuf
+
+import (
+	"sync"
+)
+
+// A Buffer queues values pushed to it, delivering them on a channel in the
+// order they were pushed. A goroutine runs to deliver them only while any
+// are pending. A Buffer must be created with New.
+type Buffer[T any] struct {
+	out   chan<- T
+	limit int
+
+	q      []T
+	cancel chan struct{}   // Closed to end delivery, nil unless running
+	busy   func(busy bool) // Set by OnBusy
+	mu     sync.Mutex      // Protects q, cancel, and busy
+}
+
+// New returns a Buffer delivering values on out. It holds at most limit
+// values not yet received, or any number of them if limit <= 0.
+func New[T any](out chan<- T, limit int) *Buffer[T] {
+	return &Buffer[T]{out: out, limit: limit}
+}
+
+// OnBusy arranges for f to be called whenever the Buffer starts or stops
+// delivering values, with busy true if it has values not yet received. It is
+// called while holding the Buffer's lock, so it must not call back into it.
+func (b *Buffer[T]) OnBusy(f func(busy bool)) {
+	b.mu.Lock()
+	b.busy = f
+	b.mu.Unlock()
+}
+
+// Push queues v for delivery without blocking. It returns false if v was
+// dropped, as the Buffer was full.
+func (b *Buffer[T]) Push(v T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.limit > 0 && len(b.q) >= b.limit {
+		return false
+	}
+	b.q = append(b.q, v)
+	if b.cancel == nil {
+		b.cancel = make(chan struct{})
+		b.setBusy(true)
+		go b.run(b.cancel)
+	}
+	return true
+}
+
+// Stop discards any values not yet delivered. A value being delivered
+// concurrently may still be received. The Buffer may be used again
+// afterwards.
+func (b *Buffer[T]) Stop() {
+	b.mu.Lock()
+	b.q = nil
+	if b.cancel != nil {
+		close(b.cancel)
+		b.cancel = nil
+		b.setBusy(false)
+	}
+	b.mu.Unlock()
+}
+
+// Report a change in whether values are being delivered. Callers must hold
+// the lock.
+func (b *Buffer[T]) setBusy(busy bool) {
+	if b.busy != nil {
+		b.busy(busy)
+	}
+}
+
+func (b *Buffer[T]) run(cancel chan struct{}) {
+	b.mu.Lock()
+	// Once stopped, q and cancel may belong to a run started since
+	for b.cancel == cancel && len(b.q) > 0 {
+		v := b.q[0]
+		b.mu.Unlock()
+
+		select {
+		case b.out <- v:
+		case <-cancel:
+			return
+		}
+
+		b.mu.Lock()
+		select {
+		case <-cancel:
+			// Stopped while sending, so q is no longer ours
+			b.mu.Unlock()
+			return
+		default:
+		}
+		var zero T
+		b.q[0] = zero
+		b.q = b.q[1:]
+	}
+	if b.cancel == cancel {
+		b.cancel = nil
+		b.setBusy(false)
+	}
+	b.mu.Unlock()
+}
@@ -0,0 +1,60 @@
+// Package weakchan refers to channels without keeping them reachable, so
+// that a timer delivering on a channel may be stopped once nothing else can
+// receive from it, as the runtime does for timers in Go 1.23, but on earlier
+// versions of Go, which lack weak references.
+package weakchan
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// A Ref refers to a channel without keeping it reachable. A Ref must be
+// created with Make.
+type Ref[T any] struct {
+	p  uintptr // Address of the channel, hidden from the garbage collector
+	mu sync.Mutex
+}
+
+// The runtime's representation of a channel, as far as is needed to attach a
+// finalizer to one. Its contents are never accessed.
+type hchan struct{ _ uintptr }
+
+// Make returns a new channel with room for size values, along with a Ref to
+// it. Once the channel is no longer reachable, other than through the Ref,
+// the Ref is cleared and collected is called, from the goroutine running
+// finalizers, so it must not block for long. Neither collected nor anything
+// it refers to may refer to the channel, or it is never collected.
+func Make[T any](size int, collected func()) (chan T, *Ref[T]) {
+	ch := make(chan T, size)
+	p := *(*unsafe.Pointer)(unsafe.Pointer(&ch))
+	r := &Ref[T]{p: uintptr(p)}
+	runtime.SetFinalizer((*hchan)(p), func(*hchan) {
+		// The channel outlives its finalizer, so is still intact until
+		// the Ref is cleared
+		r.mu.Lock()
+		r.p = 0
+		r.mu.Unlock()
+		collected()
+	})
+	return ch, r
+}
+
+// Send sends v on the channel, unless it would block or the channel has been
+// collected, and reports whether it was sent.
+func (r *Ref[T]) Send(v T) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.p == 0 {
+		return false
+	}
+	var ch chan T
+	*(*unsafe.Pointer)(unsafe.Pointer(&ch)) = *(*unsafe.Pointer)(unsafe.Pointer(&r.p))
+	select {
+	case ch <- v:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,136 @@
+package clock
+
+import (
+	"sync"
+)
+
+// DropPolicy determines how a Metronome treats a subscriber that is not
+// ready to receive a tick.
+type DropPolicy int
+
+const (
+	// DropNewest drops the new tick, leaving any already buffered.
+	DropNewest DropPolicy = iota
+	// DropOldest drops the oldest buffered tick to make room for the new one.
+	DropOldest
+	// Block waits until the subscriber receives the tick, holding back
+	// delivery of that tick to subscribers later in the order and of any
+	// later ticks to all of them.
+	Block
+)
+
+type subscriber[T any] struct {
+	ch     chan T
+	policy DropPolicy
+	quit   chan struct{}
+}
+
+// A Metronome generates ticks from a single Ticker on a Clock and delivers
+// each of them to every subscriber, so that all of them step on the same
+// beat. A Metronome must be created with NewMetronome.
+type Metronome[T Time[T, D], D Duration] struct {
+	tk   Ticker[T, D]
+	subs []*subscriber[T]
+	done chan struct{}
+	stop sync.Once
+
+	mu sync.Mutex // Protects subs
+}
+
+// NewMetronome returns a new Metronome ticking on c with a period of d. The
+// duration d must be greater than zero; if not, the Ticker underlying it
+// will panic. Stop the Metronome to release associated resources.
+func NewMetronome[T Time[T, D], D Duration](c Clock[T, D], d D) *Metronome[T, D] {
+	m := &Metronome[T, D]{
+		tk:   c.NewTicker(d),
+		done: make(chan struct{}),
+	}
+	go m.run()
+	return m
+}
+
+func (m *Metronome[T, D]) run() {
+	for {
+		select {
+		case now := <-m.tk.C():
+			m.mu.Lock()
+			subs := m.subs
+			m.mu.Unlock()
+			for _, s := range subs {
+				m.deliver(s, now)
+			}
+		case <-m.done:
+			return
+		}
+	}
+}
+
+func (m *Metronome[T, D]) deliver(s *subscriber[T], now T) {
+	select {
+	case s.ch <- now:
+		return
+	default:
+	}
+	switch s.policy {
+	case DropOldest:
+		select {
+		case <-s.ch:
+		default:
+		}
+		select {
+		case s.ch <- now:
+		default:
+		}
+	case Block:
+		select {
+		case s.ch <- now:
+		case <-s.quit:
+		case <-m.done:
+		}
+	}
+}
+
+// Subscribe returns a new channel receiving each tick from the next one
+// onwards, buffering up to size ticks. Ticks that the subscriber is not
+// ready to receive are handled according to policy.
+func (m *Metronome[T, D]) Subscribe(size int, policy DropPolicy) <-chan T {
+	s := &subscriber[T]{
+		ch:     make(chan T, size),
+		policy: policy,
+		quit:   make(chan struct{}),
+	}
+	m.mu.Lock()
+	// Copy on write, as run takes subs under the lock but then delivers to
+	// them without it, so the slice it took must never change
+	subs := make([]*subscriber[T], len(m.subs), len(m.subs)+1)
+	copy(subs, m.subs)
+	m.subs = append(subs, s)
+	m.mu.Unlock()
+	return s.ch
+}
+
+// Unsubscribe stops delivery of ticks to ch, which must have been returned
+// by Subscribe. A tick being delivered concurrently may still be received.
+// As with Ticker.Stop, the channel is not closed.
+func (m *Metronome[T, D]) Unsubscribe(ch <-chan T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, s := range m.subs {
+		if s.ch == ch {
+			subs := make([]*subscriber[T], 0, len(m.subs)-1)
+			subs = append(subs, m.subs[:i]...)
+			m.subs = append(subs, m.subs[i+1:]...)
+			close(s.quit)
+			return
+		}
+	}
+}
+
+// Stop turns off the Metronome. After Stop, no more ticks will be sent to
+// any subscriber. It is fine to call Stop more than once.
+func (m *Metronome[T, D]) Stop() {
+	m.stop.Do(func() {
+		m.tk.Stop()
+		close(m.done)
+	})
+}
@@ -0,0 +1,53 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+func TestMetronome(t *testing.T) {
+	s := steppedtime.NewClock()
+	m := NewMetronome(FromSteppedtime(s), steppedtime.Second)
+	defer m.Stop()
+	a := m.Subscribe(0, Block)
+	b := m.Subscribe(1, DropNewest)
+
+	for i := 1; i <= 2; i++ {
+		s.Step(steppedtime.Second)
+		want := steppedtime.Time(0).Add(steppedtime.Duration(i) * steppedtime.Second)
+		if got := <-a; got != want {
+			t.Errorf("first subscriber got %v, want %v", got, want)
+		}
+		if got := <-b; got != want {
+			t.Errorf("second subscriber got %v, want %v", got, want)
+		}
+	}
+
+	m.Unsubscribe(a)
+	s.Step(steppedtime.Second)
+	<-b
+	select {
+	case <-a:
+		t.Errorf("tick delivered after Unsubscribe")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestMetronomeDropOldest(t *testing.T) {
+	s := steppedtime.NewClock()
+	m := NewMetronome(FromSteppedtime(s), steppedtime.Second)
+	defer m.Stop()
+	ch := m.Subscribe(1, DropOldest)
+	probe := m.Subscribe(0, Block) // Delivered after ch, so paces the steps
+
+	for i := 0; i < 3; i++ {
+		s.Step(steppedtime.Second)
+		<-probe
+	}
+	if got, want := <-ch, steppedtime.Time(3*steppedtime.Second); got != want {
+		t.Errorf("buffered tick %v, want latest %v", got, want)
+	}
+}
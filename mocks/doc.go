@@ -0,0 +1,13 @@
+// Package mocks provides expectation-style mocks of the interfaces defined
+// in [github.com/noodlebox/clock], for tests that would rather assert on how
+// a clock is used than simulate the flow of time with
+// [github.com/noodlebox/clock/mocktime]. Each mock embeds a [mock.Mock] from
+// testify, so expectations are set and checked with its API, as with On,
+// Return, and AssertExpectations, and arguments matched with its matchers,
+// such as [mock.Anything]. Functions cannot be compared, so match the f
+// argument of AfterFunc with mock.Anything or [mock.AnythingOfType].
+//
+// The mocks are generated from the interfaces by gen.go.
+package mocks
+
+//go:generate go run gen.go
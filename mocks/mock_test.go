@@ -0,0 +1,81 @@
+package mocks_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	. "github.com/noodlebox/clock/mocks"
+)
+
+type recorder struct {
+	errs []string
+}
+
+func (*recorder) Logf(format string, args ...any) {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errs = append(r.errs, format)
+}
+
+func (*recorder) FailNow() {}
+
+func TestClock(t *testing.T) {
+	epoch := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	ch := make(chan time.Time, 1)
+	ch <- epoch
+
+	m := new(Clock)
+	m.On("Now").Return(epoch).Once()
+	m.On("After", time.Second).Return((<-chan time.Time)(ch))
+	tm := new(Timer)
+	m.On("AfterFunc", time.Minute, mock.Anything).Return(tm)
+
+	if got := m.Now(); !got.Equal(epoch) {
+		t.Errorf("Now() = %v, want %v", got, epoch)
+	}
+	if got := <-m.After(time.Second); !got.Equal(epoch) {
+		t.Errorf("<-After(1s) = %v, want %v", got, epoch)
+	}
+	if got := m.AfterFunc(time.Minute, func() {}); got != tm {
+		t.Errorf("AfterFunc(1m, f) = %v, want %v", got, tm)
+	}
+	m.AssertExpectations(t)
+	m.AssertCalled(t, "After", time.Second)
+	m.AssertNotCalled(t, "Sleep", mock.Anything)
+}
+
+func TestClockNilReturns(t *testing.T) {
+	m := new(Clock)
+	m.On("LoadLocation", "Nowhere").Return(nil, nil)
+	if loc, err := m.LoadLocation("Nowhere"); loc != nil || err != nil {
+		t.Errorf("LoadLocation() = %v, %v; want nil, nil", loc, err)
+	}
+}
+
+func TestClockUnexpected(t *testing.T) {
+	defer func() {
+		if err := recover(); err == nil {
+			t.Errorf("unexpected call should have panicked")
+		}
+	}()
+	m := new(Clock)
+	m.On("Sleep", time.Second)
+	m.Sleep(time.Minute)
+}
+
+func TestAssertExpectations(t *testing.T) {
+	m := new(Timer)
+	m.On("Stop").Return(true).Times(2)
+	m.On("Reset", mock.Anything).Return(false)
+	m.Stop()
+
+	r := new(recorder)
+	if m.AssertExpectations(r) {
+		t.Errorf("AssertExpectations succeeded with unmet expectations")
+	}
+	if len(r.errs) == 0 {
+		t.Errorf("AssertExpectations reported no errors")
+	}
+}
@@ -0,0 +1,56 @@
+package mocktime
+
+import (
+	"github.com/noodlebox/clock/internal/receive"
+)
+
+// observe advances c by d while ready to receive from ch, as set up by
+// receive.Ready, so that a Ticker created by NewTicker delivers its tick at once
+// and is rescheduled before observe returns, rather than leaving both to a
+// goroutine that a later step might race. Values already due are received
+// before it returns, so there is no need to wait on real time for them.
+func (c ClockOn[RT]) observe(ch <-chan Time, d Duration) (v Time, ok bool) {
+	recv := receive.Ready(c.Clock, ch)
+	c.Seek(c.Now().Add(d))
+	x, ok := recv()
+	return x.(Time), ok
+}
+
+// RequireFires advances c by within, triggering timers due along the way in
+// order as with Seek, and receives from ch, failing the test at once with
+// t.Fatalf if nothing was sent. It returns the value received. Unlike mixing
+// Step with a select on a real timeout, this does not race with the delivery
+// of ticks, nor does it wait on real time.
+func (c ClockOn[RT]) RequireFires(t TB, ch <-chan Time, within Duration) Time {
+	t.Helper()
+	v, ok := c.observe(ch, within)
+	if !ok {
+		t.Fatalf("mocktime: nothing sent on channel within %v", within)
+	}
+	return v
+}
+
+// AssertNoTickWithin advances c by d, triggering timers due along the way in
+// order as with Seek, and reports an error on t if anything is sent on ch,
+// including any value still on its way once c has been advanced.
+func (c ClockOn[RT]) AssertNoTickWithin(t TB, ch <-chan Time, d Duration) {
+	t.Helper()
+	if v, ok := c.observe(ch, d); ok {
+		t.Errorf("mocktime: unexpected value %v sent on channel within %v", v, d)
+	}
+}
+
+// RequireFires advances the global Clock instance by within and then
+// receives from ch, failing the test if nothing was sent. See
+// [Clock.RequireFires].
+func RequireFires(t TB, ch <-chan Time, within Duration) Time {
+	t.Helper()
+	return clock().RequireFires(t, ch, within)
+}
+
+// AssertNoTickWithin advances the global Clock instance by d and reports an
+// error on t if anything is then sent on ch. See [Clock.AssertNoTickWithin].
+func AssertNoTickWithin(t TB, ch <-chan Time, d Duration) {
+	t.Helper()
+	clock().AssertNoTickWithin(t, ch, d)
+}
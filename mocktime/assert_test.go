@@ -0,0 +1,43 @@
+package mocktime_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestRequireFires(t *testing.T) {
+	c := NewClockAt(Unix(0, 0))
+	c.Stop()
+	tk := c.NewTicker(Second)
+	defer tk.Stop()
+
+	tb := new(fakeTB)
+	c.AssertNoTickWithin(tb, tk.C(), Second/2)
+	if got, want := c.RequireFires(tb, tk.C(), Second/2), Unix(1, 0); !got.Equal(want) {
+		t.Errorf("RequireFires() = %v, want %v", got, want)
+	}
+	if n := tb.failed(); n != 0 {
+		t.Fatalf("reported %d errors for a ticker firing on time", n)
+	}
+
+	c.AssertNoTickWithin(tb, tk.C(), Second)
+	if n := tb.failed(); n != 1 {
+		t.Errorf("reported %d errors for an unexpected tick, want 1", n)
+	}
+	tm := c.NewTimer(Hour)
+	c.RequireFires(tb, tm.C(), Minute)
+	if n := tb.failed(); n != 2 {
+		t.Errorf("reported %d errors for a timer not firing, want 2", n)
+	}
+
+	// A buffered ticker delivers by goroutine, after the clock has moved
+	btk := c.NewBufferedTicker(Second, 0)
+	defer btk.Stop()
+	if got, want := c.RequireFires(tb, btk.C(), Second), c.Now(); !got.Equal(want) {
+		t.Errorf("RequireFires() = %v from a buffered ticker, want %v", got, want)
+	}
+	if n := tb.failed(); n != 2 {
+		t.Errorf("reported %d errors for a buffered ticker firing on time, want 2", n)
+	}
+}
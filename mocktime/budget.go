@@ -0,0 +1,65 @@
+package mocktime
+
+// budget tracks the amount of simulated time a test may step through.
+type budget struct {
+	t         TB
+	limit     Duration
+	spent     Duration
+	exhausted bool
+}
+
+// charge accounts for advancing by dt, reporting whether it is within the
+// budget. An error is reported to the test only the first time the budget
+// is exceeded.
+func (b *budget) charge(dt Duration) bool {
+	if b.t == nil {
+		return true
+	}
+	if b.exhausted || b.spent+dt > b.limit {
+		if !b.exhausted {
+			b.t.Helper()
+			b.t.Errorf("mocktime: stepping by %v exceeds budget of %v (%v already spent)", dt, b.limit, b.spent)
+			b.exhausted = true
+		}
+		return false
+	}
+	b.spent += dt
+	return true
+}
+
+// SetBudget limits the total amount of simulated time that may be stepped
+// through by Set, Step, or Fastforward on c for the remainder of the test to
+// d. Once the budget would be exceeded, an error is reported on t and any
+// further steps are ignored, catching code that silently waits for hours
+// of simulated time. Time passing while the clock is running does not
+// count against the budget. The budget is removed when the test completes.
+func (c ClockOn[RT]) SetBudget(t TB, d Duration) {
+	t.Helper()
+	c.st.mu.Lock()
+	c.st.budget = budget{t: t, limit: d}
+	c.st.mu.Unlock()
+	t.Cleanup(func() {
+		c.st.mu.Lock()
+		c.st.budget = budget{}
+		c.st.mu.Unlock()
+	})
+}
+
+// Budget returns the amount of simulated time remaining in the budget set
+// by SetBudget, and false if no budget is set.
+func (c ClockOn[RT]) Budget() (remaining Duration, ok bool) {
+	c.st.mu.Lock()
+	defer c.st.mu.Unlock()
+	if c.st.budget.t == nil {
+		return 0, false
+	}
+	return c.st.budget.limit - c.st.budget.spent, true
+}
+
+// SetBudget limits the total amount of simulated time that may be stepped
+// through on the global Clock instance for the remainder of the test. See
+// [Clock.SetBudget].
+func SetBudget(t TB, d Duration) {
+	t.Helper()
+	clock().SetBudget(t, d)
+}
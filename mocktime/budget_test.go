@@ -0,0 +1,55 @@
+package mocktime_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestSetBudget(t *testing.T) {
+	c := NewClock()
+	start := c.Now()
+	tb := new(fakeTB)
+	c.SetBudget(tb, Hour)
+
+	c.Step(30 * Minute)
+	c.Set(c.Now().Add(20 * Minute))
+	if remaining, _ := c.Budget(); remaining != 10*Minute {
+		t.Errorf("Budget() = %v, want %v", remaining, 10*Minute)
+	}
+	if n := tb.failed(); n != 0 {
+		t.Fatalf("reported %d errors within budget", n)
+	}
+
+	// A timer far in the future would fastforward beyond the budget
+	c.NewTimer(24 * Hour)
+	c.Fastforward()
+	if n := tb.failed(); n != 1 {
+		t.Errorf("reported %d errors beyond budget, want 1", n)
+	}
+	if got, want := c.Since(start), 50*Minute; got != want {
+		t.Errorf("clock advanced by %v, want %v", got, want)
+	}
+
+	tb.finish()
+	if _, ok := c.Budget(); ok {
+		t.Errorf("budget still set after the test")
+	}
+}
+
+func TestSetMaxStep(t *testing.T) {
+	c := NewClockAt(Unix(0, 0))
+	c.SetMaxStep(Hour)
+	c.Step(Hour)
+
+	c.NewTimer(365 * 24 * Hour)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Fastforward past the maximum step did not panic")
+		}
+		if got, want := c.Now(), Unix(3600, 0); !got.Equal(want) {
+			t.Errorf("Now() = %v after a runaway step, want %v", got, want)
+		}
+	}()
+	c.Fastforward()
+}
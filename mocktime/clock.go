@@ -1,7 +1,11 @@
 package mocktime
 
 import (
+	"fmt"
+	"math/rand"
 	"runtime"
+	"sync"
+	"sync/atomic"
 
 	"github.com/noodlebox/clock/realtime"
 	"github.com/noodlebox/clock/relativetime"
@@ -12,19 +16,52 @@ type baseClock struct {
 }
 
 // Clock provides a drop in replacement for [realtime.Clock], but with
-// additional methods to allow direct control over its behavior.
-type Clock struct {
-	*relativetime.Clock[Time, Duration, *realtime.Timer]
+// additional methods to allow direct control over its behavior. It tracks
+// real time, as created by NewClock or NewClockAt.
+type Clock = ClockOn[*realtime.Timer]
+
+// ClockOn is a Clock tracking a reference clock whose Timers are of type
+// RT. NewClockOn returns one tracking any Reference; otherwise, it is the
+// same as Clock, which is its instantiation for real time.
+type ClockOn[RT relativetime.RTimer[Duration]] struct {
+	*relativetime.Clock[Time, Duration, RT]
 	baseClock // embed within a struct to ensure lower precedence
+
+	st *state
+}
+
+// state holds settings specific to a mocktime Clock, shared between copies.
+type state struct {
+	ref Reference // Tracked by the Clock
+
+	budget   budget
+	watchdog atomic.Pointer[watchdog] // Started by SetStuckHook
+	waits    []*Timer                 // From After or NewTimer, while watched
+	prune    int                      // Length of waits at which to prune it
+
+	sleepers atomic.Int32  // Goroutines currently blocked in Sleep
+	begun    atomic.Uint64 // Calls to Sleep, After, or NewTimer, to notice new waiters
+	fixed    atomic.Bool   // Whether explicit changes are ignored, without Manual
+	maxStep  atomic.Int64  // Limit on a single explicit advance, if positive
+	speedCap atomic.Uint64 // Bits of the float64 set by SetSpeedCap
+	travel   *travelMark   // Saved by Freeze or Travel, for TravelBack
+
+	observer atomic.Pointer[func(Duration, string)] // Set by SetSleepObserver
+
+	src  *lockedSource
+	rand *rand.Rand // Backed by src, which does its own locking
+
+	mu sync.Mutex
+}
+
+func newState(ref Reference) *state {
+	src := newLockedSource(DefaultSeed)
+	return &state{ref: ref, src: src, rand: rand.New(src)}
 }
 
 // NewClock returns a new Clock set to the current time.
 func NewClock() Clock {
-	rclock := realtime.NewClock()
-	return Clock{
-		relativetime.NewClock[Time, Duration, *realtime.Timer](rclock, rclock.Now(), 1.0),
-		baseClock{rclock}, // zero value would work, but be explicit for clarity
-	}
+	return NewClockAt(realtime.Now())
 }
 
 // NewClockAt returns a new Clock set to the the time, at.
@@ -33,24 +70,146 @@ func NewClockAt(at Time) Clock {
 	return Clock{
 		relativetime.NewClock[Time, Duration, *realtime.Timer](rclock, at, 1.0),
 		baseClock{rclock}, // zero value would work, but be explicit for clarity
+		newState(realReference{rclock}),
+	}
+}
+
+// ErrClosed is returned by Close when the Clock was already closed, and by
+// SleepUntilNext when it is closed before the time arrives.
+var ErrClosed = relativetime.ErrClosed
+
+// Close cancels every pending Timer and Ticker on c, wakes any goroutines
+// sleeping on it, and removes any hook set by SetStuckHook, so that a Clock
+// no longer needed may be torn down without leaking them. Afterwards, Sleep
+// returns immediately, and Timers and Tickers never fire. Close returns
+// ErrClosed if c was already closed.
+func (c ClockOn[RT]) Close() error {
+	c.SetStuckHook(0, nil)
+	return c.Clock.Close()
+}
+
+// Set sets the current time to now. If any timers are active, a value of now
+// earlier than the previous setting may lead to undefined behavior.
+func (c ClockOn[RT]) Set(now Time) {
+	dt := now.Sub(c.Now())
+	if !c.charge(dt) {
+		return
+	}
+	defer c.progressed()
+	if dt <= 0 || c.SpeedCap() <= 0 {
+		c.Clock.Set(now)
+		return
+	}
+	c.pace(dt, c.Clock.Step)
+}
+
+// Step advances the current time by dt. If any timers are active, a negative
+// value for dt may lead to undefined behavior.
+func (c ClockOn[RT]) Step(dt Duration) {
+	if !c.charge(dt) {
+		return
+	}
+	defer c.progressed()
+	c.pace(dt, c.Clock.Step)
+}
+
+// Seek advances the current time to t, stopping at each timer due before
+// then to trigger it at the time it was scheduled, in order. If t is earlier
+// than the current time, Seek does nothing.
+func (c ClockOn[RT]) Seek(t Time) {
+	dt := t.Sub(c.Now())
+	if !c.charge(dt) {
+		return
+	}
+	defer c.progressed()
+	c.seek(dt, t)
+}
+
+// SetOffset adjusts the current time by delta, which may be negative. If
+// fire is true, timers due at or before the adjusted time are triggered, as
+// with Step. Otherwise, every timer is shifted along with the clock, so that
+// the time remaining until each triggers is unchanged.
+func (c ClockOn[RT]) SetOffset(delta Duration, fire bool) {
+	if !c.charge(delta) {
+		return
+	}
+	defer c.progressed()
+	c.pace(delta, func(d Duration) { c.Clock.SetOffset(d, fire) })
+}
+
+// StepToNext advances the current time exactly to that of the next
+// scheduled timer, triggering it along with any others due at that time. It
+// returns the time it advanced to, or false if no timers are scheduled or
+// the advance would exceed the budget set by SetBudget.
+func (c ClockOn[RT]) StepToNext() (when Time, ok bool) {
+	when = c.NextAt()
+	if when.IsZero() {
+		return Time{}, false
+	}
+	dt := c.Until(when)
+	if dt < 0 {
+		// Ensure we're never stepping backwards
+		dt = 0
+	}
+	if !c.charge(dt) {
+		return Time{}, false
 	}
+	defer c.progressed()
+	c.seek(dt, when)
+	return when, true
+}
+
+// Seek to t, dt ahead, paced as set by SetSpeedCap.
+func (c ClockOn[RT]) seek(dt Duration, t Time) {
+	if dt <= 0 || c.SpeedCap() <= 0 {
+		c.Clock.Seek(t)
+		return
+	}
+	c.pace(dt, func(d Duration) {
+		if next := c.Now().Add(d); next.Before(t) {
+			c.Clock.Seek(next)
+		} else {
+			c.Clock.Seek(t)
+		}
+	})
 }
 
 // Fastforward steps forward to trigger timers until there are no timers left
 // to trigger.
-func (c Clock) Fastforward() {
+func (c ClockOn[RT]) Fastforward() {
 	active := c.Active()
 	c.Stop()
-	for when := c.NextAt(); !when.IsZero(); when = c.NextAt() {
-		dt := c.Until(when)
-		if dt < 0 {
-			// Ensure we're never stepping backwards
-			dt = 0
-		}
-		c.Step(dt)
+	for _, ok := c.StepToNext(); ok; _, ok = c.StepToNext() {
 		runtime.Gosched()
 	}
 	if active {
 		c.Start()
 	}
 }
+
+// SetMaxStep limits how far c may be advanced at once by Set, Step, Seek,
+// SetOffset, StepToNext, or Fastforward (for each timer it steps to) to d.
+// Any of them asked to advance further panics, so that a miscomputed
+// duration fast-forwarding the clock by years fails loudly, with a trace
+// of the call responsible. Time passing while the clock is running does not
+// count. If d <= 0, there is no limit, which is the default.
+func (c ClockOn[RT]) SetMaxStep(d Duration) {
+	c.st.maxStep.Store(int64(d))
+}
+
+// charge accounts for an explicit advancement of the clock by dt, reporting
+// whether it should be allowed.
+func (c ClockOn[RT]) charge(dt Duration) bool {
+	if c.st.fixed.Load() {
+		return false
+	}
+	if dt <= 0 {
+		return true
+	}
+	if max := Duration(c.st.maxStep.Load()); max > 0 && dt > max {
+		panic(fmt.Sprintf("mocktime: advancing by %v exceeds the maximum step of %v", dt, max))
+	}
+	c.st.mu.Lock()
+	defer c.st.mu.Unlock()
+	return c.st.budget.charge(dt)
+}
@@ -1,9 +1,11 @@
 package mocktime_test
 
 import (
+	"runtime"
 	"testing"
 
 	. "github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/steppedtime"
 )
 
 func BenchmarkNow(b *testing.B) {
@@ -83,3 +85,252 @@ func BenchmarkClockStopStep(b *testing.B) {
 	Set(start)
 	Start()
 }
+
+// Test that an AfterFunc callback may use the clock and its own Timer.
+func TestAfterFuncReentrant(t *testing.T) {
+	c := NewClock()
+	c.Stop()
+	done := make(chan int)
+	n := 0
+	var tm *Timer
+	tm = c.AfterFunc(Second, func() {
+		n++
+		if tm.Stop() {
+			t.Errorf("Stop reported a fired timer as active")
+		}
+		c.NewTimer(Second).Stop()
+		if n < 3 {
+			tm.Reset(Second)
+		}
+		done <- n
+	})
+
+	for i := 1; i <= 3; i++ {
+		c.Step(Second)
+		if got := <-done; got != i {
+			t.Fatalf("callback ran %d times, want %d", got, i)
+		}
+	}
+}
+
+func TestElapsed(t *testing.T) {
+	s := steppedtime.NewClock()
+	c := NewClockOn(SteppedReference(s, Unix(0, 0)), Unix(0, 0))
+	c.Start()
+	s.Step(2 * steppedtime.Second)
+	c.Stop()
+	s.Step(3 * steppedtime.Second)
+	c.Step(Hour)
+
+	if got, want := c.ElapsedActive(), 2*Second; got != want {
+		t.Errorf("ElapsedActive() = %v, want %v", got, want)
+	}
+	if got, want := c.ElapsedStopped(), 3*Second; got != want {
+		t.Errorf("ElapsedStopped() = %v, want %v", got, want)
+	}
+	if got, want := c.Elapsed(), Hour+2*Second; got != want {
+		t.Errorf("Elapsed() = %v, want %v", got, want)
+	}
+
+	c.SetScale(2)
+	c.Start()
+	s.Step(steppedtime.Second)
+	if got, want := c.Elapsed(), Hour+4*Second; got != want {
+		t.Errorf("Elapsed() = %v at double speed, want %v", got, want)
+	}
+	if got, want := c.ElapsedActive(), 3*Second; got != want {
+		t.Errorf("ElapsedActive() = %v at double speed, want %v", got, want)
+	}
+}
+
+func TestSetOffset(t *testing.T) {
+	start := Unix(0, 0)
+	c := NewClockAt(start)
+	c.Stop()
+	fired := c.NewTimer(Second)
+	shifted := c.NewTimer(3 * Second)
+
+	c.SetOffset(2*Second, true)
+	select {
+	case <-fired.C():
+	default:
+		t.Errorf("timer skipped over by SetOffset(2s, true) did not fire")
+	}
+
+	c.SetOffset(2*Second, false)
+	if got, want := c.Now(), start.Add(4*Second); !got.Equal(want) {
+		t.Errorf("Now() = %v, want %v", got, want)
+	}
+	if got, want := c.NextAt(), start.Add(5*Second); !got.Equal(want) {
+		t.Errorf("NextAt() = %v after SetOffset(2s, false), want %v", got, want)
+	}
+	c.Step(Second)
+	select {
+	case <-shifted.C():
+	default:
+		t.Errorf("shifted timer did not fire")
+	}
+}
+
+func TestSeek(t *testing.T) {
+	start := Unix(0, 0)
+	c := NewClockAt(start)
+	c.Stop()
+	var timers []*Timer
+	for i := 1; i <= 3; i++ {
+		timers = append(timers, c.NewTimer(Duration(i)*Second))
+	}
+
+	c.Seek(start.Add(5 * Second))
+	if got, want := c.Now(), start.Add(5*Second); !got.Equal(want) {
+		t.Errorf("Now() = %v after Seek, want %v", got, want)
+	}
+	for i, tm := range timers {
+		want := start.Add(Duration(i+1) * Second)
+		select {
+		case got := <-tm.C():
+			if !got.Equal(want) {
+				t.Errorf("timer %d fired at %v, want %v", i, got, want)
+			}
+		default:
+			t.Errorf("timer %d did not fire", i)
+		}
+	}
+}
+
+func TestStepToNext(t *testing.T) {
+	start := Unix(0, 0)
+	c := NewClockAt(start)
+	c.Stop()
+	a := c.NewTimer(2 * Second)
+	b := c.NewTimer(Second)
+
+	for i, tm := range []*Timer{b, a} {
+		want := start.Add(Duration(i+1) * Second)
+		when, ok := c.StepToNext()
+		if !ok || !when.Equal(want) {
+			t.Fatalf("StepToNext() = %v, %v; want %v, true", when, ok, want)
+		}
+		select {
+		case <-tm.C():
+		default:
+			t.Errorf("timer due at %v did not fire", want)
+		}
+	}
+	if when, ok := c.StepToNext(); ok {
+		t.Errorf("StepToNext() = %v, true with no timers scheduled", when)
+	}
+}
+
+func TestCancelTag(t *testing.T) {
+	c := NewClock()
+	c.Stop()
+	for i := 0; i < 10; i++ {
+		c.AfterFunc(Second, func() { t.Errorf("canceled timer fired") }).SetTag(t)
+	}
+	kept := c.NewTimer(Second)
+
+	if got := len(c.TimersByTag(t)); got != 10 {
+		t.Errorf("TimersByTag() found %d timers, want 10", got)
+	}
+	if n := c.CancelTag(t); n != 10 {
+		t.Errorf("CancelTag() = %d, want 10", n)
+	}
+	c.Step(Second)
+	<-kept.C()
+}
+
+func TestTimerZeroDurationStopped(t *testing.T) {
+	c := NewClock()
+	c.Stop()
+	select {
+	case <-c.NewTimer(0).C():
+	default:
+		t.Errorf("NewTimer(0) did not fire on a stopped clock")
+	}
+	tm := c.NewTimer(Hour)
+	tm.Reset(-Second)
+	select {
+	case <-tm.C():
+	default:
+		t.Errorf("Reset(-1s) did not fire on a stopped clock")
+	}
+	tm.Reset(Hour)
+	if !tm.FireNow() {
+		t.Errorf("FireNow() = false on an active timer")
+	}
+	<-tm.C()
+}
+
+func TestTimerPause(t *testing.T) {
+	start := Unix(0, 0)
+	c := NewClockAt(start)
+	c.Stop()
+	tm := c.NewTimer(3 * Second)
+	c.Step(Second)
+	if !tm.Pause() {
+		t.Fatalf("Pause() = false on an active timer")
+	}
+	if when := c.NextAt(); !when.IsZero() {
+		t.Errorf("NextAt() = %v while paused, want zero", when)
+	}
+	c.Step(Hour)
+
+	if !tm.Resume() {
+		t.Fatalf("Resume() = false on a paused timer")
+	}
+	if when, want := c.NextAt(), start.Add(Hour+3*Second); !when.Equal(want) {
+		t.Errorf("NextAt() = %v after Resume, want %v", when, want)
+	}
+	c.Step(2 * Second)
+	if got, want := <-tm.C(), start.Add(Hour+3*Second); !got.Equal(want) {
+		t.Errorf("timer fired at %v, want %v", got, want)
+	}
+}
+
+func TestUnbufferedTimers(t *testing.T) {
+	start := Unix(0, 0)
+	c := NewClockAt(start)
+	c.Stop()
+	c.SetUnbufferedTimers(true)
+
+	tm := c.NewTimer(Second)
+	c.Step(Second)
+	if !tm.Reset(Second) {
+		t.Errorf("Reset() = false with an undelivered value, want true")
+	}
+	select {
+	case v := <-tm.C():
+		t.Fatalf("received stale value %v after Reset", v)
+	default:
+	}
+
+	c.Step(Second)
+	if got, want := <-tm.C(), start.Add(2*Second); !got.Equal(want) {
+		t.Errorf("received %v, want %v", got, want)
+	}
+	if tm.Stop() {
+		t.Errorf("Stop() = true after the value was received, want false")
+	}
+	// Nothing is left behind by a value never received
+	before := runtime.NumGoroutine()
+	for i := 0; i < 100; i++ {
+		c.After(Second)
+	}
+	c.Step(Second)
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("%d goroutines after firing unreceived timers, want at most %d", after, before)
+	}
+
+	// Nor by Stop discarding one
+	tm.Reset(Second)
+	c.Step(Second)
+	if !tm.Stop() {
+		t.Errorf("Stop() = false with an undelivered value, want true")
+	}
+	select {
+	case v := <-tm.C():
+		t.Fatalf("received stale value %v after Stop", v)
+	default:
+	}
+}
@@ -0,0 +1,25 @@
+package mocktime
+
+import (
+	"github.com/noodlebox/clock/realtime"
+)
+
+// Clone returns a new Clock with the same time, scale, state, Mode, maximum
+// step, and speed cap as c, but otherwise independent of it, so that
+// table-driven subtests may each branch from a common prepared state
+// without repeating its setup. The new Clock has no Timers or Tickers,
+// unless periodic is true, in which case each pending Ticker created by
+// TickFunc is copied, as with [relativetime.Clock.Clone]. No budget, stuck
+// hook, sleep observer, or checks set up by Strict are carried over, and its
+// source of randomness starts afresh from DefaultSeed.
+func (c ClockOn[RT]) Clone(periodic bool) ClockOn[RT] {
+	st := newState(c.st.ref)
+	st.fixed.Store(c.st.fixed.Load())
+	st.maxStep.Store(c.st.maxStep.Load())
+	st.speedCap.Store(c.st.speedCap.Load())
+	return ClockOn[RT]{
+		c.Clock.Clone(periodic),
+		baseClock{realtime.NewClock()},
+		st,
+	}
+}
@@ -0,0 +1,36 @@
+package mocktime_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestClone(t *testing.T) {
+	c := NewClockAt(Unix(0, 0))
+	var ticks atomic.Int32
+	c.TickFunc(Second, func() { ticks.Add(1) })
+	c.NewTicker(Second)
+	c.Step(Minute)
+
+	for _, periodic := range []bool{false, true} {
+		cl := c.Clone(periodic)
+		if got, want := cl.Now(), c.Now(); !got.Equal(want) {
+			t.Errorf("Clone(%v).Now() = %v, want %v", periodic, got, want)
+		}
+		want := cl.Now().Add(Second)
+		if !periodic {
+			want = Time{}
+		}
+		if got := cl.NextAt(); !got.Equal(want) {
+			t.Errorf("Clone(%v).NextAt() = %v, want %v", periodic, got, want)
+		}
+
+		// Changes to the clone leave the original alone
+		cl.Step(Hour)
+		if got, want := c.Now(), Unix(60, 0); !got.Equal(want) {
+			t.Errorf("original Now() = %v after stepping clone, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package mocktime
+
+import (
+	"github.com/noodlebox/clock/internal/daily"
+)
+
+// SleepUntilNext pauses the current goroutine until the next time the wall
+// clock in loc reads clockTime, given as "15:04" or "15:04:05". See
+// [realtime.Clock.SleepUntilNext]. If loc is nil, the location of the
+// current time on c is used. It returns ErrClosed if c is closed before
+// then.
+func (c ClockOn[RT]) SleepUntilNext(clockTime string, loc *Location) error {
+	now := c.Now()
+	next, err := daily.Next(now, clockTime, loc)
+	if err != nil {
+		return err
+	}
+	c.Sleep(next.Sub(now))
+	select {
+	case <-c.Done():
+		return ErrClosed
+	default:
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package mocktime_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestSleepUntilNext(t *testing.T) {
+	ny, err := LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	// The day before clocks spring forward, so the next 09:00 is 23h away
+	c := NewClockAt(Date(2024, March, 9, 9, 30, 0, 0, ny))
+	done := make(chan error)
+	go func() { done <- c.SleepUntilNext("09:00", ny) }()
+	for c.NextAt().IsZero() {
+		runtime.Gosched()
+	}
+	c.Fastforward()
+	if err := <-done; err != nil {
+		t.Fatalf("SleepUntilNext() = %v", err)
+	}
+	if got, want := c.Now(), Date(2024, March, 10, 9, 0, 0, 0, ny); !got.Equal(want) {
+		t.Errorf("woke at %v, want %v", got, want)
+	}
+	if got, want := c.Now().Sub(Date(2024, March, 9, 9, 30, 0, 0, ny)), 23*Hour-30*Minute; got != want {
+		t.Errorf("slept %v, want %v across the change", got, want)
+	}
+
+	if err := c.SleepUntilNext("9am", ny); err == nil {
+		t.Errorf("SleepUntilNext(\"9am\") = nil, want an error")
+	}
+}
@@ -0,0 +1,110 @@
+package mocktime
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/noodlebox/clock/relativetime"
+)
+
+// State is the JSON representation of a Clock used by the HTTP control
+// endpoint returned by NewHandler.
+type State struct {
+	Now    Time    `json:"now"`
+	Scale  float64 `json:"scale"`
+	Active bool    `json:"active"`
+	NextAt *Time   `json:"next_at,omitempty"` // Omitted if no timers are scheduled
+}
+
+type handler[RT relativetime.RTimer[Duration]] struct {
+	c ClockOn[RT]
+}
+
+// NewHandler returns an http.Handler exposing control of c over a small
+// JSON API, allowing integration tests or test environments to manipulate
+// time remotely. Each request responds with the resulting State of the
+// clock. The following requests are supported, relative to the path the
+// handler is mounted at:
+//
+//	GET  /        Report the current state
+//	GET  /next    Same as GET /, provided for readability
+//	POST /set     Set the current time, given {"now": "2009-11-10T23:00:00Z"}
+//	POST /step    Step the current time, given {"duration": "1h30m"}
+//	POST /scale   Set the scaling factor, given {"scale": 2.5}
+//	POST /start   Start the clock
+//	POST /stop    Stop the clock
+//
+// The handler performs no authentication of its own, and should not be
+// exposed beyond trusted environments. The clock c may track any reference
+// clock, as one created by NewClockOn.
+func NewHandler[RT relativetime.RTimer[Duration]](c ClockOn[RT]) http.Handler {
+	return &handler[RT]{c}
+}
+
+func (h *handler[RT]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+
+	method := http.MethodPost
+	if path == "" || path == "next" {
+		method = http.MethodGet
+	}
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Now      *Time    `json:"now"`
+		Duration string   `json:"duration"`
+		Scale    *float64 `json:"scale"`
+	}
+	if method == http.MethodPost && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch path {
+	case "", "next":
+	case "set":
+		if req.Now == nil {
+			http.Error(w, `missing "now"`, http.StatusBadRequest)
+			return
+		}
+		h.c.Set(*req.Now)
+	case "step":
+		dt, err := ParseDuration(req.Duration)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		h.c.Step(dt)
+	case "scale":
+		if req.Scale == nil {
+			http.Error(w, `missing "scale"`, http.StatusBadRequest)
+			return
+		}
+		h.c.SetScale(*req.Scale)
+	case "start":
+		h.c.Start()
+	case "stop":
+		h.c.Stop()
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	state := State{
+		Now:    h.c.Now(),
+		Scale:  h.c.Scale(),
+		Active: h.c.Active(),
+	}
+	if next := h.c.NextAt(); !next.IsZero() {
+		state.NextAt = &next
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
@@ -0,0 +1,85 @@
+package mocktime_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+func TestHandler(t *testing.T) {
+	epoch := Date(2009, November, 10, 23, 0, 0, 0, UTC)
+	c := NewClockAt(epoch)
+	c.NewTimer(2 * Hour)
+	srv := httptest.NewServer(NewHandler(c))
+	defer srv.Close()
+
+	do := func(method, path, body string) (state State) {
+		t.Helper()
+		req, _ := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s %s: %s", method, path, resp.Status)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		return
+	}
+
+	if s := do("GET", "/", ""); !s.Now.Equal(epoch) || s.Active || s.NextAt == nil {
+		t.Errorf("GET / = %+v", s)
+	}
+	if s := do("POST", "/step", `{"duration": "1h"}`); !s.Now.Equal(epoch.Add(Hour)) {
+		t.Errorf("POST /step: now = %v, want %v", s.Now, epoch.Add(Hour))
+	}
+	if s := do("POST", "/scale", `{"scale": 2.5}`); s.Scale != 2.5 {
+		t.Errorf("POST /scale: scale = %v, want 2.5", s.Scale)
+	}
+	if s := do("POST", "/set", `{"now": "2009-11-11T02:00:00Z"}`); s.NextAt != nil {
+		t.Errorf("POST /set: next_at = %v, want none", s.NextAt)
+	}
+	if s := do("POST", "/start", ""); !s.Active {
+		t.Errorf("POST /start: clock not active")
+	}
+	if s := do("POST", "/stop", ""); s.Active {
+		t.Errorf("POST /stop: clock still active")
+	}
+
+	resp, err := http.Get(srv.URL + "/step")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET /step: %s, want %d", resp.Status, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandlerOn(t *testing.T) {
+	epoch := Unix(0, 0)
+	c := NewClockOn(SteppedReference(steppedtime.NewClock(), epoch), epoch)
+	srv := httptest.NewServer(NewHandler(c))
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+"/step", "application/json", strings.NewReader(`{"duration": "1h"}`))
+	if err != nil {
+		t.Fatalf("POST /step: %v", err)
+	}
+	defer resp.Body.Close()
+	var s State
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		t.Fatalf("POST /step: %v", err)
+	}
+	if want := epoch.Add(Hour); !s.Now.Equal(want) || !c.Now().Equal(want) {
+		t.Errorf("POST /step: now = %v, want %v", s.Now, want)
+	}
+}
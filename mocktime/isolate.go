@@ -0,0 +1,60 @@
+package mocktime
+
+import (
+	"context"
+	"sync"
+
+	"github.com/noodlebox/clock/internal/ctxkey"
+)
+
+// Of tests to the Clocks isolated for them by WithIsolated
+var isolated sync.Map
+
+// WithIsolated returns a fresh Clock for the remainder of the test, as the
+// global instance is at startup: set to a fixed epoch and running. It is
+// closed when the test completes.
+//
+// The global Clock instance is shared by every test in the package, so it is
+// left untouched, and tests using WithIsolated may run in parallel. The
+// isolation extends only to the returned Clock: the package-level functions,
+// such as Step and Now, still act on the global instance, so a test must use
+// the methods of the returned Clock instead. Code under test is handed the
+// Clock for its test: directly, through a context made by NewContext, or by
+// looking it up with Isolated.
+func WithIsolated(t TB) Clock {
+	t.Helper()
+	c := newGlobal()
+	isolated.Store(t, c)
+	t.Cleanup(func() {
+		isolated.Delete(t)
+		c.Close()
+	})
+	return c
+}
+
+// Isolated returns the Clock given to t by WithIsolated, or the global Clock
+// instance if there is none.
+func Isolated(t TB) Clock {
+	if c, ok := isolated.Load(t); ok {
+		return c.(Clock)
+	}
+	return clock()
+}
+
+// NewContext returns a copy of ctx carrying c, to be retrieved with
+// FromContext, so that code under test may find the Clock isolated for its
+// test by WithIsolated without requiring it as a parameter. The key is the
+// one used by the root package's NewContext, so that its FromContext finds c
+// as well.
+func NewContext(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, ctxkey.Key{}, c)
+}
+
+// FromContext returns the Clock carried by ctx, as set by NewContext, or the
+// global Clock instance if there is none.
+func FromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(ctxkey.Key{}).(Clock); ok {
+		return c
+	}
+	return clock()
+}
@@ -0,0 +1,43 @@
+package mocktime_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/noodlebox/clock"
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestWithIsolated(t *testing.T) {
+	t.Run("group", func(t *testing.T) {
+		for _, name := range []string{"a", "b", "c", "d"} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+				c := WithIsolated(t)
+				if got := Isolated(t); got != c {
+					t.Fatalf("Isolated(t) is not the Clock from WithIsolated")
+				}
+				ctx := NewContext(context.Background(), c)
+				FromContext(ctx).Stop()
+				if c.Active() {
+					t.Fatalf("isolated Clock still active after Stop")
+				}
+				start := c.Now()
+				FromContext(ctx).Step(Hour)
+				if got, want := c.Now(), start.Add(Hour); !got.Equal(want) {
+					t.Errorf("isolated Clock at %v after Step, want %v", got, want)
+				}
+
+				// The root package finds it under the same key
+				if got, want := clock.FromContext(ctx).Now(), c.Now(); !got.Equal(want) {
+					t.Errorf("clock.FromContext(ctx).Now() = %v, want %v", got, want)
+				}
+			})
+		}
+	})
+
+	// None of it touched the global Clock instance
+	if got := FromContext(context.Background()); got != Isolated(t) || !got.Active() {
+		t.Errorf("global Clock stopped by tests using isolated ones")
+	}
+}
@@ -1,6 +1,8 @@
 package mocktime
 
 import (
+	"math/rand"
+	"sync/atomic"
 	"time"
 
 	"github.com/noodlebox/clock/realtime"
@@ -33,6 +35,29 @@ type Timer = relativetime.Timer[Time, Duration]
 // [Duration].
 type Ticker = relativetime.Ticker[Time, Duration]
 
+// StdTimer is an alias for [relativetime.StdTimer] using the types [Time]
+// and [Duration]. Its channel is available as the field C, as with
+// [time.Timer].
+type StdTimer = relativetime.StdTimer[Time, Duration]
+
+// StdTicker is an alias for [relativetime.StdTicker] using the types [Time]
+// and [Duration]. Its channel is available as the field C, as with
+// [time.Ticker].
+type StdTicker = relativetime.StdTicker[Time, Duration]
+
+// TickID is an alias for [relativetime.TickID].
+type TickID = relativetime.TickID
+
+// Firing is an alias for [relativetime.Firing] using the type [Time].
+type Firing = relativetime.Firing[Time]
+
+// Schedule is an alias for [relativetime.Schedule] using the type [Time].
+type Schedule = relativetime.Schedule[Time]
+
+// Scheduled is an alias for [relativetime.Scheduled] using the types [Time]
+// and [Duration].
+type Scheduled = relativetime.Scheduled[Time, Duration]
+
 // Duration constants.
 const (
 	Nanosecond  = time.Nanosecond
@@ -102,73 +127,150 @@ const (
 
 // Wrap package-level functions around Clock methods
 
-var clock Clock
+var global atomic.Pointer[Clock]
+
+// clock returns the global Clock instance.
+func clock() Clock { return *global.Load() }
 
 func init() {
-	clock = NewClockAt(realtime.Clock{}.Date(
+	c := newGlobal()
+	global.Store(&c)
+}
+
+// newGlobal returns a Clock as the global instance is at startup: set to a
+// fixed epoch and running.
+func newGlobal() Clock {
+	c := NewClockAt(realtime.Clock{}.Date(
 		2009, November, 10, 23, 0, 0, 0, UTC,
 	))
-	clock.Start()
+	c.Start()
+	return c
 }
 
 // Start starts or resumes the global Clock instance.
-func Start() { clock.Start() }
+func Start() { clock().Start() }
 
 // Stop pauses the global Clock instance.
-func Stop() { clock.Stop() }
+func Stop() { clock().Stop() }
 
 // Active returns true if the global Clock instance is currently running.
-func Active() { clock.Active() }
+func Active() { clock().Active() }
 
 // SetScale sets the scaling factor for the global Clock instance.
-func SetScale(scale float64) { clock.SetScale(scale) }
+func SetScale(scale float64) { clock().SetScale(scale) }
+
+// SetUnbufferedTimers sets whether Timers created afterwards on the global
+// Clock instance behave as if unbuffered, as timers in Go 1.23 do. See
+// [relativetime.Clock.SetUnbufferedTimers].
+func SetUnbufferedTimers(enabled bool) { clock().SetUnbufferedTimers(enabled) }
 
 // Scale returns the scaling factor of the global Clock instance.
-func Scale() float64 { return clock.Scale() }
+func Scale() float64 { return clock().Scale() }
+
+// Elapsed returns the time elapsed on the global Clock instance since it was
+// created, including any change made by Set or Step.
+func Elapsed() Duration { return clock().Elapsed() }
+
+// ElapsedActive returns the real time elapsed while the global Clock
+// instance was running.
+func ElapsedActive() Duration { return clock().ElapsedActive() }
+
+// ElapsedStopped returns the real time elapsed while the global Clock
+// instance was stopped.
+func ElapsedStopped() Duration { return clock().ElapsedStopped() }
+
+// Rand returns the source of randomness for the global Clock instance.
+func Rand() *rand.Rand { return clock().Rand() }
+
+// SetSeed reseeds the source of randomness for the global Clock instance.
+func SetSeed(seed int64) { clock().SetSeed(seed) }
+
+// Jitter returns d scaled by a random factor drawn uniformly from the range
+// [1-frac, 1+frac), using the source of randomness for the global Clock
+// instance.
+func Jitter(d Duration, frac float64) Duration { return clock().Jitter(d, frac) }
 
 // Set changes the current time on the global Clock instance to now.
-func Set(now Time) { clock.Set(now) }
+func Set(now Time) { clock().Set(now) }
 
 // Step advances the current time on the global Clock instance by dt.
-func Step(dt Duration) { clock.Step(dt) }
+func Step(dt Duration) { clock().Step(dt) }
+
+// Seek advances the current time on the global Clock instance to t,
+// triggering each timer due before then at the time it was scheduled.
+func Seek(t Time) { clock().Seek(t) }
+
+// SetOffset adjusts the current time on the global Clock instance by delta,
+// triggering any timers skipped over only if fire is true.
+func SetOffset(delta Duration, fire bool) { clock().SetOffset(delta, fire) }
 
 // NextAt returns the time of the next scheduled Timer or Ticker on the
 // global Clock instance.
-func NextAt() Time { return clock.NextAt() }
+func NextAt() Time { return clock().NextAt() }
+
+// StepToNext advances the global Clock instance exactly to the time of its
+// next scheduled timer, triggering it, and returns that time. It returns
+// false if no timers are scheduled.
+func StepToNext() (Time, bool) { return clock().StepToNext() }
 
 // Fastforward steps the global Clock instance forward to trigger timers
 // until there are no timers left to trigger on it.
-func Fastforward() { clock.Fastforward() }
+func Fastforward() { clock().Fastforward() }
+
+// SetMaxStep limits how far the global Clock instance may be advanced at
+// once. See [Clock.SetMaxStep].
+func SetMaxStep(d Duration) { clock().SetMaxStep(d) }
 
 // After waits for the duration to elapse and then sends the current time on
 // the returned channel. It is equivalent to NewTimer(d).C(). The underlying
 // Timer is not recovered by the garbage collector until the timer fires. If
 // efficiency is a concern, use clock.NewTimer instead and call Timer.Stop if
 // the timer is no longer needed.
-func After(d Duration) <-chan Time { return clock.After(d) }
+func After(d Duration) <-chan Time { return clock().After(d) }
 
 // Sleep pauses the current goroutine for at least the duration d. A negative
 // or zero duration causes Sleep to return immediately.
-func Sleep(d Duration) { clock.Sleep(d) }
+func Sleep(d Duration) { clock().Sleep(d) }
+
+// SleepUntilNext pauses the current goroutine until the next time the wall
+// clock in loc reads clockTime. See [Clock.SleepUntilNext].
+func SleepUntilNext(clockTime string, loc *Location) error {
+	return clock().SleepUntilNext(clockTime, loc)
+}
 
 // Tick is a convenience wrapper for NewTicker providing access to the
 // ticking channel only. While Tick is useful for clients that have no need
 // to shut down the Ticker, be aware that without a way to shut it down the
 // underlying Ticker cannot be recovered by the garbage collector; it
 // "leaks". Unlike NewTicker, Tick will return nil if d <= 0.
-func Tick(d Duration) <-chan Time { return clock.Tick(d) }
+func Tick(d Duration) <-chan Time { return clock().Tick(d) }
 
 // ParseDuration parses a duration string. A duration string is a possibly
 // signed sequence of decimal numbers, each with optional fraction and a unit
 // suffix, such as "300ms", "-1.5h" or "2h45m". Valid time units are "ns",
 // "us" (or "µs"), "ms", "s", "m", "h".
-func ParseDuration(s string) (Duration, error) { return clock.ParseDuration(s) }
+func ParseDuration(s string) (Duration, error) { return clock().ParseDuration(s) }
+
+// MustParseDuration is like ParseDuration, but panics if s cannot be parsed.
+func MustParseDuration(s string) Duration { return clock().MustParseDuration(s) }
+
+// ParseISODuration parses an ISO 8601 duration, such as "P1DT2H30M". See
+// [relativetime.Clock.ParseISODuration].
+func ParseISODuration(s string) (Duration, error) { return clock().ParseISODuration(s) }
+
+// FormatISODuration formats d as an ISO 8601 duration. See
+// [relativetime.Clock.FormatISODuration].
+func FormatISODuration(d Duration) string { return clock().FormatISODuration(d) }
+
+// DurationOf returns the sum of the given counts of units, written as
+// alternating pairs of a count and a unit. See [relativetime.Clock.DurationOf].
+func DurationOf(pairs ...Duration) Duration { return clock().DurationOf(pairs...) }
 
 // Since returns the time elapsed since t. It is shorthand for Now().Sub(t).
-func Since(t Time) Duration { return clock.Since(t) }
+func Since(t Time) Duration { return clock().Since(t) }
 
 // Until returns the duration until t. It is shorthand for t.Sub(Now()).
-func Until(t Time) Duration { return clock.Until(t) }
+func Until(t Time) Duration { return clock().Until(t) }
 
 // NewTicker returns a new Ticker containing a channel that will send the
 // current time on the channel after each tick. The period of the ticks is
@@ -176,49 +278,75 @@ func Until(t Time) Duration { return clock.Until(t) }
 // interval or drop ticks to make up for slow receivers. The duration d must
 // be greater than zero; if not, NewTicker will panic. Stop the ticker to
 // release associated resources.
-func NewTicker(d Duration) *Ticker { return clock.NewTicker(d) }
+func NewTicker(d Duration) *Ticker { return clock().NewTicker(d) }
+
+// NewBufferedTicker is like NewTicker, but rather than dropping ticks for
+// slow receivers, it buffers them to be delivered in order. At most limit
+// ticks are held until received, or any number if limit <= 0.
+func NewBufferedTicker(d Duration, limit int) *Ticker { return clock().NewBufferedTicker(d, limit) }
+
+// TickFunc calls f in its own goroutine after each tick, with the period of
+// the ticks specified by the duration argument. The C method of the returned
+// Ticker returns nil. The duration d must be greater than zero; if not,
+// TickFunc will panic. Stop the ticker to release associated resources.
+func TickFunc(d Duration, f func()) *Ticker { return clock().TickFunc(d, f) }
 
 // See [time.Date].
 func Date(year int, month Month, day, hour, min, sec, nsec int, loc *Location) Time {
-	return clock.Date(year, month, day, hour, min, sec, nsec, loc)
+	return clock().Date(year, month, day, hour, min, sec, nsec, loc)
 }
 
 // Now returns the current time on the global Clock instance.
-func Now() Time { return clock.Now() }
+func Now() Time { return clock().Now() }
 
 // See [time.Parse].
-func Parse(layout, value string) (Time, error) { return clock.Parse(layout, value) }
+func Parse(layout, value string) (Time, error) { return clock().Parse(layout, value) }
 
 // See [time.ParseInLocation].
 func ParseInLocation(layout, value string, loc *Location) (Time, error) {
-	return clock.ParseInLocation(layout, value, loc)
+	return clock().ParseInLocation(layout, value, loc)
 }
 
 // See [time.Unix].
-func Unix(sec int64, nsec int64) Time { return clock.Unix(sec, nsec) }
+func Unix(sec int64, nsec int64) Time { return clock().Unix(sec, nsec) }
 
 // See [time.UnixMicro].
-func UnixMicro(usec int64) Time { return clock.UnixMicro(usec) }
+func UnixMicro(usec int64) Time { return clock().UnixMicro(usec) }
 
 // See [time.UnixMilli].
-func UnixMilli(msec int64) Time { return clock.UnixMilli(msec) }
+func UnixMilli(msec int64) Time { return clock().UnixMilli(msec) }
 
 // AfterFunc waits for the duration to elapse and then calls f in its own
 // goroutine. It returns a Timer that can be used to cancel the call using
-// its Stop method.
-func AfterFunc(d Duration, f func()) *Timer { return clock.AfterFunc(d, f) }
+// its Stop method. As f is not called while holding any lock, it may safely
+// call methods on the clock or the returned Timer, such as Reset to
+// reschedule itself.
+func AfterFunc(d Duration, f func()) *Timer { return clock().AfterFunc(d, f) }
+
+// ScheduleFunc calls f in its own goroutine at each time given by s. See
+// [relativetime.Clock.ScheduleFunc].
+func ScheduleFunc(s Schedule, f func(Time)) *Scheduled { return clock().ScheduleFunc(s, f) }
 
 // NewTimer creates a new Timer that will send the current time on its
 // channel after at least duration d.
-func NewTimer(d Duration) *Timer { return clock.NewTimer(d) }
+func NewTimer(d Duration) *Timer { return clock().NewTimer(d) }
+
+// NewStdTimer is like NewTimer, but returns a StdTimer.
+func NewStdTimer(d Duration) *StdTimer { return clock().NewStdTimer(d) }
+
+// StdAfterFunc is like AfterFunc, but returns a StdTimer.
+func StdAfterFunc(d Duration, f func()) *StdTimer { return clock().StdAfterFunc(d, f) }
+
+// NewStdTicker is like NewTicker, but returns a StdTicker.
+func NewStdTicker(d Duration) *StdTicker { return clock().NewStdTicker(d) }
 
 // See [time.FixedZone].
-func FixedZone(name string, offset int) *Location { return clock.FixedZone(name, offset) }
+func FixedZone(name string, offset int) *Location { return clock().FixedZone(name, offset) }
 
 // See [time.LoadLocation].
-func LoadLocation(name string) (*Location, error) { return clock.LoadLocation(name) }
+func LoadLocation(name string) (*Location, error) { return clock().LoadLocation(name) }
 
 // See [time.LoadLocationFromTZData].
 func LoadLocationFromTZData(name string, data []byte) (*Location, error) {
-	return clock.LoadLocationFromTZData(name, data)
+	return clock().LoadLocationFromTZData(name, data)
 }
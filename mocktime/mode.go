@@ -0,0 +1,49 @@
+package mocktime
+
+// Mode is a set of flags describing how a Clock advances, for switching
+// between simulated and real time at runtime. Either flag may be set
+// independently of the other.
+type Mode uint8
+
+const (
+	// Tracking means the clock follows real time at scale 1.
+	Tracking Mode = 1 << iota
+	// Manual means the clock accepts explicit changes to its time by Set,
+	// Step, Seek, SetOffset, StepToNext, or Fastforward. Without it, they
+	// are ignored.
+	Manual
+
+	// Hybrid means the clock follows real time, but may also be stepped
+	// past stretches of it, as when an integration test wants to skip the
+	// boring ten minutes.
+	Hybrid = Tracking | Manual
+)
+
+// SetMode sets how c advances. Setting Tracking starts c at scale 1, and
+// clearing it stops c, as with Start, SetScale, and Stop. Every Clock begins
+// with Manual set.
+func (c ClockOn[RT]) SetMode(m Mode) {
+	c.st.fixed.Store(m&Manual == 0)
+	if m&Tracking != 0 {
+		c.SetScale(1.0)
+		c.Start()
+	} else {
+		c.Stop()
+	}
+}
+
+// Mode returns how c currently advances. Tracking is reported whenever c is
+// running at scale 1, whether or not it was started by SetMode.
+func (c ClockOn[RT]) Mode() (m Mode) {
+	if c.Active() && c.Scale() == 1.0 {
+		m |= Tracking
+	}
+	if !c.st.fixed.Load() {
+		m |= Manual
+	}
+	return
+}
+
+// SetMode sets how the global Clock instance advances. See
+// [Clock.SetMode].
+func SetMode(m Mode) { clock().SetMode(m) }
@@ -0,0 +1,46 @@
+package mocktime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestMode(t *testing.T) {
+	c := NewClockAt(Unix(0, 0))
+	defer c.Stop()
+	if m := c.Mode(); m != Manual {
+		t.Errorf("Mode() = %v for a new clock, want %v", m, Manual)
+	}
+
+	c.SetMode(Hybrid)
+	if m := c.Mode(); m != Hybrid {
+		t.Errorf("Mode() = %v after SetMode(Hybrid), want %v", m, Hybrid)
+	}
+	start := c.Now()
+	time.Sleep(10 * time.Millisecond)
+	c.Step(10 * Minute)
+	if dt := c.Since(start); dt < 10*Minute+10*Millisecond {
+		t.Errorf("hybrid clock advanced by %v, want at least %v", dt, 10*Minute+10*Millisecond)
+	}
+
+	// Without Manual, explicit changes are ignored
+	c.SetMode(Tracking)
+	now := c.Now()
+	c.Step(Hour)
+	c.Set(now.Add(Hour))
+	if dt := c.Since(now); dt >= Hour {
+		t.Errorf("clock advanced by %v without Manual, want less than %v", dt, Hour)
+	}
+
+	c.SetMode(Manual)
+	if c.Active() {
+		t.Errorf("clock still running without Tracking")
+	}
+	now = c.Now()
+	c.Step(Hour)
+	if dt := c.Since(now); dt != Hour {
+		t.Errorf("clock advanced by %v, want %v", dt, Hour)
+	}
+}
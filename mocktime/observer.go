@@ -0,0 +1,148 @@
+package mocktime
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// SetSleepObserver arranges for f to be called whenever code sleeps or
+// schedules a Timer or Ticker on c, with the duration requested and the
+// file and line from which the request was made, outside this package and
+// the adapters of package clock, such as that returned by FromMocktime, so
+// that a test may assert that a component asked for exactly the delays
+// expected. It covers Sleep, SleepUntilNext, After, AfterFunc, NewTimer,
+// NewTicker, NewBufferedTicker, TickFunc, Tick, and their Std variants, but
+// not Reset on a Timer or Ticker already created. It is called synchronously
+// by the goroutine making the request, before that request takes effect, so
+// it should not block or call back into c. A nil f removes the observer.
+func (c ClockOn[RT]) SetSleepObserver(f func(d Duration, caller string)) {
+	if f == nil {
+		c.st.observer.Store(nil)
+		return
+	}
+	c.st.observer.Store(&f)
+}
+
+// SetSleepObserver sets a function to be called whenever code sleeps or
+// schedules on the global Clock instance. See [Clock.SetSleepObserver].
+func SetSleepObserver(f func(d Duration, caller string)) { clock().SetSleepObserver(f) }
+
+// Prefixes of functions belonging to this package, or to package clock at
+// the root of this module, which adapts it, as named by the runtime.
+var pkgPrefixes = []string{
+	reflect.TypeOf(Clock{}).PkgPath() + ".",
+	path.Dir(reflect.TypeOf(Clock{}).PkgPath()) + ".",
+}
+
+// notify reports a request for a delay of d to the observer, if any.
+func (c ClockOn[RT]) notify(d Duration) {
+	f := c.st.observer.Load()
+	if f == nil {
+		return
+	}
+	(*f)(d, caller())
+}
+
+// caller returns the position of the innermost call from outside this
+// package and package clock.
+func caller() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !internal(frame.Function) {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// internal reports whether the function named fn has one of pkgPrefixes.
+func internal(fn string) bool {
+	for _, p := range pkgPrefixes {
+		if strings.HasPrefix(fn, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// After waits for the duration to elapse and then sends the current time on
+// the returned channel. It is equivalent to c.NewTimer(d).C().
+func (c ClockOn[RT]) After(d Duration) <-chan Time {
+	c.notify(d)
+	if c.st.watchdog.Load() != nil {
+		t := c.Clock.NewTimer(d)
+		c.track(t)
+		return t.C()
+	}
+	return c.Clock.After(d)
+}
+
+// AfterFunc waits for the duration to elapse and then calls f in its own
+// goroutine. It returns a Timer that can be used to cancel the call using
+// its Stop method.
+func (c ClockOn[RT]) AfterFunc(d Duration, f func()) *Timer {
+	c.notify(d)
+	return c.Clock.AfterFunc(d, f)
+}
+
+// NewTimer creates a new Timer that will send the current time on its
+// channel after at least duration d.
+func (c ClockOn[RT]) NewTimer(d Duration) *Timer {
+	c.notify(d)
+	t := c.Clock.NewTimer(d)
+	c.track(t)
+	return t
+}
+
+// NewTicker returns a new Ticker containing a channel that will send the
+// current time on the channel after each tick, with a period of d.
+func (c ClockOn[RT]) NewTicker(d Duration) *Ticker {
+	c.notify(d)
+	return c.Clock.NewTicker(d)
+}
+
+// NewBufferedTicker is like NewTicker, but queues up to limit ticks for a
+// slow receiver. See [relativetime.Clock.NewBufferedTicker].
+func (c ClockOn[RT]) NewBufferedTicker(d Duration, limit int) *Ticker {
+	c.notify(d)
+	return c.Clock.NewBufferedTicker(d, limit)
+}
+
+// TickFunc calls f in its own goroutine after each tick, with a period of d.
+// See [relativetime.Clock.TickFunc].
+func (c ClockOn[RT]) TickFunc(d Duration, f func()) *Ticker {
+	c.notify(d)
+	return c.Clock.TickFunc(d, f)
+}
+
+// Tick is a convenience wrapper for NewTicker providing access to the
+// ticking channel only. Unlike NewTicker, Tick will return nil if d <= 0.
+func (c ClockOn[RT]) Tick(d Duration) <-chan Time {
+	c.notify(d)
+	return c.Clock.Tick(d)
+}
+
+// NewStdTimer is like NewTimer, but returns a StdTimer.
+func (c ClockOn[RT]) NewStdTimer(d Duration) *StdTimer {
+	c.notify(d)
+	return c.Clock.NewStdTimer(d)
+}
+
+// StdAfterFunc is like AfterFunc, but returns a StdTimer.
+func (c ClockOn[RT]) StdAfterFunc(d Duration, f func()) *StdTimer {
+	c.notify(d)
+	return c.Clock.StdAfterFunc(d, f)
+}
+
+// NewStdTicker is like NewTicker, but returns a StdTicker.
+func (c ClockOn[RT]) NewStdTicker(d Duration) *StdTicker {
+	c.notify(d)
+	return c.Clock.NewStdTicker(d)
+}
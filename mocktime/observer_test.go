@@ -0,0 +1,84 @@
+package mocktime_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/noodlebox/clock"
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestSetSleepObserver(t *testing.T) {
+	c := NewClockAt(Unix(0, 0))
+	var (
+		got     []Duration
+		callers []string
+		mu      sync.Mutex
+	)
+	c.SetSleepObserver(func(d Duration, caller string) {
+		mu.Lock()
+		got = append(got, d)
+		callers = append(callers, caller)
+		mu.Unlock()
+	})
+
+	c.NewTimer(Second).Stop()
+	c.AfterFunc(2*Second, func() {}).Stop()
+	c.NewTicker(3 * Second).Stop()
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(4 * Second)
+		close(done)
+	}()
+	for c.NextAt().IsZero() {
+		runtime.Gosched()
+	}
+	c.Step(4 * Second)
+	<-done
+
+	c.SetSleepObserver(nil)
+	c.NewTimer(5 * Second).Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []Duration{Second, 2 * Second, 3 * Second, 4 * Second}
+	if len(got) != len(want) {
+		t.Fatalf("observed %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("observed %v, want %v", got, want)
+			break
+		}
+	}
+	for _, caller := range callers {
+		if !strings.HasPrefix(filepath.Base(caller), "observer_test.go:") {
+			t.Errorf("caller = %q, want a line in observer_test.go", caller)
+		}
+	}
+}
+
+func TestSetSleepObserverAdapted(t *testing.T) {
+	c := NewClockAt(Unix(0, 0))
+	var callers []string
+	c.SetSleepObserver(func(d Duration, caller string) {
+		callers = append(callers, caller)
+	})
+
+	sc := clock.FromMocktime(c)
+	sc.NewTimer(Second).Stop()
+	sc.AfterFunc(Second, func() {}).Stop()
+	sc.NewTicker(Second).Stop()
+
+	if len(callers) != 3 {
+		t.Fatalf("observed %d requests through FromMocktime, want 3", len(callers))
+	}
+	for _, caller := range callers {
+		if !strings.HasPrefix(filepath.Base(caller), "observer_test.go:") {
+			t.Errorf("caller = %q, want a line in observer_test.go", caller)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package mocktime
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// DefaultSeed is the seed of the random source of each new Clock, so that
+// any jitter drawn from it is identical from run to run.
+const DefaultSeed = 1
+
+// lockedSource is a rand.Source64 safe for concurrent use, which may be
+// reseeded in place.
+type lockedSource struct {
+	src rand.Source64
+	mu  sync.Mutex
+}
+
+func newLockedSource(seed int64) *lockedSource {
+	return &lockedSource{src: rand.NewSource(seed).(rand.Source64)}
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
+
+// Rand returns the source of randomness for c, from which helpers such as
+// Jitter draw. It is seeded with DefaultSeed unless changed with SetSeed.
+// Its methods are safe for concurrent use, other than Read.
+func (c ClockOn[RT]) Rand() *rand.Rand {
+	return c.st.rand
+}
+
+// SetSeed reseeds the source returned by Rand, restarting its sequence,
+// including that of Read, which discards any bytes left over from the last
+// call.
+func (c ClockOn[RT]) SetSeed(seed int64) {
+	c.st.rand.Seed(seed)
+}
+
+// Jitter returns d scaled by a random factor drawn uniformly from the range
+// [1-frac, 1+frac), using the source returned by Rand.
+func (c ClockOn[RT]) Jitter(d Duration, frac float64) Duration {
+	return Duration(float64(d) * (1 + frac*(2*c.st.rand.Float64()-1)))
+}
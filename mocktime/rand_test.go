@@ -0,0 +1,41 @@
+package mocktime_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestJitterDeterministic(t *testing.T) {
+	a, b := NewClock(), NewClock()
+	for i := 0; i < 10; i++ {
+		x, y := a.Jitter(Second, 0.5), b.Jitter(Second, 0.5)
+		if x != y {
+			t.Fatalf("jitter %d differs between clocks: %v != %v", i, x, y)
+		}
+		if x < Second/2 || x >= 3*Second/2 {
+			t.Errorf("Jitter(1s, 0.5) = %v, out of range", x)
+		}
+	}
+
+	a.SetSeed(42)
+	b.SetSeed(42)
+	if x, y := a.Rand().Int63(), b.Rand().Int63(); x != y {
+		t.Errorf("values differ after reseeding: %v != %v", x, y)
+	}
+}
+
+func TestSetSeedRead(t *testing.T) {
+	a, b := NewClock(), NewClock()
+	// Leave bytes over from the last value drawn for Read
+	a.Rand().Read(make([]byte, 3))
+
+	a.SetSeed(42)
+	b.SetSeed(42)
+	x, y := make([]byte, 8), make([]byte, 8)
+	a.Rand().Read(x)
+	b.Rand().Read(y)
+	if string(x) != string(y) {
+		t.Errorf("Read differs after reseeding: %x != %x", x, y)
+	}
+}
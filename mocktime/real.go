@@ -0,0 +1,44 @@
+package mocktime
+
+import (
+	"github.com/noodlebox/clock/realtime"
+)
+
+// RealTimer is a Timer exempt from the scale of the Clock that created it,
+// as returned by NewRealTimer. It expires after a duration of real time,
+// whether the Clock is running fast, slow, or not at all, and then sends the
+// Clock's current time on its channel. Its other methods are those of
+// [realtime.Timer].
+type RealTimer struct {
+	*realtime.Timer
+	c chan Time
+}
+
+// C returns the channel on which the time is delivered.
+func (t *RealTimer) C() <-chan Time {
+	return t.c
+}
+
+// NewRealTimer creates a new Timer that will send the current time of c on
+// its channel after at least duration d of real time, ignoring the scale of
+// c, for work such as protocol keepalives that must keep real time while
+// everything else is accelerated. Unlike other Timers on c, it is not
+// affected by Set, Step, Stop, or Close.
+func (c ClockOn[RT]) NewRealTimer(d Duration) *RealTimer {
+	t := &RealTimer{c: make(chan Time, 1)}
+	t.Timer = c.baseClock.AfterFunc(d, func() {
+		select {
+		case t.c <- c.Now():
+		default:
+		}
+	})
+	return t
+}
+
+// RealAfterFunc waits for duration d of real time to elapse, ignoring the
+// scale of c, and then calls f in its own goroutine. It returns a Timer
+// that can be used to cancel the call using its Stop method. As with
+// NewRealTimer, it is not affected by Set, Step, Stop, or Close.
+func (c ClockOn[RT]) RealAfterFunc(d Duration, f func()) *realtime.Timer {
+	return c.baseClock.AfterFunc(d, f)
+}
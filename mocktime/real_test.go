@@ -0,0 +1,38 @@
+package mocktime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestNewRealTimer(t *testing.T) {
+	c := NewClockAt(Unix(0, 0))
+	c.SetScale(1000)
+	c.Start()
+	defer c.Stop()
+
+	start := time.Now()
+	scaled := c.NewTimer(Second)
+	rt := c.NewRealTimer(50 * Millisecond)
+	<-scaled.C()
+	select {
+	case <-rt.C():
+		t.Fatalf("real Timer fired along with a scaled one")
+	default:
+	}
+	now := <-rt.C()
+	if dt := time.Since(start); dt < 50*time.Millisecond {
+		t.Errorf("real Timer fired after %v of real time, want at least 50ms", dt)
+	}
+	if dt := now.Sub(Unix(0, 0)); dt < 50*Second {
+		t.Errorf("real Timer sent %v past the start, want the scaled clock's time", dt)
+	}
+
+	// Stopping the clock has no effect on real Timers
+	c.Stop()
+	done := make(chan struct{})
+	c.RealAfterFunc(Millisecond, func() { close(done) })
+	<-done
+}
@@ -0,0 +1,66 @@
+package mocktime
+
+import (
+	"github.com/noodlebox/clock/realtime"
+	"github.com/noodlebox/clock/relativetime"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+// Reference is the interface a reference clock must satisfy for a Clock to
+// track it, as given to NewClockOn. A Clock created by NewClock or
+// NewClockAt tracks real time, as given by [realtime.Clock]. Its AfterFunc
+// returns the minimal Timer interface needed, so that most implementations
+// need only a thin wrapper to satisfy it; see SteppedReference.
+type Reference = relativetime.RClock[Time, Duration, relativetime.RTimer[Duration]]
+
+// realReference adapts realtime.Clock to Reference.
+type realReference struct {
+	realtime.Clock
+}
+
+func (r realReference) AfterFunc(d Duration, f func()) relativetime.RTimer[Duration] {
+	return r.Clock.AfterFunc(d, f)
+}
+
+// NewClockOn returns a new ClockOn set to the time, at, tracking ref in
+// place of real time, so that even the passage of time while it runs may be
+// made fully deterministic, as with a reference from SteppedReference.
+// Methods that are by nature tied to real time, such as NewRealTimer, still
+// use real time.
+func NewClockOn(ref Reference, at Time) ClockOn[relativetime.RTimer[Duration]] {
+	return ClockOn[relativetime.RTimer[Duration]]{
+		relativetime.NewClock[Time, Duration, relativetime.RTimer[Duration]](ref, at, 1.0),
+		baseClock{realtime.NewClock()}, // zero value would work, but be explicit for clarity
+		newState(ref),
+	}
+}
+
+// Reference returns the reference clock tracked by c.
+func (c ClockOn[RT]) Reference() Reference {
+	return c.st.ref
+}
+
+// steppedReference adapts a steppedtime.Clock to Reference.
+type steppedReference struct {
+	s     *steppedtime.Clock
+	epoch Time
+}
+
+// SteppedReference returns a Reference whose time stands at epoch while s
+// reads zero, and advances only as s is stepped, for driving a Clock from a
+// simulation with NewClockOn.
+func SteppedReference(s *steppedtime.Clock, epoch Time) Reference {
+	return steppedReference{s, epoch}
+}
+
+func (r steppedReference) Now() Time {
+	return r.epoch.Add(r.s.Now().Sub(0))
+}
+
+func (r steppedReference) Seconds(n float64) Duration {
+	return r.s.Seconds(n)
+}
+
+func (r steppedReference) AfterFunc(d Duration, f func()) relativetime.RTimer[Duration] {
+	return r.s.AfterFunc(d, f)
+}
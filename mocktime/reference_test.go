@@ -0,0 +1,52 @@
+package mocktime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/realtime"
+	"github.com/noodlebox/clock/relativetime"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+func TestNewClockOn(t *testing.T) {
+	s := steppedtime.NewClock()
+	ref := SteppedReference(s, Unix(1000, 0))
+	c := NewClockOn(ref, Unix(0, 0))
+	if c.Reference() != ref {
+		t.Errorf("Reference() did not return the reference given")
+	}
+	c.SetScale(2)
+	c.Start()
+	defer c.Stop()
+
+	tm := c.NewTimer(10 * Second)
+	s.Step(4 * Second)
+	if got, want := c.Now(), Unix(8, 0); !got.Equal(want) {
+		t.Errorf("Now() = %v after stepping the reference, want %v", got, want)
+	}
+	select {
+	case <-tm.C():
+		t.Fatalf("Timer fired early")
+	default:
+	}
+	s.Step(Second)
+	select {
+	case now := <-tm.C():
+		if want := Unix(10, 0); !now.Equal(want) {
+			t.Errorf("Timer fired at %v, want %v", now, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timer did not fire once the reference was stepped")
+	}
+}
+
+func TestClockField(t *testing.T) {
+	// The embedded Clock of one tracking real time keeps its concrete type
+	c := NewClock()
+	var rc *relativetime.Clock[Time, Duration, *realtime.Timer] = c.Clock
+	if rc == nil {
+		t.Errorf("NewClock() has no embedded relativetime.Clock")
+	}
+}
@@ -0,0 +1,260 @@
+package mocktime
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/noodlebox/clock/realtime"
+)
+
+// Source is the set of methods wrapped by a Recorder, whose Timer and Ticker
+// types are TM and TK. Both Clock and [realtime.Clock] satisfy it.
+type Source[TM, TK any] interface {
+	Now() Time
+	Sleep(d Duration)
+	After(d Duration) <-chan Time
+	AfterFunc(d Duration, f func()) TM
+	NewTimer(d Duration) TM
+	NewTicker(d Duration) TK
+	Tick(d Duration) <-chan Time
+}
+
+// Query is a single entry in a log recorded by a Recorder.
+type Query struct {
+	Seq  int      // Order in which the query was made, from 1
+	Kind string   // Method called, such as "Now", "Sleep", or "NewTimer"
+	D    Duration // Duration given, if any
+	At   Time     // Time on the clock as the call returned
+}
+
+func (q Query) String() string {
+	if q.Kind == "Now" {
+		return fmt.Sprintf("#%d Now() = %v", q.Seq, q.At)
+	}
+	return fmt.Sprintf("#%d %s(%v) at %v", q.Seq, q.Kind, q.D, q.At)
+}
+
+// Recorder wraps a Source, logging every query of the time made through it,
+// and the time at which it was answered, for later use with Replay. Since
+// and Until are logged as calls to Now. Timers and Tickers are logged as
+// they are created, but not as they are used. Recording a realtime.Clock
+// in production lets a timing-dependent bug be reproduced in a test.
+type Recorder[TM, TK any] struct {
+	src Source[TM, TK]
+	log []Query
+
+	mu sync.Mutex
+}
+
+// Record returns a Recorder wrapping src.
+func Record[TM, TK any](src Source[TM, TK]) *Recorder[TM, TK] {
+	return &Recorder[TM, TK]{src: src}
+}
+
+// RecordRealtime returns a Recorder wrapping the real clock.
+func RecordRealtime() *Recorder[*realtime.Timer, *realtime.Ticker] {
+	return Record[*realtime.Timer, *realtime.Ticker](realtime.Clock{})
+}
+
+// Record returns a Recorder wrapping c.
+func (c ClockOn[RT]) Record() *Recorder[*Timer, *Ticker] {
+	return Record[*Timer, *Ticker](c)
+}
+
+func (r *Recorder[TM, TK]) record(kind string, d Duration, at Time) Time {
+	r.mu.Lock()
+	r.log = append(r.log, Query{Seq: len(r.log) + 1, Kind: kind, D: d, At: at})
+	r.mu.Unlock()
+	return at
+}
+
+// Queries returns the queries logged so far, in the order they were made.
+func (r *Recorder[TM, TK]) Queries() []Query {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Query(nil), r.log...)
+}
+
+// Now returns the current time, logging it.
+func (r *Recorder[TM, TK]) Now() Time {
+	return r.record("Now", 0, r.src.Now())
+}
+
+// Since returns the time elapsed since t, logging the current time.
+func (r *Recorder[TM, TK]) Since(t Time) Duration {
+	return r.Now().Sub(t)
+}
+
+// Until returns the duration until t, logging the current time.
+func (r *Recorder[TM, TK]) Until(t Time) Duration {
+	return t.Sub(r.Now())
+}
+
+// Sleep pauses the current goroutine for at least the duration d, logging
+// the time at which it wakes.
+func (r *Recorder[TM, TK]) Sleep(d Duration) {
+	r.src.Sleep(d)
+	r.record("Sleep", d, r.src.Now())
+}
+
+// After is like Clock.After, logging the time the timer is created.
+func (r *Recorder[TM, TK]) After(d Duration) <-chan Time {
+	ch := r.src.After(d)
+	r.record("After", d, r.src.Now())
+	return ch
+}
+
+// AfterFunc is like Clock.AfterFunc, logging the time the timer is created.
+func (r *Recorder[TM, TK]) AfterFunc(d Duration, f func()) TM {
+	t := r.src.AfterFunc(d, f)
+	r.record("AfterFunc", d, r.src.Now())
+	return t
+}
+
+// NewTimer is like Clock.NewTimer, logging the time the timer is created.
+func (r *Recorder[TM, TK]) NewTimer(d Duration) TM {
+	t := r.src.NewTimer(d)
+	r.record("NewTimer", d, r.src.Now())
+	return t
+}
+
+// NewTicker is like Clock.NewTicker, logging the time the ticker is
+// created.
+func (r *Recorder[TM, TK]) NewTicker(d Duration) TK {
+	t := r.src.NewTicker(d)
+	r.record("NewTicker", d, r.src.Now())
+	return t
+}
+
+// Tick is like Clock.Tick, logging the time the ticker is created.
+func (r *Recorder[TM, TK]) Tick(d Duration) <-chan Time {
+	ch := r.src.Tick(d)
+	r.record("Tick", d, r.src.Now())
+	return ch
+}
+
+// Replayer serves the queries logged by a Recorder, answering each with the
+// time that was recorded for it. It is backed by a stopped Clock, which is
+// advanced as each query is served, firing Timers and Tickers created
+// through the Replayer at the same points in the sequence as when recorded.
+// Queries must be made in the same order as they were recorded, so code
+// making them from several goroutines at once may not replay faithfully.
+// Once a query differs from the log, or the log runs out, Err reports it,
+// and queries are answered from the backing Clock as it stands.
+type Replayer struct {
+	c   Clock
+	log []Query
+	err error
+
+	mu sync.Mutex // Protects log and err, and serializes queries
+}
+
+// Replay returns a Replayer serving the queries in log, starting at the
+// time of the first.
+func Replay(log []Query) *Replayer {
+	var start Time
+	if len(log) > 0 {
+		start = log[0].At
+	}
+	return &Replayer{c: NewClockAt(start), log: log}
+}
+
+// Clock returns the Clock backing r.
+func (r *Replayer) Clock() Clock {
+	return r.c
+}
+
+// Err returns an error describing the first query that differed from the
+// log, or was made after it ran out, or nil if there were none.
+func (r *Replayer) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Remaining returns the number of queries in the log not yet served.
+func (r *Replayer) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.log)
+}
+
+// Serve the next query in the log, which should match kind and d, advancing
+// the backing Clock to its time, which is returned.
+func (r *Replayer) serve(kind string, d Duration) Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.c.Now()
+	}
+	if len(r.log) == 0 {
+		r.err = fmt.Errorf("mocktime: replayed %s(%v) after the end of the log", kind, d)
+		return r.c.Now()
+	}
+	q := r.log[0]
+	if q.Kind != kind || q.D != d {
+		r.err = fmt.Errorf("mocktime: replayed %s(%v), but log has %v", kind, d, q)
+		return r.c.Now()
+	}
+	r.log = r.log[1:]
+	r.c.Seek(q.At)
+	return q.At
+}
+
+// Now returns the time recorded for the next query.
+func (r *Replayer) Now() Time {
+	return r.serve("Now", 0)
+}
+
+// Since returns the time elapsed since t, as of the time recorded for the
+// next query.
+func (r *Replayer) Since(t Time) Duration {
+	return r.Now().Sub(t)
+}
+
+// Until returns the duration until t, as of the time recorded for the next
+// query.
+func (r *Replayer) Until(t Time) Duration {
+	return t.Sub(r.Now())
+}
+
+// Sleep returns at once, having advanced to the time recorded for the next
+// query, at which the recorded sleep ended.
+func (r *Replayer) Sleep(d Duration) {
+	r.serve("Sleep", d)
+}
+
+// After is like Clock.After, on the backing Clock, as of the time recorded
+// for the next query.
+func (r *Replayer) After(d Duration) <-chan Time {
+	r.serve("After", d)
+	return r.c.After(d)
+}
+
+// AfterFunc is like Clock.AfterFunc, on the backing Clock, as of the time
+// recorded for the next query.
+func (r *Replayer) AfterFunc(d Duration, f func()) *Timer {
+	r.serve("AfterFunc", d)
+	return r.c.AfterFunc(d, f)
+}
+
+// NewTimer is like Clock.NewTimer, on the backing Clock, as of the time
+// recorded for the next query.
+func (r *Replayer) NewTimer(d Duration) *Timer {
+	r.serve("NewTimer", d)
+	return r.c.NewTimer(d)
+}
+
+// NewTicker is like Clock.NewTicker, on the backing Clock, as of the time
+// recorded for the next query.
+func (r *Replayer) NewTicker(d Duration) *Ticker {
+	r.serve("NewTicker", d)
+	return r.c.NewTicker(d)
+}
+
+// Tick is like Clock.Tick, on the backing Clock, as of the time recorded for
+// the next query.
+func (r *Replayer) Tick(d Duration) <-chan Time {
+	r.serve("Tick", d)
+	return r.c.Tick(d)
+}
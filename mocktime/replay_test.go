@@ -0,0 +1,52 @@
+package mocktime_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestRecordReplay(t *testing.T) {
+	c := NewClockAt(Unix(0, 0))
+	c.Stop()
+	rec := c.Record()
+	rec.Now()
+	c.Step(Second)
+	tm := rec.NewTimer(Second)
+	c.Step(Second)
+	<-tm.C()
+	rec.Since(Unix(0, 0))
+
+	log := rec.Queries()
+	if len(log) != 3 {
+		t.Fatalf("recorded %d queries, want 3: %v", len(log), log)
+	}
+
+	rp := Replay(log)
+	if got := rp.Now(); !got.Equal(Unix(0, 0)) {
+		t.Errorf("first Now() = %v, want %v", got, Unix(0, 0))
+	}
+	tm = rp.NewTimer(Second)
+	select {
+	case <-tm.C():
+		t.Errorf("replayed timer fired early")
+	default:
+	}
+	if got := rp.Since(Unix(0, 0)); got != 2*Second {
+		t.Errorf("Since() = %v, want %v", got, 2*Second)
+	}
+	select {
+	case <-tm.C():
+	default:
+		t.Errorf("replayed timer did not fire")
+	}
+	if err := rp.Err(); err != nil || rp.Remaining() != 0 {
+		t.Errorf("Err() = %v with %d queries remaining after a faithful replay", err, rp.Remaining())
+	}
+
+	rp = Replay(log)
+	rp.Sleep(Second)
+	if rp.Err() == nil {
+		t.Errorf("Err() = nil after a query differing from the log")
+	}
+}
@@ -0,0 +1,67 @@
+package mocktime
+
+import (
+	"math"
+	"time"
+)
+
+// How much reference time passes between the slices of an advance paced by
+// SetSpeedCap.
+const paceSlice = time.Millisecond
+
+// SetSpeedCap limits how fast c may be advanced by Set, Step, Seek,
+// SetOffset, StepToNext, or Fastforward to n times real time, or the time of
+// the Reference given to NewClockOn. Rather than jumping at once, each
+// advance is made in slices, about a millisecond of reference time apart,
+// so that code sensitive to the rate at which time passes, such as
+// backpressure or batching, sees it flow as it would at n times real speed,
+// while a test still runs far faster than in real time. An advance returns
+// only once complete. Time passing while the clock is running is
+// unaffected. If n <= 0, there is no cap, which is the default.
+func (c ClockOn[RT]) SetSpeedCap(n float64) {
+	if n < 0 {
+		n = 0
+	}
+	c.st.speedCap.Store(math.Float64bits(n))
+}
+
+// SpeedCap returns the cap set by SetSpeedCap, or 0 if there is none.
+func (c ClockOn[RT]) SpeedCap() float64 {
+	return math.Float64frombits(c.st.speedCap.Load())
+}
+
+// pace advances c by dt through move, in slices spaced out in real time as
+// set by SetSpeedCap, or all at once if there is no cap.
+func (c ClockOn[RT]) pace(dt Duration, move func(Duration)) {
+	speed := c.SpeedCap()
+	if speed <= 0 || dt <= 0 {
+		move(dt)
+		return
+	}
+	slice := Duration(float64(paceSlice) * speed)
+	if slice <= 0 {
+		slice = 1
+	}
+	// Keep to a schedule from the start, so that time spent moving does
+	// not add up to a slower pace
+	ref := c.st.ref
+	start := ref.Now()
+	var done Duration
+	for done < dt {
+		d := slice
+		if d > dt-done {
+			d = dt - done
+		}
+		done += d
+		if wait := start.Add(Duration(float64(done) / speed)).Sub(ref.Now()); wait > 0 {
+			woke := make(chan struct{})
+			ref.AfterFunc(wait, func() { close(woke) })
+			<-woke
+		}
+		move(d)
+	}
+}
+
+// SetSpeedCap limits how fast the global Clock instance may be advanced.
+// See [Clock.SetSpeedCap].
+func SetSpeedCap(n float64) { clock().SetSpeedCap(n) }
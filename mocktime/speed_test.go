@@ -0,0 +1,50 @@
+package mocktime_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+func TestSetSpeedCap(t *testing.T) {
+	s := steppedtime.NewClock()
+	c := NewClockOn(SteppedReference(s, Unix(0, 0)), Unix(0, 0))
+	c.Stop()
+	c.SetSpeedCap(1000)
+	waiting := func() {
+		for s.QueueStats().Depth == 0 {
+			runtime.Gosched()
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		c.Step(50 * Second)
+		close(done)
+	}()
+	// Time flows a slice at a time, as the reference advances, rather than
+	// jumping
+	for i := 1; i <= 50; i++ {
+		waiting()
+		if got, want := c.Now(), Unix(int64(i-1), 0); !got.Equal(want) {
+			t.Fatalf("Now() = %v before slice %d, want %v", got, i, want)
+		}
+		s.Step(steppedtime.Millisecond)
+	}
+	<-done
+	if got, want := c.Now(), Unix(50, 0); !got.Equal(want) {
+		t.Errorf("Now() = %v, want %v", got, want)
+	}
+	if n := s.QueueStats().Depth; n != 0 {
+		t.Errorf("%d waits left on the reference", n)
+	}
+
+	// Without a cap, it jumps at once
+	c.SetSpeedCap(0)
+	c.Step(Hour)
+	if got, want := c.Now(), Unix(50, 0).Add(Hour); !got.Equal(want) {
+		t.Errorf("Now() = %v, want %v", got, want)
+	}
+}
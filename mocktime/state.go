@@ -0,0 +1,89 @@
+package mocktime
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/noodlebox/clock/relativetime"
+)
+
+// PendingTimer is an alias for [relativetime.Pending] using the types [Time]
+// and [Duration].
+type PendingTimer = relativetime.Pending[Time, Duration]
+
+// ClockState is a snapshot of a Clock, as returned by Clock.State, for
+// comparing with Diff. Unlike State, used by NewHandler, it lists every
+// pending timer.
+type ClockState struct {
+	Now     Time
+	Scale   float64
+	Active  bool
+	Pending []PendingTimer // In the order they will fire
+}
+
+// State returns a snapshot of c: its time, scale, whether it is running,
+// and the Timers and Tickers waiting to fire.
+func (c ClockOn[RT]) State() ClockState {
+	return ClockState{
+		Now:     c.Now(),
+		Scale:   c.Scale(),
+		Active:  c.Active(),
+		Pending: c.PendingTimers(),
+	}
+}
+
+// Diff describes the differences between two snapshots, one per line, or
+// returns an empty string if there are none. Pending timers are compared in
+// the order they will fire, so that two test scenarios expected to behave
+// alike can be checked for where their timers diverge.
+func Diff(a, b ClockState) string {
+	var sb strings.Builder
+	if !a.Now.Equal(b.Now) {
+		fmt.Fprintf(&sb, "Now: %v != %v\n", a.Now, b.Now)
+	}
+	if a.Scale != b.Scale {
+		fmt.Fprintf(&sb, "Scale: %v != %v\n", a.Scale, b.Scale)
+	}
+	if a.Active != b.Active {
+		fmt.Fprintf(&sb, "Active: %v != %v\n", a.Active, b.Active)
+	}
+	for i := 0; i < len(a.Pending) || i < len(b.Pending); i++ {
+		switch {
+		case i >= len(a.Pending):
+			fmt.Fprintf(&sb, "Pending[%d]: missing != %s\n", i, describe(b.Pending[i]))
+		case i >= len(b.Pending):
+			fmt.Fprintf(&sb, "Pending[%d]: %s != missing\n", i, describe(a.Pending[i]))
+		case !samePending(a.Pending[i], b.Pending[i]):
+			fmt.Fprintf(&sb, "Pending[%d]: %s != %s\n", i, describe(a.Pending[i]), describe(b.Pending[i]))
+		}
+	}
+	return sb.String()
+}
+
+func samePending(a, b PendingTimer) bool {
+	return a.When.Equal(b.When) && a.Period == b.Period && sameTag(a.Tag, b.Tag)
+}
+
+// sameTag reports whether tags a and b are equal, comparing them with ==
+// where possible, or else, for tags such as slices on which == panics, with
+// reflect.DeepEqual.
+func sameTag(a, b any) (same bool) {
+	defer func() {
+		if recover() != nil {
+			same = reflect.DeepEqual(a, b)
+		}
+	}()
+	return a == b
+}
+
+func describe(p PendingTimer) string {
+	s := "timer at " + p.When.String()
+	if p.Period != 0 {
+		s = fmt.Sprintf("ticker every %v at %v", p.Period, p.When)
+	}
+	if p.Tag != nil {
+		s += fmt.Sprintf(" tagged %v", p.Tag)
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+package mocktime_test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestDiff(t *testing.T) {
+	a, b := NewClockAt(Unix(0, 0)), NewClockAt(Unix(0, 0))
+	for _, c := range []Clock{a, b} {
+		c.NewTicker(Second).SetTag("tick")
+		c.NewTimer(Minute)
+	}
+	if d := Diff(a.State(), b.State()); d != "" {
+		t.Errorf("Diff() of identical clocks = %q, want none", d)
+	}
+
+	b.NewTimer(Hour)
+	b.SetScale(2)
+	d := Diff(a.State(), b.State())
+	for _, want := range []string{"Scale: 1 != 2", "Pending[2]: missing != timer at"} {
+		if !strings.Contains(d, want) {
+			t.Errorf("Diff() = %q, missing %q", d, want)
+		}
+	}
+	if n := strings.Count(d, "\n"); n != 2 {
+		t.Errorf("Diff() reported %d differences, want 2:\n%s", n, d)
+	}
+	if p := a.State().Pending[0]; p.Period != Second || p.Tag != "tick" {
+		t.Errorf("first pending = %+v, want the tagged ticker", p)
+	}
+}
+
+func TestStatePendingOrder(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	a, b := NewClockAt(Unix(0, 0)), NewClockAt(Unix(0, 0))
+	for _, c := range []Clock{a, b} {
+		c.Stop()
+		for i := 0; i < 16; i++ {
+			// All due at the same time
+			c.NewTimer(Second).SetTag(i)
+		}
+	}
+	if d := Diff(a.State(), b.State()); d != "" {
+		t.Errorf("Diff() of identical clocks = %q, want none", d)
+	}
+	for i, p := range a.State().Pending {
+		if p.Tag != i {
+			t.Errorf("Pending[%d] tagged %v, want the timer created %dth", i, p.Tag, i)
+		}
+	}
+
+	// Snapshots built by hand may hold tags that == panics on
+	sa, sb := a.State(), a.State()
+	sa.Pending[0].Tag, sb.Pending[0].Tag = []int{1}, []int{1}
+	if d := Diff(sa, sb); d != "" {
+		t.Errorf("Diff() with equal slice tags = %q, want none", d)
+	}
+	sb.Pending[0].Tag = []int{2}
+	if d := Diff(sa, sb); !strings.Contains(d, "Pending[0]") {
+		t.Errorf("Diff() with different slice tags = %q, want Pending[0] reported", d)
+	}
+}
@@ -0,0 +1,33 @@
+package mocktime_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestStdTimer(t *testing.T) {
+	c := NewClock()
+	tm := c.NewStdTimer(Hour)
+	if tm.C != tm.Timer.C() {
+		t.Fatalf("StdTimer.C does not match Timer.C()")
+	}
+	c.Step(Hour)
+	select {
+	case <-tm.C:
+	default:
+		t.Errorf("StdTimer did not fire")
+	}
+}
+
+func TestStdTicker(t *testing.T) {
+	c := NewClock()
+	tk := c.NewStdTicker(Hour)
+	defer tk.Stop()
+	c.Step(Hour)
+	select {
+	case <-tk.C:
+	case <-After(Second):
+		t.Errorf("StdTicker did not tick")
+	}
+}
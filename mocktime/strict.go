@@ -0,0 +1,36 @@
+package mocktime
+
+// TB is the subset of [testing.TB] used by helpers that report failures on
+// a test.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+	Cleanup(func())
+}
+
+// Strict reports an error on t if, for the remainder of the test, any of
+// c's timers (including those used by Sleep) are triggered because real
+// time has passed, rather than by an explicit Set, Step, Seek, or
+// Fastforward. This catches tests that accidentally rely on the passage of
+// real time, such as by mixing calls to [time.Sleep] with the mock clock,
+// or by waiting for a running clock to catch up. The clock is left running
+// or stopped as it was, so that a test relying on real time fails rather
+// than hangs.
+func (c ClockOn[RT]) Strict(t TB) {
+	t.Helper()
+	c.SetWakeHook(func() {
+		t.Errorf("mocktime: timer triggered by the passage of real time on a strict clock")
+	})
+	t.Cleanup(func() {
+		c.SetWakeHook(nil)
+	})
+}
+
+// Strict reports an error on t if, for the remainder of the test, any
+// timers on the global Clock instance are triggered by the passage of real
+// time. See [Clock.Strict].
+func Strict(t TB) {
+	t.Helper()
+	clock().Strict(t)
+}
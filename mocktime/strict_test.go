@@ -0,0 +1,79 @@
+package mocktime_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+// fakeTB records failures reported by helpers under test.
+type fakeTB struct {
+	testing.TB
+
+	mu       sync.Mutex
+	errs     []string
+	cleanups []func()
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.mu.Lock()
+	tb.errs = append(tb.errs, fmt.Sprintf(format, args...))
+	tb.mu.Unlock()
+}
+
+func (tb *fakeTB) Fatalf(format string, args ...any) {
+	tb.Errorf(format, args...)
+}
+
+func (tb *fakeTB) Cleanup(f func()) {
+	tb.cleanups = append(tb.cleanups, f)
+}
+
+func (tb *fakeTB) failed() int {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return len(tb.errs)
+}
+
+func (tb *fakeTB) finish() {
+	for i := len(tb.cleanups) - 1; i >= 0; i-- {
+		tb.cleanups[i]()
+	}
+}
+
+func TestStrict(t *testing.T) {
+	c := NewClock()
+	c.Start()
+	tb := new(fakeTB)
+	c.Strict(tb)
+	if !c.Active() {
+		t.Errorf("strict clock was stopped")
+	}
+
+	// Advancing explicitly is fine, even while running
+	tm := c.NewTimer(Hour)
+	c.Step(Hour)
+	<-tm.C()
+	tm = c.NewTimer(Hour)
+	c.Fastforward()
+	<-tm.C()
+	if n := tb.failed(); n != 0 {
+		t.Errorf("strict clock reported %d errors after Step and Fastforward", n)
+	}
+
+	// Relying on real time is not
+	c.Sleep(Millisecond)
+	if n := tb.failed(); n != 1 {
+		t.Errorf("strict clock reported %d errors after Sleep, want 1", n)
+	}
+
+	tb.finish()
+	c.Sleep(Millisecond)
+	if n := tb.failed(); n != 1 {
+		t.Errorf("strict clock reported %d errors after the test, want 1", n)
+	}
+}
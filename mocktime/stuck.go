@@ -0,0 +1,183 @@
+package mocktime
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/noodlebox/clock/relativetime"
+)
+
+// Stuck describes goroutines found waiting on a stopped Clock that has made
+// no progress for a while, as reported to the hook set by SetStuckHook.
+type Stuck struct {
+	Sleepers int      // Number of goroutines blocked in Sleep
+	Timers   int      // Number of Timers from After or NewTimer yet to fire
+	Now      Time     // Time at which the clock is stopped
+	Next     Time     // Time of the next scheduled timer
+	Waited   Duration // Time passed on the reference clock without progress
+}
+
+func (s Stuck) String() string {
+	return fmt.Sprintf("%d goroutine(s) blocked in Sleep and %d timer(s) from After or NewTimer "+
+		"pending on a clock stopped at %v (next timer due at %v), with no Set or Step for %v",
+		s.Sleepers, s.Timers, s.Now, s.Next, s.Waited)
+}
+
+// watchdog checks a Clock for waiters stuck on it, once it has made no
+// progress for a while, by a timer on its reference clock.
+type watchdog struct {
+	after Duration
+	f     func(Stuck)
+	timer relativetime.RTimer[Duration]
+
+	since   Time   // Reference time at which the clock last made progress
+	now     Time   // Time on the clock then
+	begun   uint64 // Waits begun by then
+	stopped bool
+
+	mu sync.Mutex // Held while calling f
+}
+
+// Sleep pauses the current goroutine for at least the duration d. A negative
+// or zero duration causes Sleep to return immediately.
+func (c ClockOn[RT]) Sleep(d Duration) {
+	c.notify(d)
+	if d <= 0 {
+		return
+	}
+	c.st.sleepers.Add(1)
+	c.st.begun.Add(1)
+	defer c.st.sleepers.Add(-1)
+	c.progressed()
+	c.Clock.Sleep(d)
+}
+
+// Track t, created by After or NewTimer, as a likely wait, while a stuck
+// hook is set.
+func (c ClockOn[RT]) track(t *Timer) {
+	if c.st.watchdog.Load() == nil {
+		return
+	}
+	c.st.begun.Add(1)
+	c.st.mu.Lock()
+	if len(c.st.waits) >= c.st.prune {
+		c.st.waits = pending(c.st.waits)
+		c.st.prune = 2*len(c.st.waits) + 16
+	}
+	c.st.waits = append(c.st.waits, t)
+	c.st.mu.Unlock()
+	c.progressed()
+}
+
+// Return those of ts still waiting to fire, reusing its storage.
+func pending(ts []*Timer) []*Timer {
+	n := 0
+	for _, t := range ts {
+		if t.Pending() {
+			ts[n] = t
+			n++
+		}
+	}
+	for i := n; i < len(ts); i++ {
+		ts[i] = nil
+	}
+	return ts[:n]
+}
+
+// SetStuckHook arranges for f to be called when goroutines have been blocked
+// in Sleep on c, or Timers created by After or NewTimer, which a goroutine
+// is likely waiting on, have been pending on c, while it is stopped, and
+// neither the time nor the set of waiters has changed for at least the
+// duration after on the reference clock: real time, unless c was created by
+// NewClockOn. No goroutine waiting on a stopped clock can wake itself, so
+// unless some other goroutine is about to call Set or Step, this is a
+// deadlock: the most common cause of hangs when adopting mocktime. Some time
+// must be allowed for other goroutines to make progress, so after trades
+// detection latency against false reports. Once reported, f is not called
+// again until the clock has made progress and become stuck anew. A nil f
+// removes the hook.
+func (c ClockOn[RT]) SetStuckHook(after Duration, f func(Stuck)) {
+	var w *watchdog
+	if f != nil {
+		w = &watchdog{after: after, f: f}
+		w.mu.Lock()
+		w.since, w.now, w.begun = c.st.ref.Now(), c.Now(), c.st.begun.Load()
+		w.timer = c.st.ref.AfterFunc(after, func() { c.check(w) })
+		w.mu.Unlock()
+	}
+	c.st.mu.Lock()
+	prev := c.st.watchdog.Swap(w)
+	if w == nil {
+		c.st.waits = nil
+	}
+	c.st.mu.Unlock()
+
+	if prev != nil {
+		prev.mu.Lock()
+		prev.stopped = true
+		prev.timer.Stop()
+		prev.mu.Unlock()
+	}
+}
+
+// Note that c has made progress, restarting the wait before any report.
+func (c ClockOn[RT]) progressed() {
+	w := c.st.watchdog.Load()
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped {
+		return
+	}
+	w.since, w.now, w.begun = c.st.ref.Now(), c.Now(), c.st.begun.Load()
+	w.timer.Reset(w.after)
+}
+
+// Check for waiters stuck on c, once its watchdog's timer has fired.
+func (c ClockOn[RT]) check(w *watchdog) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	rNow := c.st.ref.Now()
+	if w.stopped || rNow.Sub(w.since) < w.after {
+		// Stopped, or progress was made since the timer was due
+		return
+	}
+
+	c.st.mu.Lock()
+	c.st.waits = pending(c.st.waits)
+	timers := len(c.st.waits)
+	c.st.mu.Unlock()
+	sleepers := int(c.st.sleepers.Load())
+	if sleepers == 0 && timers == 0 {
+		// Nothing to wait on, until the next waiter progresses
+		return
+	}
+	if now, begun := c.Now(), c.st.begun.Load(); c.Active() || !now.Equal(w.now) || begun != w.begun {
+		w.since, w.now, w.begun = rNow, now, begun
+		w.timer.Reset(w.after)
+		return
+	}
+	w.f(Stuck{Sleepers: sleepers, Timers: timers, Now: w.now, Next: c.NextAt(), Waited: rNow.Sub(w.since)})
+}
+
+// DetectStuck reports an error on t if, for the remainder of the test,
+// goroutines are left waiting on c while it is stopped and makes no
+// progress for at least the duration after on the reference clock. See
+// [Clock.SetStuckHook].
+func (c ClockOn[RT]) DetectStuck(t TB, after Duration) {
+	t.Helper()
+	c.SetStuckHook(after, func(s Stuck) {
+		t.Errorf("mocktime: possible deadlock: %v", s)
+	})
+	t.Cleanup(func() { c.SetStuckHook(0, nil) })
+}
+
+// DetectStuck reports an error on t if, for the remainder of the test,
+// goroutines are left waiting on the global Clock instance while it is
+// stopped and makes no progress. See [Clock.DetectStuck].
+func DetectStuck(t TB, after Duration) {
+	t.Helper()
+	clock().DetectStuck(t, after)
+}
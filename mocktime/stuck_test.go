@@ -0,0 +1,73 @@
+package mocktime_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/noodlebox/clock/mocktime"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+func TestDetectStuck(t *testing.T) {
+	s := steppedtime.NewClock()
+	c := NewClockOn(SteppedReference(s, Unix(0, 0)), Unix(0, 0))
+	c.Stop()
+	stuck := make(chan Stuck, 1)
+	c.SetStuckHook(50*Millisecond, func(st Stuck) { stuck <- st })
+	defer c.SetStuckHook(0, nil)
+
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(Second)
+		close(done)
+	}()
+	for c.NextAt().IsZero() {
+		runtime.Gosched()
+	}
+
+	// Stepping regularly is progress, even short of waking the sleeper
+	for i := 0; i < 10; i++ {
+		s.Step(20 * steppedtime.Millisecond)
+		c.Step(Millisecond)
+	}
+	select {
+	case st := <-stuck:
+		t.Fatalf("reported %v while stepping", st)
+	default:
+	}
+
+	// Leaving it stopped is not
+	s.Step(200 * steppedtime.Millisecond)
+	want := Stuck{Sleepers: 1, Now: Unix(0, 0).Add(10 * Millisecond), Next: Unix(1, 0), Waited: 200 * Millisecond}
+	if got := <-stuck; got != want {
+		t.Errorf("reported %+v, want %+v", got, want)
+	}
+
+	c.Step(Second)
+	<-done
+}
+
+func TestDetectStuckTimers(t *testing.T) {
+	s := steppedtime.NewClock()
+	c := NewClockOn(SteppedReference(s, Unix(0, 0)), Unix(0, 0))
+	c.Stop()
+	stuck := make(chan Stuck, 1)
+	c.SetStuckHook(50*Millisecond, func(st Stuck) { stuck <- st })
+	defer c.SetStuckHook(0, nil)
+
+	// A timer that fired, or was stopped, is not waited on
+	c.NewTimer(Minute).Stop()
+	fired := c.NewTimer(Millisecond)
+	c.Step(Millisecond)
+	<-fired.C()
+	ch := c.After(Second)
+
+	s.Step(100 * steppedtime.Millisecond)
+	want := Stuck{Timers: 1, Now: Unix(0, 0).Add(Millisecond), Next: Unix(0, 0).Add(Second + Millisecond), Waited: 100 * Millisecond}
+	if got := <-stuck; got != want {
+		t.Errorf("reported %+v, want %+v", got, want)
+	}
+
+	c.Step(Second)
+	<-ch
+}
@@ -167,3 +167,128 @@ func BenchmarkTickerResetNaive(b *testing.B) {
 		ticker.Stop()
 	})
 }
+
+func TestTickerMissed(t *testing.T) {
+	c := NewClockAt(Unix(0, 0))
+	c.Stop()
+	tk := c.NewTicker(Second)
+	defer tk.Stop()
+
+	c.Step(Second)     // Held until received
+	c.Step(3 * Second) // Missed while waiting
+	<-tk.C()
+
+	// The ticker is queued again, counting what it missed, only once the
+	// tick is received
+	for c.NextAt().IsZero() {
+		runtime.Gosched()
+	}
+	if n := tk.Missed(); n != 3 {
+		t.Errorf("Missed() = %d, want 3", n)
+	}
+	if n := tk.Missed(); n != 0 {
+		t.Errorf("Missed() = %d again, want 0", n)
+	}
+}
+
+func TestBufferedTicker(t *testing.T) {
+	start := Unix(0, 0)
+	c := NewClockAt(start)
+	c.Stop()
+	tk := c.NewBufferedTicker(Second, 0)
+	defer tk.Stop()
+
+	c.Step(3 * Second)
+	for i := 1; i <= 3; i++ {
+		if got, want := <-tk.C(), start.Add(Duration(i)*Second); !got.Equal(want) {
+			t.Errorf("tick %d at %v, want %v", i, got, want)
+		}
+	}
+	if n := tk.Missed(); n != 0 {
+		t.Errorf("Missed() = %d, want 0", n)
+	}
+
+	// A long step sends only the last ticks that fit
+	lim := c.NewBufferedTicker(Second, 2)
+	defer lim.Stop()
+	now := c.Now()
+	c.Step(Hour)
+	for i := 3599; i <= 3600; i++ {
+		if got, want := <-lim.C(), now.Add(Duration(i)*Second); !got.Equal(want) {
+			t.Errorf("tick at %v, want %v", got, want)
+		}
+	}
+	if n := lim.Missed(); n != 3598 {
+		t.Errorf("Missed() = %d, want 3598", n)
+	}
+}
+
+func TestTickerPause(t *testing.T) {
+	start := Unix(0, 0)
+	c := NewClockAt(start)
+	c.Stop()
+	tk := c.NewTicker(3 * Second)
+	defer tk.Stop()
+
+	c.Step(Second)
+	tk.Pause()
+	if when := c.NextAt(); !when.IsZero() {
+		t.Errorf("NextAt() = %v while paused, want zero", when)
+	}
+	c.Step(10 * Second)
+
+	tk.Resume()
+	if when, want := c.NextAt(), start.Add(13*Second); !when.Equal(want) {
+		t.Errorf("NextAt() = %v after Resume, want %v", when, want)
+	}
+	c.Step(2 * Second)
+	if got, want := <-tk.C(), start.Add(13*Second); !got.Equal(want) {
+		t.Errorf("tick at %v, want %v", got, want)
+	}
+}
+
+func TestTickerSetPeriod(t *testing.T) {
+	start := Unix(0, 0)
+	c := NewClockAt(start)
+	c.Stop()
+	tk := c.NewBufferedTicker(3*Second, 0)
+	defer tk.Stop()
+
+	c.Step(Second)
+	tk.SetPeriod(Second)
+	if when, want := c.NextAt(), start.Add(3*Second); !when.Equal(want) {
+		t.Errorf("NextAt() = %v after SetPeriod, want %v", when, want)
+	}
+	c.Step(3 * Second)
+	for i := 3; i <= 4; i++ {
+		if got, want := <-tk.C(), start.Add(Duration(i)*Second); !got.Equal(want) {
+			t.Errorf("tick at %v, want %v", got, want)
+		}
+	}
+}
+
+func TestTickFunc(t *testing.T) {
+	c := NewClockAt(Unix(0, 0))
+	c.Stop()
+	c.SetCallbackLimit(1) // Run callbacks one at a time, in order
+	calls := make(chan Time, 3)
+	tk := c.TickFunc(Second, func() { calls <- c.Now() })
+	if tk.C() != nil {
+		t.Errorf("TickFunc returned a Ticker with a channel")
+	}
+
+	for i := 1; i <= 3; i++ {
+		c.Step(Second)
+		if got, want := <-calls, Unix(int64(i), 0); !got.Equal(want) {
+			t.Fatalf("f called at %v, want %v", got, want)
+		}
+	}
+	tk.Stop()
+	c.Step(Second)
+	// Any call of f after Stop would come before this one
+	c.AfterFunc(Second, func() { calls <- Time{} })
+	c.Step(Second)
+	if got := <-calls; !got.IsZero() {
+		t.Errorf("f called at %v after Stop", got)
+	}
+}
@@ -0,0 +1,110 @@
+package mocktime
+
+// The state of a Clock saved by Freeze or Travel, for TravelBack.
+type travelMark struct {
+	now    Time    // Time on the clock when saved
+	ref    Time    // Time on the reference clock when saved
+	active bool    // Whether the clock was running
+	scale  float64 // Scale it was running at
+}
+
+// Save the state of c before the first of a series of travels.
+func (c ClockOn[RT]) saveTravel() {
+	m := travelMark{
+		now:    c.Now(),
+		ref:    c.st.ref.Now(),
+		active: c.Active(),
+		scale:  c.Scale(),
+	}
+	c.st.mu.Lock()
+	if c.st.travel == nil {
+		c.st.travel = &m
+	}
+	c.st.mu.Unlock()
+}
+
+// Move c to t, triggering any timers due on the way forward, or shifting
+// every timer along with it on the way back, so that none is left in the
+// past.
+func (c ClockOn[RT]) jump(t Time) {
+	if dt := t.Sub(c.Now()); dt < 0 {
+		c.SetOffset(dt, false)
+	} else {
+		c.Set(t)
+	}
+}
+
+// Freeze stops c, so that its time stands still until TravelBack, or until
+// it is otherwise started, as with Stop. The state of c before the first
+// call to Freeze or Travel since the last TravelBack is saved, to be
+// restored by TravelBack.
+func (c ClockOn[RT]) Freeze() {
+	c.saveTravel()
+	c.Stop()
+}
+
+// Travel moves c to t, leaving it running or stopped as it was. Moving
+// forward triggers any timers due on the way, as with Set, while moving
+// back shifts every timer along with c, as with SetOffset, so that the time
+// remaining until each triggers is unchanged. The state of c before the
+// first call to Freeze or Travel since the last TravelBack is saved, to be
+// restored by TravelBack.
+func (c ClockOn[RT]) Travel(t Time) {
+	c.saveTravel()
+	c.jump(t)
+}
+
+// TravelBack undoes every call to Freeze and Travel since the last
+// TravelBack, restoring whether c is running and at what scale, and moving
+// it to the time it would have shown had they never been made. It does
+// nothing if there are none to undo.
+func (c ClockOn[RT]) TravelBack() {
+	c.st.mu.Lock()
+	m := c.st.travel
+	c.st.travel = nil
+	c.st.mu.Unlock()
+	if m == nil {
+		return
+	}
+
+	c.SetScale(m.scale)
+	now := m.now
+	if m.active {
+		now = now.Add(Duration(float64(c.st.ref.Now().Sub(m.ref)) * m.scale))
+	}
+	c.jump(now)
+	if m.active {
+		c.Start()
+	} else {
+		c.Stop()
+	}
+}
+
+// Scaled calls f with c running at scale, then restores whether c was
+// running and at what scale. Time that passed during f is kept.
+func (c ClockOn[RT]) Scaled(scale float64, f func()) {
+	active, prev := c.Active(), c.Scale()
+	c.SetScale(scale)
+	c.Start()
+	defer func() {
+		c.SetScale(prev)
+		if !active {
+			c.Stop()
+		}
+	}()
+	f()
+}
+
+// Freeze stops the global Clock instance. See [Clock.Freeze].
+func Freeze() { clock().Freeze() }
+
+// Travel moves the global Clock instance to t. See [Clock.Travel].
+func Travel(t Time) { clock().Travel(t) }
+
+// TravelBack undoes every call to Freeze and Travel on the global Clock
+// instance. See [Clock.TravelBack].
+func TravelBack() { clock().TravelBack() }
+
+// Scaled calls f with the global Clock instance running at scale. See
+// [Clock.Scaled].
+func Scaled(scale float64, f func()) { clock().Scaled(scale, f) }
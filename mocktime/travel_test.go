@@ -0,0 +1,58 @@
+package mocktime_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock/mocktime"
+)
+
+func TestTravel(t *testing.T) {
+	c := NewClockAt(Unix(0, 0))
+	c.Start()
+	defer c.Stop()
+
+	c.Freeze()
+	frozen := c.Now()
+	time.Sleep(5 * time.Millisecond)
+	if !c.Now().Equal(frozen) {
+		t.Errorf("Now() changed while frozen")
+	}
+
+	tm := c.NewTimer(Hour)
+	c.Travel(frozen.Add(2 * Hour))
+	select {
+	case <-tm.C():
+	default:
+		t.Errorf("Timer did not fire on traveling past it")
+	}
+	tm = c.NewTimer(Hour)
+	c.Travel(Unix(0, 0).Add(-24 * Hour))
+	if got, want := c.NextAt(), Unix(0, 0).Add(-23*Hour); !got.Equal(want) {
+		t.Errorf("NextAt() = %v after traveling back, want %v", got, want)
+	}
+	tm.Stop()
+
+	c.TravelBack()
+	if !c.Active() {
+		t.Errorf("clock not running after TravelBack")
+	}
+	if dt := c.Since(frozen); dt < 5*Millisecond || dt > Second {
+		t.Errorf("TravelBack returned to %v past the freeze, want about the real time elapsed", dt)
+	}
+
+	c.Stop()
+	c.Scaled(1000, func() {
+		if c.Scale() != 1000 || !c.Active() {
+			t.Errorf("Scaled: Scale() = %v, Active() = %v; want 1000, true", c.Scale(), c.Active())
+		}
+		start := c.Now()
+		time.Sleep(5 * time.Millisecond)
+		if dt := c.Since(start); dt < 5*Second {
+			t.Errorf("Scaled(1000) advanced by %v in 5ms", dt)
+		}
+	})
+	if c.Scale() != 1 || c.Active() {
+		t.Errorf("after Scaled: Scale() = %v, Active() = %v; want 1, false", c.Scale(), c.Active())
+	}
+}
@@ -0,0 +1,130 @@
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/noodlebox/clock/realtime"
+	"github.com/noodlebox/clock/relativetime"
+)
+
+// How often a clock returned by FromNowFunc checks its function while
+// waiting on a timer.
+const nowFuncPoll = 10 * time.Millisecond
+
+// FromNowFunc returns a StdClock reading the current time from now, for
+// bringing in code that exposes its notion of time only as such a function.
+// Now, Since, and Until call now directly. Timers, Tickers, and Sleep are
+// emulated by polling now at least every 10ms of real time while any are
+// pending, so they may fire late by up to that much, but follow now
+// wherever it goes, whether it tracks real time or is a fake set by hand.
+// Calendar methods are those of [realtime].
+func FromNowFunc(now func() time.Time) StdClock {
+	ref := pollClock{now}
+	rc := relativetime.NewClock[time.Time, time.Duration, *pollTimer](ref, now(), 1.0)
+	rc.Start()
+	return AdaptLocated[time.Time, time.Duration, *relativetime.Timer[time.Time, time.Duration], *relativetime.Ticker[time.Time, time.Duration]](
+		nowFuncClock{rc, calendar{}, now},
+	)
+}
+
+type calendar struct {
+	realtime.Clock
+}
+
+type nowFuncClock struct {
+	*relativetime.Clock[time.Time, time.Duration, *pollTimer]
+	calendar // embed within a struct to ensure lower precedence
+
+	now func() time.Time
+}
+
+func (c nowFuncClock) Now() time.Time { return c.now() }
+
+func (c nowFuncClock) Since(t time.Time) time.Duration { return c.now().Sub(t) }
+
+func (c nowFuncClock) Until(t time.Time) time.Duration { return t.Sub(c.now()) }
+
+// A reference clock for relativetime, whose timers poll a function for the
+// current time.
+type pollClock struct {
+	now func() time.Time
+}
+
+func (c pollClock) Now() time.Time { return c.now() }
+
+func (pollClock) Seconds(n float64) time.Duration { return time.Duration(n * float64(time.Second)) }
+
+func (c pollClock) AfterFunc(d time.Duration, f func()) *pollTimer {
+	t := &pollTimer{now: c.now, f: f}
+	t.Reset(d)
+	return t
+}
+
+// A timer calling f once now reaches at, checked whenever at would be
+// reached in real time, or after nowFuncPoll, whichever comes first.
+type pollTimer struct {
+	now    func() time.Time
+	f      func()
+	at     time.Time
+	rt     *time.Timer
+	active bool
+	gen    uint64 // Incremented on each change, to ignore stale checks
+
+	mu sync.Mutex // Protects all fields but now and f
+}
+
+func (t *pollTimer) Reset(d time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	active := t.stop()
+	t.at = t.now().Add(d)
+	t.active = true
+	t.arm()
+	return active
+}
+
+func (t *pollTimer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stop()
+}
+
+// Disarm the timer, returning whether it was active. Callers must hold the
+// lock.
+func (t *pollTimer) stop() bool {
+	active := t.active
+	t.active = false
+	t.gen++
+	if t.rt != nil {
+		t.rt.Stop()
+	}
+	return active
+}
+
+// Schedule the next check. Callers must hold the lock.
+func (t *pollTimer) arm() {
+	wait := t.at.Sub(t.now())
+	if wait > nowFuncPoll {
+		wait = nowFuncPoll
+	}
+	gen := t.gen
+	t.rt = time.AfterFunc(wait, func() { t.check(gen) })
+}
+
+func (t *pollTimer) check(gen uint64) {
+	t.mu.Lock()
+	if gen != t.gen {
+		t.mu.Unlock()
+		return
+	}
+	if t.now().Before(t.at) {
+		t.arm()
+		t.mu.Unlock()
+		return
+	}
+	t.active = false
+	t.gen++
+	t.mu.Unlock()
+	t.f()
+}
@@ -0,0 +1,40 @@
+package clock_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock"
+)
+
+func TestFromNowFunc(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var offset atomic.Int64
+	now := func() time.Time { return start.Add(time.Duration(offset.Load())) }
+	c := FromNowFunc(now)
+
+	if got := c.Now(); !got.Equal(start) {
+		t.Errorf("Now() = %v, want %v", got, start)
+	}
+	offset.Store(int64(time.Minute))
+	if got := c.Since(start); got != time.Minute {
+		t.Errorf("Since() = %v, want %v", got, time.Minute)
+	}
+
+	tm := c.NewTimer(time.Hour)
+	select {
+	case <-tm.C():
+		t.Fatalf("Timer fired before its time")
+	case <-time.After(30 * time.Millisecond):
+	}
+	offset.Add(int64(time.Hour))
+	select {
+	case got := <-tm.C():
+		if want := start.Add(time.Hour + time.Minute); !got.Equal(want) {
+			t.Errorf("Timer fired at %v, want %v", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timer did not fire once now passed its time")
+	}
+}
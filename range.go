@@ -0,0 +1,113 @@
+package clock
+
+import (
+	"reflect"
+)
+
+// Range is a half-open interval of time, including Start but not End. A
+// Range whose End is not after its Start is empty.
+type Range[T Time[T, D], D Duration] struct {
+	Start, End T
+}
+
+// NewRange returns the Range of duration d beginning at start.
+func NewRange[T Time[T, D], D Duration](start T, d D) Range[T, D] {
+	return Range[T, D]{start, start.Add(d)}
+}
+
+// Empty reports whether r contains no instants.
+func (r Range[T, D]) Empty() bool {
+	return !r.End.After(r.Start)
+}
+
+// Duration returns the length of r.
+func (r Range[T, D]) Duration() D {
+	return r.End.Sub(r.Start)
+}
+
+// Contains reports whether t falls within r.
+func (r Range[T, D]) Contains(t T) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// Overlaps reports whether r and s share any instant.
+func (r Range[T, D]) Overlaps(s Range[T, D]) bool {
+	_, ok := r.Intersect(s)
+	return ok
+}
+
+// Intersect returns the Range of instants shared by r and s. It returns
+// false if there are none.
+func (r Range[T, D]) Intersect(s Range[T, D]) (Range[T, D], bool) {
+	if s.Start.After(r.Start) {
+		r.Start = s.Start
+	}
+	if s.End.Before(r.End) {
+		r.End = s.End
+	}
+	if r.Empty() {
+		return Range[T, D]{}, false
+	}
+	return r, true
+}
+
+// Clamp returns t limited to the instants within r: r.Start if t is before
+// it, or if t is at or after r.End, which r excludes, the last instant before
+// r.End. That is one unit of D before it where D is an integer type, such as
+// a nanosecond for [time.Duration], or the nearest instant that can be
+// represented where D is a floating point type. If r is empty, Clamp returns
+// r.Start. It panics if D is not a number.
+func (r Range[T, D]) Clamp(t T) T {
+	if t.Before(r.Start) || r.Empty() {
+		return r.Start
+	}
+	if !t.Before(r.End) {
+		return r.last()
+	}
+	return t
+}
+
+// Return the last instant within r, which must not be empty.
+func (r Range[T, D]) last() T {
+	var step D
+	v := reflect.ValueOf(&step).Elem()
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(-1)
+	case reflect.Float32, reflect.Float64:
+		// Halve the step back from End for as long as it still lands before
+		// End, starting from the whole Range
+		f := reflect.ValueOf(r.Duration()).Float()
+		for {
+			v.SetFloat(-f / 2)
+			if f/2 == 0 || !r.End.Add(step).Before(r.End) {
+				break
+			}
+			f /= 2
+		}
+		v.SetFloat(-f)
+	default:
+		panic("Range.Clamp: Duration type is not a number")
+	}
+	return r.End.Add(step)
+}
+
+// Split divides r into consecutive Ranges of duration d, in order. The last
+// is shorter if d does not evenly divide the duration of r. The duration d
+// must be greater than zero; if not, Split will panic.
+func (r Range[T, D]) Split(d D) []Range[T, D] {
+	if d.Seconds() <= 0 {
+		panic("non-positive duration for clock.Range.Split")
+	}
+
+	var rs []Range[T, D]
+	for t := r.Start; t.Before(r.End); {
+		next := t.Add(d)
+		if next.After(r.End) {
+			next = r.End
+		}
+		rs = append(rs, Range[T, D]{t, next})
+		t = next
+	}
+	return rs
+}
@@ -0,0 +1,82 @@
+package clock_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+type steppedRange = Range[steppedtime.Time, steppedtime.Duration]
+
+func TestRangeIntersect(t *testing.T) {
+	for _, tt := range []struct {
+		r, s steppedRange
+		want steppedRange
+		ok   bool
+	}{
+		{steppedRange{0, 10}, steppedRange{5, 15}, steppedRange{5, 10}, true},
+		{steppedRange{5, 15}, steppedRange{0, 10}, steppedRange{5, 10}, true},
+		{steppedRange{0, 10}, steppedRange{2, 3}, steppedRange{2, 3}, true},
+		{steppedRange{0, 10}, steppedRange{10, 20}, steppedRange{}, false},
+		{steppedRange{0, 10}, steppedRange{20, 30}, steppedRange{}, false},
+	} {
+		got, ok := tt.r.Intersect(tt.s)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%v.Intersect(%v) = %v, %v; want %v, %v", tt.r, tt.s, got, ok, tt.want, tt.ok)
+		}
+		if tt.r.Overlaps(tt.s) != tt.ok {
+			t.Errorf("%v.Overlaps(%v) = %v, want %v", tt.r, tt.s, !tt.ok, tt.ok)
+		}
+	}
+}
+
+func TestRangeContainsClamp(t *testing.T) {
+	r := steppedRange{10, 20}
+	for _, tt := range []struct {
+		t        steppedtime.Time
+		contains bool
+		clamp    steppedtime.Time
+	}{
+		{5, false, 10},
+		{10, true, 10},
+		{15, true, 15},
+		{19, true, 19},
+		{20, false, 19},
+		{25, false, 19},
+	} {
+		if got := r.Contains(tt.t); got != tt.contains {
+			t.Errorf("%v.Contains(%v) = %v, want %v", r, tt.t, got, tt.contains)
+		}
+		if got := r.Clamp(tt.t); got != tt.clamp {
+			t.Errorf("%v.Clamp(%v) = %v, want %v", r, tt.t, got, tt.clamp)
+		}
+	}
+
+	if got := (steppedRange{10, 10}).Clamp(15); got != 10 {
+		t.Errorf("Clamp(15) on an empty Range = %v, want its start", got)
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr := NewRange(start, time.Hour)
+	if got, want := tr.Clamp(start.Add(2*time.Hour)), start.Add(time.Hour-time.Nanosecond); !got.Equal(want) {
+		t.Errorf("Clamp past the end = %v, want %v", got, want)
+	}
+}
+
+func TestRangeSplit(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewRange(start, 150*time.Minute)
+	var got []time.Duration
+	for _, s := range r.Split(time.Hour) {
+		got = append(got, s.Duration())
+	}
+	want := []time.Duration{time.Hour, time.Hour, 30 * time.Minute}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Split(1h) durations = %v, want %v", got, want)
+	}
+	if rs := (Range[time.Time, time.Duration]{start, start}).Split(time.Hour); len(rs) != 0 {
+		t.Errorf("Split of empty range = %v, want none", rs)
+	}
+}
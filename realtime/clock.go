@@ -1,7 +1,10 @@
 package realtime
 
 import (
+	"sync"
 	"time"
+
+	"github.com/noodlebox/clock/internal/durations"
 )
 
 // See [time.Time].
@@ -146,6 +149,32 @@ func (Clock) ParseDuration(s string) (Duration, error) {
 	return time.ParseDuration(s)
 }
 
+// MustParseDuration is like ParseDuration, but panics if s cannot be parsed.
+func (Clock) MustParseDuration(s string) Duration {
+	return durations.MustParse(s)
+}
+
+// ParseISODuration parses an ISO 8601 duration, such as "P1DT2H30M" or
+// "PT0.5S", optionally preceded by a sign. Days are taken as 24 hours and
+// weeks as 7 days; years and months, whose lengths vary, are rejected.
+func (Clock) ParseISODuration(s string) (Duration, error) {
+	return durations.ParseISO(s)
+}
+
+// FormatISODuration formats d as an ISO 8601 duration, such as "P1DT2H30M",
+// as accepted by ParseISODuration.
+func (Clock) FormatISODuration(d Duration) string {
+	return durations.FormatISO(d)
+}
+
+// DurationOf returns the sum of the given counts of units, written as
+// alternating pairs of a count and a unit, such as DurationOf(1, Hour, 30,
+// Minute). It panics if given an odd number of values, or if the sum
+// overflows.
+func (Clock) DurationOf(pairs ...Duration) Duration {
+	return durations.Of(pairs...)
+}
+
 // Since returns the time elapsed since t. It is shorthand for
 // clock.Now().Sub(t).
 func (Clock) Since(t Time) Duration {
@@ -157,6 +186,14 @@ func (Clock) Until(t Time) Duration {
 	return time.Until(t)
 }
 
+// Close does nothing, and always returns nil. It is provided so that each
+// Clock implementation in this module may be torn down alike, but Timers,
+// Tickers, and sleeps on the real clock belong to the runtime, which no
+// Clock value tracks, so each must be stopped on its own.
+func (Clock) Close() error {
+	return nil
+}
+
 // Sleep pauses the current goroutine for at least the duration d. A negative
 // or zero duration causes Sleep to return immediately.
 func (Clock) Sleep(d Duration) {
@@ -164,13 +201,188 @@ func (Clock) Sleep(d Duration) {
 }
 
 // Ticker wraps [time.Ticker] to provide an interfaceable implementation.
+// One created by NewForwardingTicker forwards its ticks to a channel of its
+// own, so that those dropped for slow receivers may be counted, and its
+// phase kept across Resume and SetPeriod.
 type Ticker struct {
 	*time.Ticker
+
+	c         chan Time     // Delivers the ticks forwarded from Ticker, nil unless forwarded
+	done      chan struct{} // Closed to stop forwarding, nil unless forwarding
+	since     Time          // Ticks due before this are stale
+	last      int           // Number of periods after start of the last tick
+	missed    int           // Ticks dropped since the last call to Missed
+	period    Duration
+	start     Time     // Ticks are due a whole number of periods after start
+	stopped   bool     // Whether Stop has been called without Reset
+	paused    bool     // Whether Pause has been called without Resume
+	remaining Duration // Time left until the next tick, while paused
+	shifted   bool     // Whether the next tick is due off the period
+
+	skipSuspend bool   // Set by SetSkipSuspend
+	unwatch     func() // Stops watching for suspends, while running
+
+	mu sync.Mutex
 }
 
 // C returns the channel on which the ticks are delivered.
 func (t *Ticker) C() <-chan Time {
-	return t.Ticker.C
+	if t.c == nil {
+		return t.Ticker.C
+	}
+	return t.c
+}
+
+// Missed returns the number of ticks that were not delivered since the
+// previous call to Missed, because the receiver was not ready for them.
+// Only a Ticker created by NewForwardingTicker sees its ticks to count
+// them; for one created by NewTicker, Missed always returns 0.
+func (t *Ticker) Missed() (n int) {
+	t.mu.Lock()
+	n, t.missed = t.missed, 0
+	t.mu.Unlock()
+	return
+}
+
+// Forward ticks from src to c until done is closed, counting those dropped,
+// either here for want of a receiver, or by src for want of forwarding.
+func (t *Ticker) forward(src <-chan Time, done <-chan struct{}) {
+	for {
+		var now Time
+		select {
+		case <-done:
+			return
+		case now = <-src:
+		}
+		t.mu.Lock()
+		if t.paused || now.Before(t.since) {
+			// Sent before a Pause, Reset, or change of phase
+			t.mu.Unlock()
+			continue
+		}
+		if k := int((now.Sub(t.start) + t.period/2) / t.period); k > t.last {
+			t.missed += k - t.last - 1
+			t.last = k
+		}
+		select {
+		case t.c <- now:
+		default:
+			t.missed++
+		}
+		if t.shifted {
+			// Restore the period from the shifted tick on
+			t.shifted = false
+			t.Ticker.Reset(t.period)
+			t.start, t.last = now, 0
+		}
+		t.mu.Unlock()
+	}
+}
+
+// Reset stops a ticker and resets its period to the specified duration. The
+// next tick will arrive after the new period elapses. The duration d must be
+// greater than zero; if not, Reset will panic.
+func (t *Ticker) Reset(d Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Ticker.Reset(d)
+	t.shifted = false
+	t.period, t.start = d, time.Now()
+	t.since, t.last = t.start, 0
+	t.stopped, t.paused = false, false
+	if t.c != nil && t.done == nil {
+		t.done = make(chan struct{})
+		go t.forward(t.Ticker.C, t.done)
+	}
+	if t.skipSuspend {
+		t.watchSuspend()
+	}
+}
+
+// SetPeriod changes the period of a ticker without disturbing its phase:
+// the next tick still arrives when it was due, and later ones follow at
+// intervals of the new period. Unlike Reset, it does not restart a stopped
+// ticker. The duration d must be greater than zero; if not, SetPeriod will
+// panic. As with Resume, the phase of later ticks may slip by the latency in
+// forwarding the next tick. A Ticker created by NewTicker, which does not
+// forward its ticks, instead starts afresh, as by Reset, but only if
+// running.
+func (t *Ticker) SetPeriod(d Duration) {
+	if d <= 0 {
+		panic("non-positive interval for realtime.Ticker.SetPeriod")
+	}
+
+	t.mu.Lock()
+	if t.stopped || t.paused {
+		t.period = d
+	} else {
+		next := t.period - time.Since(t.start)%t.period
+		t.period = d
+		t.restart(next)
+	}
+	t.mu.Unlock()
+}
+
+// Stop turns off a ticker. After Stop, no more ticks will be sent. Stop does
+// not close the channel, to prevent a concurrent goroutine reading from the
+// channel from seeing an erroneous "tick".
+func (t *Ticker) Stop() {
+	t.mu.Lock()
+	t.unwatchSuspend()
+	t.Ticker.Stop()
+	if t.done != nil {
+		close(t.done)
+		t.done = nil
+	}
+	t.stopped, t.paused = true, false
+	t.mu.Unlock()
+}
+
+// Pause suspends a running ticker, remembering the time left until its next
+// tick. No ticks are sent while paused. Pause has no effect on a ticker that
+// is already paused or has been stopped.
+func (t *Ticker) Pause() {
+	t.mu.Lock()
+	if !t.stopped && !t.paused {
+		t.Ticker.Stop()
+		t.paused = true
+		t.remaining = t.period - time.Since(t.start)%t.period
+	}
+	t.mu.Unlock()
+}
+
+// Resume restarts a paused ticker in the same phase, so that its next tick
+// arrives once the time that was left when it was paused has elapsed.
+// Resume has no effect on a ticker that is not paused. Reset or Stop on a
+// paused ticker clears the pause.
+//
+// As [time.Ticker] offers no way to delay only its first tick, the period
+// is restored as that tick is forwarded, so the phase of later ticks may
+// slip by the latency in forwarding it. A Ticker created by NewTicker,
+// which does not forward its ticks, instead ticks a full period after it is
+// resumed.
+func (t *Ticker) Resume() {
+	t.mu.Lock()
+	if t.paused {
+		t.paused = false
+		t.restart(t.remaining)
+	}
+	t.mu.Unlock()
+}
+
+// restart sets the ticker running with its next tick due after next, and
+// those following at intervals of its period, restored by forward once that
+// tick arrives. Without a forwarder to restore it, the next tick is instead
+// due a full period from now. Callers must hold the lock.
+func (t *Ticker) restart(next Duration) {
+	if t.c == nil {
+		next = t.period
+	}
+	now := time.Now()
+	t.start = now.Add(next - t.period)
+	t.since, t.last = now, 0
+	t.shifted = next != t.period
+	t.Ticker.Reset(next)
 }
 
 // NewTicker returns a new Ticker containing a channel that will send the
@@ -178,9 +390,25 @@ func (t *Ticker) C() <-chan Time {
 // specified by the duration argument. The ticker will adjust the time
 // interval or drop ticks to make up for slow receivers. The duration d must
 // be greater than zero; if not, NewTicker will panic. Stop the ticker to
-// release associated resources.
+// release associated resources. Its channel is that of the underlying
+// [time.Ticker], so, as for one, nothing more is needed to run it.
 func (Clock) NewTicker(d Duration) *Ticker {
-	return &Ticker{time.NewTicker(d)}
+	start := time.Now()
+	return &Ticker{Ticker: time.NewTicker(d), since: start, period: d, start: start}
+}
+
+// NewForwardingTicker is like NewTicker, but forwards the ticks to a
+// channel of its own, from a goroutine that runs until the ticker is
+// stopped, so that Missed may count the ticks dropped for slow receivers,
+// and Resume and SetPeriod may keep the ticker in phase. Unlike one created
+// by NewTicker, it must be stopped to be recovered by the garbage
+// collector. The duration d must be greater than zero; if not,
+// NewForwardingTicker will panic.
+func (Clock) NewForwardingTicker(d Duration) *Ticker {
+	start := time.Now()
+	t := &Ticker{Ticker: time.NewTicker(d), c: make(chan Time, 1), done: make(chan struct{}), since: start, period: d, start: start}
+	go t.forward(t.Ticker.C, t.done)
+	return t
 }
 
 // Tick is a convenience wrapper for NewTicker providing access to the
@@ -195,6 +423,14 @@ func (Clock) Tick(d Duration) <-chan Time {
 // Timer wraps [time.Timer] to provide an interfaceable implementation.
 type Timer struct {
 	*time.Timer
+
+	when      Time     // Time at which the timer is due to expire
+	paused    bool     // Whether Pause has been called without Resume
+	remaining Duration // Time left until expiry, while paused
+	f         func()   // Function to call, for timers created by AfterFunc
+	onStop    func()   // Set by OnStop
+
+	mu sync.Mutex
 }
 
 // C returns the channel on which the ticks are delivered.
@@ -202,10 +438,101 @@ func (t *Timer) C() <-chan Time {
 	return t.Timer.C
 }
 
+// call runs the function most recently given to AfterFunc or ResetFunc.
+func (t *Timer) call() {
+	t.mu.Lock()
+	f := t.f
+	t.mu.Unlock()
+	f()
+}
+
+// Reset changes the timer to expire after duration d. It returns true if the
+// timer had been active, false if the timer had expired or been stopped.
+func (t *Timer) Reset(d Duration) (active bool) {
+	t.mu.Lock()
+	active = t.Timer.Reset(d) || t.paused
+	t.when, t.paused = time.Now().Add(d), false
+	t.mu.Unlock()
+	return
+}
+
+// ResetFunc is like Reset, but also replaces the function to be called when
+// the timer expires with f, so that a single Timer may be re-armed with
+// different callbacks without allocating a new one each time. It panics if
+// the Timer was not created by AfterFunc. As a call already due may not yet
+// have started, ResetFunc is best called from the previous function itself,
+// or after it has returned; otherwise, that call may run f in its place.
+func (t *Timer) ResetFunc(d Duration, f func()) (active bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.f == nil {
+		panic("ResetFunc called on realtime.Timer not created by AfterFunc")
+	}
+	t.f = f
+	active = t.Timer.Reset(d) || t.paused
+	t.when, t.paused = time.Now().Add(d), false
+	return
+}
+
+// Stop prevents the Timer from firing. It returns true if the call stops the
+// timer, false if the timer has already expired or been stopped. Stop does
+// not close the channel, to prevent a read from the channel succeeding
+// incorrectly.
+func (t *Timer) Stop() (active bool) {
+	t.mu.Lock()
+	active = t.Timer.Stop() || t.paused
+	t.paused = false
+	if active && t.onStop != nil {
+		go t.onStop()
+	}
+	t.mu.Unlock()
+	return
+}
+
+// OnStop sets f to be called in its own goroutine whenever Stop stops the
+// Timer before firing, so that resources tied to the pending work may be
+// released deterministically. It is not called when the Timer fires, nor by
+// Stop on a Timer that has already fired or been stopped. A nil f removes
+// any function set previously.
+func (t *Timer) OnStop(f func()) {
+	t.mu.Lock()
+	t.onStop = f
+	t.mu.Unlock()
+}
+
+// Pause suspends an active timer, remembering the time left until it
+// expires. It returns true if the call pauses the timer, false if the timer
+// has already expired, been stopped, or been paused. A paused timer counts
+// as active for Reset and Stop, either of which clears the pause.
+func (t *Timer) Pause() (paused bool) {
+	t.mu.Lock()
+	if !t.paused && t.Timer.Stop() {
+		t.paused, paused = true, true
+		t.remaining = time.Until(t.when)
+	}
+	t.mu.Unlock()
+	return
+}
+
+// Resume re-arms a paused timer to expire once the time that was left when
+// it was paused has elapsed. It returns true if the call resumes the timer,
+// false if the timer was not paused.
+func (t *Timer) Resume() (resumed bool) {
+	t.mu.Lock()
+	if resumed = t.paused; resumed {
+		t.paused = false
+		t.when = time.Now().Add(t.remaining)
+		t.Timer.Reset(t.remaining)
+	}
+	t.mu.Unlock()
+	return
+}
+
 // NewTimer creates a new Timer that will send the current time on its
 // channel after at least duration d.
 func (Clock) NewTimer(d Duration) *Timer {
-	return &Timer{time.NewTimer(d)}
+	when := time.Now().Add(d)
+	return &Timer{Timer: time.NewTimer(d), when: when}
 }
 
 // After waits for the duration to elapse and then sends the current time on
@@ -221,7 +548,9 @@ func (Clock) After(d Duration) <-chan Time {
 // goroutine. It returns a Timer that can be used to cancel the call using
 // its Stop method.
 func (Clock) AfterFunc(d Duration, f func()) *Timer {
-	return &Timer{time.AfterFunc(d, f)}
+	t := &Timer{when: time.Now().Add(d), f: f}
+	t.Timer = time.AfterFunc(d, t.call)
+	return t
 }
 
 // Wall clock (Location dependent) implementation
@@ -241,6 +570,13 @@ func (Clock) Date(year int, month Month, day, hour, min, sec, nsec int, loc *Loc
 	return time.Date(year, month, day, hour, min, sec, nsec, loc)
 }
 
+// AddDate returns the time corresponding to adding the given number of
+// years, months, and days to t. It is equivalent to t.AddDate(years, months,
+// days). See [time.Time.AddDate].
+func (Clock) AddDate(t Time, years, months, days int) Time {
+	return t.AddDate(years, months, days)
+}
+
 // See [time.Unix].
 func (Clock) Unix(sec int64, nsec int64) Time {
 	return time.Unix(sec, nsec)
@@ -0,0 +1,24 @@
+package realtime
+
+import (
+	"github.com/noodlebox/clock/internal/daily"
+)
+
+// SleepUntilNext pauses the current goroutine until the next time the wall
+// clock in loc reads clockTime, given as "15:04" or "15:04:05", such as
+// "09:00" for the next nine o'clock. If loc is nil, the Local time zone is
+// used. The next occurrence is found on the calendar, rather than by adding
+// 24 hours, so it keeps to the wall clock across changes to daylight saving
+// time; a time skipped by such a change is normalized as by [time.Date]. It
+// returns an error, without sleeping, if clockTime is not a valid time of
+// day. As the duration is measured on the monotonic clock, a step in the
+// wall clock while sleeping is not noticed.
+func (c Clock) SleepUntilNext(clockTime string, loc *Location) error {
+	now := c.Now()
+	next, err := daily.Next(now, clockTime, loc)
+	if err != nil {
+		return err
+	}
+	c.Sleep(next.Sub(now))
+	return nil
+}
@@ -2,4 +2,8 @@
 // works with [time.Time] and [time.Duration] values. [Timer] and [Ticker]
 // override their corresponding C fields with a method, to work around the
 // limitation of interfaces not being able to specify fields.
+//
+// Package-level functions such as [Now], [Sleep], and [NewTimer] mirror those
+// of [time] and of the mocktime package, so that code importing one may be
+// switched to another by changing only the import.
 package realtime
@@ -0,0 +1,51 @@
+package realtime
+
+import (
+	"time"
+)
+
+// Hooks into the internals of the package, for its tests only.
+
+type WallReading = wallReading
+
+func NewWallReading(wall Time, mono Duration) WallReading {
+	return wallReading{wall: wall, mono: mono}
+}
+
+var WatchWall = watchWall
+
+type SuspendReading = suspendReading
+
+func NewSuspendReading(wall Time, mono, total Duration, exact bool) SuspendReading {
+	return suspendReading{wall: wall, mono: mono, total: total, exact: exact}
+}
+
+var WatchSuspend = watchSuspend
+
+const SuspendPoll = suspendPoll
+
+func NewSuspend(at Time, slept, unseen Duration, resume Time) Suspend {
+	return Suspend{At: at, Slept: slept, unseen: unseen, resume: resume}
+}
+
+func (s Suspend) Unseen() Duration { return s.unseen }
+func (s Suspend) Resume() Time     { return s.resume }
+
+func (t *Ticker) Resumed(s Suspend) { t.resumed(s) }
+
+// NewTickerFrom returns a Ticker with period d forwarding the ticks sent on
+// src, rather than those of its own, so that tests may send them at will.
+func NewTickerFrom(d Duration, src <-chan Time) *Ticker {
+	start := time.Now()
+	t := &Ticker{Ticker: time.NewTicker(d), c: make(chan Time, 1), done: make(chan struct{}), since: start, period: d, start: start}
+	t.Ticker.Stop()
+	go t.forward(src, t.done)
+	return t
+}
+
+// Start returns the time from which the ticks of t are due.
+func (t *Ticker) Start() Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.start
+}
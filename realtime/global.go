@@ -0,0 +1,109 @@
+package realtime
+
+// Wrap package-level functions around Clock methods, mirroring both [time]
+// and the package-level API of mocktime, so that either may stand in for
+// the other by changing only an import.
+
+var clock Clock
+
+// After waits for the duration to elapse and then sends the current time on
+// the returned channel. See [time.After].
+func After(d Duration) <-chan Time { return clock.After(d) }
+
+// Sleep pauses the current goroutine for at least the duration d. See
+// [time.Sleep].
+func Sleep(d Duration) { clock.Sleep(d) }
+
+// SleepUntilNext pauses the current goroutine until the next time the wall
+// clock in loc reads clockTime. See [Clock.SleepUntilNext].
+func SleepUntilNext(clockTime string, loc *Location) error {
+	return clock.SleepUntilNext(clockTime, loc)
+}
+
+// Tick is a convenience wrapper for NewTicker providing access to the
+// ticking channel only. See [time.Tick].
+func Tick(d Duration) <-chan Time { return clock.Tick(d) }
+
+// See [time.ParseDuration].
+func ParseDuration(s string) (Duration, error) { return clock.ParseDuration(s) }
+
+// MustParseDuration is like ParseDuration, but panics if s cannot be parsed.
+func MustParseDuration(s string) Duration { return clock.MustParseDuration(s) }
+
+// ParseISODuration parses an ISO 8601 duration, such as "P1DT2H30M". See
+// [Clock.ParseISODuration].
+func ParseISODuration(s string) (Duration, error) { return clock.ParseISODuration(s) }
+
+// FormatISODuration formats d as an ISO 8601 duration. See
+// [Clock.FormatISODuration].
+func FormatISODuration(d Duration) string { return clock.FormatISODuration(d) }
+
+// DurationOf returns the sum of the given counts of units, written as
+// alternating pairs of a count and a unit. See [Clock.DurationOf].
+func DurationOf(pairs ...Duration) Duration { return clock.DurationOf(pairs...) }
+
+// Since returns the time elapsed since t. See [time.Since].
+func Since(t Time) Duration { return clock.Since(t) }
+
+// Until returns the duration until t. See [time.Until].
+func Until(t Time) Duration { return clock.Until(t) }
+
+// NewTicker returns a new Ticker containing a channel that will send the
+// current time on the channel after each tick. See [time.NewTicker].
+func NewTicker(d Duration) *Ticker { return clock.NewTicker(d) }
+
+// NewForwardingTicker is like NewTicker, but counts the ticks dropped for
+// slow receivers. See [Clock.NewForwardingTicker].
+func NewForwardingTicker(d Duration) *Ticker { return clock.NewForwardingTicker(d) }
+
+// See [time.Date].
+func Date(year int, month Month, day, hour, min, sec, nsec int, loc *Location) Time {
+	return clock.Date(year, month, day, hour, min, sec, nsec, loc)
+}
+
+// Now returns the current local time. See [time.Now].
+func Now() Time { return clock.Now() }
+
+// NowQuantized returns the current local time rounded down to a multiple of
+// granularity, cached until the next boundary. See [Clock.NowQuantized].
+func NowQuantized(granularity Duration) Time { return clock.NowQuantized(granularity) }
+
+// See [time.Parse].
+func Parse(layout, value string) (Time, error) { return clock.Parse(layout, value) }
+
+// See [time.ParseInLocation].
+func ParseInLocation(layout, value string, loc *Location) (Time, error) {
+	return clock.ParseInLocation(layout, value, loc)
+}
+
+// See [time.Unix].
+func Unix(sec int64, nsec int64) Time { return clock.Unix(sec, nsec) }
+
+// See [time.UnixMicro].
+func UnixMicro(usec int64) Time { return clock.UnixMicro(usec) }
+
+// See [time.UnixMilli].
+func UnixMilli(msec int64) Time { return clock.UnixMilli(msec) }
+
+// AfterFunc waits for the duration to elapse and then calls f in its own
+// goroutine. See [time.AfterFunc].
+func AfterFunc(d Duration, f func()) *Timer { return clock.AfterFunc(d, f) }
+
+// ScheduleFunc calls f in its own goroutine at each time given by s. See
+// [Clock.ScheduleFunc].
+func ScheduleFunc(s Schedule, f func(Time)) *Scheduled { return clock.ScheduleFunc(s, f) }
+
+// NewTimer creates a new Timer that will send the current time on its
+// channel after at least duration d. See [time.NewTimer].
+func NewTimer(d Duration) *Timer { return clock.NewTimer(d) }
+
+// See [time.FixedZone].
+func FixedZone(name string, offset int) *Location { return clock.FixedZone(name, offset) }
+
+// See [time.LoadLocation].
+func LoadLocation(name string) (*Location, error) { return clock.LoadLocation(name) }
+
+// See [time.LoadLocationFromTZData].
+func LoadLocationFromTZData(name string, data []byte) (*Location, error) {
+	return clock.LoadLocationFromTZData(name, data)
+}
@@ -0,0 +1,20 @@
+package realtime_test
+
+import (
+	"testing"
+
+	"github.com/noodlebox/clock/realtime"
+)
+
+func TestPackageFunctions(t *testing.T) {
+	t0 := realtime.Now()
+	realtime.Sleep(10 * realtime.Millisecond)
+	if dt := realtime.Since(t0); dt < 10*realtime.Millisecond {
+		t.Errorf("Sleep(10ms) returned after %v", dt)
+	}
+	<-realtime.After(realtime.Millisecond)
+	<-realtime.NewTimer(realtime.Millisecond).C()
+	if got := realtime.Date(2009, realtime.November, 10, 23, 0, 0, 0, realtime.UTC); got.Year() != 2009 {
+		t.Errorf("Date returned %v", got)
+	}
+}
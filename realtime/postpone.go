@@ -0,0 +1,34 @@
+package realtime
+
+import (
+	"time"
+)
+
+// Postpone moves the time at which an active timer expires later by delta,
+// without recomputing its deadline from the current time, so that an idle
+// timeout may be extended on every message without the window in which a
+// Stop followed by a Reset leaves it inactive. It returns true if the timer
+// was moved, false if it had already expired or been stopped. A paused
+// timer has its remaining time extended instead. A negative delta moves it
+// earlier, as with Advance.
+func (t *Timer) Postpone(delta Duration) (active bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.paused {
+		t.remaining += delta
+		return true
+	}
+	if !t.Timer.Stop() {
+		return false
+	}
+	t.when = t.when.Add(delta)
+	t.Timer.Reset(time.Until(t.when))
+	return true
+}
+
+// Advance moves the time at which an active timer expires earlier by delta,
+// firing it at once if that time has already passed. It is equivalent to
+// Postpone(-delta).
+func (t *Timer) Advance(delta Duration) (active bool) {
+	return t.Postpone(-delta)
+}
@@ -0,0 +1,99 @@
+package realtime
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A cached time for one granularity, kept current by a goroutine that stops
+// once it goes unread for quantumIdle boundaries in a row.
+type quantum struct {
+	g       Duration
+	now     atomic.Pointer[Time]
+	used    atomic.Bool // Whether read since the last boundary
+	stopped atomic.Bool
+}
+
+const quantumIdle = 100
+
+// Cached times by granularity, read without locks. Entries are only
+// replaced, once stopped, while holding quantaMu.
+var (
+	quanta   sync.Map // Duration to *quantum
+	quantaMu sync.Mutex
+)
+
+// Return the running quantum for g, starting one if needed.
+func loadQuantum(g Duration) *quantum {
+	if v, ok := quanta.Load(g); ok && !v.(*quantum).stopped.Load() {
+		return v.(*quantum)
+	}
+	quantaMu.Lock()
+	defer quantaMu.Unlock()
+	if v, ok := quanta.Load(g); ok && !v.(*quantum).stopped.Load() {
+		return v.(*quantum)
+	}
+	q := startQuantum(g)
+	quanta.Store(g, q)
+	return q
+}
+
+// NowQuantized returns the current local time rounded down to a multiple of
+// granularity, as with [time.Time.Truncate], for services that stamp every
+// request but need only coarse accuracy. The value is cached until the next
+// boundary, when a goroutine updates it, so that most calls read no clock
+// at all. The goroutine exits once the cache goes unread for a hundred
+// boundaries, and is started again by the next call. As with Truncate, the
+// result carries no monotonic clock reading. If granularity <= 0, it
+// returns the current time with no rounding.
+//
+// The result is never later than time.Now().Truncate(granularity), but it
+// lags behind it from each boundary until the goroutine wakes to update
+// the cache, by the latency of the timer waking it. That is usually far
+// less than a millisecond, but it is not bounded: on a heavily loaded
+// system, the goroutine may wait to be scheduled for a boundary or more.
+// Callers that cannot tolerate any lag should call time.Now and Truncate
+// the result themselves.
+func (Clock) NowQuantized(granularity Duration) Time {
+	if granularity <= 0 {
+		return time.Now()
+	}
+	for {
+		q := loadQuantum(granularity)
+		q.used.Store(true)
+		if now := *q.now.Load(); !q.stopped.Load() {
+			return now
+		}
+	}
+}
+
+func startQuantum(g Duration) *quantum {
+	q := &quantum{g: g}
+	now := time.Now()
+	q.update(now)
+	go q.run(now)
+	return q
+}
+
+func (q *quantum) update(now Time) {
+	t := now.Truncate(q.g)
+	q.now.Store(&t)
+}
+
+// Wake at each boundary after start, and publish the time there.
+func (q *quantum) run(start Time) {
+	tm := time.NewTimer(start.Truncate(q.g).Add(q.g).Sub(start))
+	defer tm.Stop()
+	idle := 0
+	for now := range tm.C {
+		q.update(now)
+		if q.used.Swap(false) {
+			idle = 0
+		} else if idle++; idle >= quantumIdle {
+			q.stopped.Store(true)
+			return
+		}
+		tm.Reset(now.Truncate(q.g).Add(q.g).Sub(now))
+	}
+}
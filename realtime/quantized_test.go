@@ -0,0 +1,25 @@
+package realtime_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock/realtime"
+)
+
+func TestNowQuantized(t *testing.T) {
+	const g = 10 * Millisecond
+	before := time.Now().Truncate(g)
+	got := time.NowQuantized(g)
+	if got.Truncate(g) != got {
+		t.Errorf("NowQuantized(%v) = %v, not a multiple", g, got)
+	}
+	if got.Before(before) {
+		t.Errorf("NowQuantized(%v) = %v, before %v", g, got, before)
+	}
+
+	// The cache advances across boundaries
+	time.Sleep(3 * g)
+	if later := time.NowQuantized(g); !later.After(got) {
+		t.Errorf("NowQuantized(%v) = %v after sleeping, want after %v", g, later, got)
+	}
+}
@@ -0,0 +1,24 @@
+package realtime
+
+// SetTimerSlack sets the timer slack of the calling thread to d, where
+// supported, as on Linux, and is otherwise a no-op. The kernel may delay
+// the expiry of a timer by up to its slack, so as to coalesce wakeups and
+// save power; the default of 50µs is fine for most uses, but latency-critical
+// code may lower it, down to a minimum of 1ns, at the cost of more wakeups
+// and so more CPU time and power. A value of 0 restores the default.
+//
+// Slack applies per OS thread, and is inherited by threads created
+// afterwards, while the Go runtime multiplexes goroutines over many
+// threads. The most reliable effect is had by calling SetTimerSlack early in
+// main, before the runtime starts most of its threads, or from a goroutine
+// locked to its thread by [runtime.LockOSThread] that then does the
+// latency-critical waiting itself.
+func (Clock) SetTimerSlack(d Duration) error {
+	return setTimerSlack(d)
+}
+
+// TimerSlack returns the timer slack of the calling thread, or 0 where it
+// cannot be read. See [Clock.SetTimerSlack].
+func (Clock) TimerSlack() (Duration, error) {
+	return timerSlack()
+}
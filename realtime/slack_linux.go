@@ -0,0 +1,31 @@
+//go:build linux
+
+package realtime
+
+import (
+	"syscall"
+)
+
+// From linux/prctl.h
+const (
+	prSetTimerSlack = 29
+	prGetTimerSlack = 30
+)
+
+func setTimerSlack(d Duration) error {
+	if d < 0 {
+		return syscall.EINVAL
+	}
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, prSetTimerSlack, uintptr(d), 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func timerSlack() (Duration, error) {
+	r, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, prGetTimerSlack, 0, 0)
+	if errno != 0 {
+		return 0, errno
+	}
+	return Duration(r), nil
+}
@@ -0,0 +1,7 @@
+//go:build !linux
+
+package realtime
+
+func setTimerSlack(d Duration) error { return nil }
+
+func timerSlack() (Duration, error) { return 0, nil }
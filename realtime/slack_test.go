@@ -0,0 +1,32 @@
+package realtime_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/noodlebox/clock/realtime"
+)
+
+func TestTimerSlack(t *testing.T) {
+	c := realtime.NewClock()
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	prev, err := c.TimerSlack()
+	if err != nil {
+		t.Fatalf("TimerSlack() = %v", err)
+	}
+	if prev == 0 {
+		t.Skip("timer slack not supported")
+	}
+	defer c.SetTimerSlack(prev)
+
+	if err := c.SetTimerSlack(realtime.Microsecond); err != nil {
+		t.Fatalf("SetTimerSlack(1µs) = %v", err)
+	}
+	if got, err := c.TimerSlack(); err != nil || got != realtime.Microsecond {
+		t.Errorf("TimerSlack() = %v, %v; want %v, nil", got, err, realtime.Microsecond)
+	}
+	if err := c.SetTimerSlack(-1); err == nil {
+		t.Errorf("SetTimerSlack(-1) = nil, want an error")
+	}
+}
@@ -816,3 +816,73 @@ func doWork(dur Duration) {
 	for time.Since(start) < dur {
 	}
 }
+
+func TestTimerPause(t *testing.T) {
+	const delta = 100 * Millisecond
+	tm := time.NewTimer(delta)
+	time.Sleep(delta / 2)
+	if !tm.Pause() {
+		t.Fatalf("Pause() = false on an active timer")
+	}
+	time.Sleep(delta)
+	select {
+	case <-tm.C():
+		t.Fatalf("timer fired while paused")
+	default:
+	}
+
+	t0 := time.Now()
+	if !tm.Resume() {
+		t.Fatalf("Resume() = false on a paused timer")
+	}
+	<-tm.C()
+	if dt := time.Since(t0); dt >= delta {
+		t.Errorf("timer fired %v after Resume, want less than %v", dt, delta)
+	}
+}
+
+func TestTimerResetFunc(t *testing.T) {
+	c := make(chan int, 2)
+	ready := make(chan struct{})
+	var tm *Timer
+	tm = time.AfterFunc(0, func() {
+		<-ready // Until tm is assigned
+		c <- 1
+		tm.ResetFunc(0, func() { c <- 2 })
+	})
+	close(ready)
+	for want := 1; want <= 2; want++ {
+		if got := <-c; got != want {
+			t.Fatalf("call %d ran function %d", want, got)
+		}
+	}
+
+	if tm.ResetFunc(Hour, func() { c <- 3 }) {
+		t.Errorf("ResetFunc() = true on an expired timer")
+	}
+	if !tm.Stop() {
+		t.Errorf("Stop() = false after ResetFunc")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ResetFunc did not panic on a timer from NewTimer")
+		}
+	}()
+	time.NewTimer(Hour).ResetFunc(0, func() {})
+}
+
+func TestTimerPostpone(t *testing.T) {
+	start := time.Now()
+	tm := time.NewTimer(20 * Millisecond)
+	if !tm.Postpone(30 * Millisecond) {
+		t.Errorf("Postpone() = false for an active timer")
+	}
+	at := <-tm.C()
+	if dt := at.Sub(start); dt < 50*Millisecond-windowsInaccuracy {
+		t.Errorf("postponed Timer fired after %v, want at least %v", dt, 50*Millisecond)
+	}
+	if tm.Postpone(Second) || tm.Advance(Second) {
+		t.Errorf("Postpone() or Advance() = true for an expired timer")
+	}
+}
@@ -0,0 +1,193 @@
+package realtime
+
+import (
+	"sync"
+	"time"
+)
+
+// Suspend describes a suspend and resume of the system, as reported to
+// functions passed to OnSuspend.
+type Suspend struct {
+	At    Time     // Time at which the resume was noticed
+	Slept Duration // Approximate time spent suspended
+
+	unseen Duration // Part of Slept not counted by the monotonic clock
+	resume Time     // Earliest time at which the system could have resumed
+}
+
+// A reading of the clocks, compared with the one before it to find suspends.
+type suspendReading struct {
+	wall  Time     // Wall time, without a monotonic reading
+	mono  Duration // Monotonic time since monoOrigin
+	total Duration // Time spent suspended since boot, if exact
+	exact bool     // Whether the platform reports time spent suspended
+}
+
+// The origin from which monotonic times in readings are measured.
+var monoOrigin = time.Now()
+
+func readSuspend() suspendReading {
+	now := time.Now()
+	total, exact := suspendedTotal()
+	return suspendReading{wall: now.Round(0), mono: now.Sub(monoOrigin), total: total, exact: exact}
+}
+
+// How often to check for a suspend, and how long one must last to count.
+const (
+	suspendPoll  = time.Second
+	suspendSlack = time.Second
+)
+
+// Functions notified of suspends, and the goroutine watching for them.
+var suspend struct {
+	subs map[*func(Suspend)]struct{}
+	stop chan struct{} // Closed to stop watching, nil unless running
+
+	mu sync.Mutex
+}
+
+// OnSuspend arranges for f to be called in its own goroutine with a Suspend
+// whenever the system is found to have been suspended and resumed, until
+// stop is called. The system is checked about once a second, and suspends
+// shorter than that are ignored. Where the platform reports time spent
+// suspended, as on Linux, that is used; elsewhere, a check that arrives
+// much later than due, by either the monotonic or the wall clock, is taken
+// to span a suspend, so that a process stopped or starved for as long, or a
+// step forward of the wall clock, may also be reported.
+func (Clock) OnSuspend(f func(Suspend)) (stop func()) {
+	p := &f
+	suspend.mu.Lock()
+	defer suspend.mu.Unlock()
+	if suspend.subs == nil {
+		suspend.subs = make(map[*func(Suspend)]struct{})
+	}
+	suspend.subs[p] = struct{}{}
+	if suspend.stop == nil {
+		suspend.stop = make(chan struct{})
+		go func(stop chan struct{}) {
+			tk := time.NewTicker(suspendPoll)
+			defer tk.Stop()
+			watchSuspend(stop, tk.C, readSuspend, notifySuspend)
+		}(suspend.stop)
+	}
+	return func() {
+		suspend.mu.Lock()
+		defer suspend.mu.Unlock()
+		if _, ok := suspend.subs[p]; !ok {
+			return
+		}
+		delete(suspend.subs, p)
+		if len(suspend.subs) == 0 {
+			close(suspend.stop)
+			suspend.stop = nil
+		}
+	}
+}
+
+// Watch for suspends until stop is closed, taking a reading with read each
+// time poll delivers, and passing each suspend found to notify.
+func watchSuspend(stop <-chan struct{}, poll <-chan Time, read func() suspendReading, notify func(Suspend)) {
+	prev := read()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-poll:
+		}
+		now := read()
+		var slept, unseen Duration
+		if prev.exact && now.exact {
+			slept, unseen = now.total-prev.total, now.total-prev.total
+		} else {
+			// Depending on the platform, the monotonic clock may or may not
+			// stop while suspended, so go by whichever shows the longer gap
+			mono := now.mono - prev.mono
+			wall := now.wall.Sub(prev.wall)
+			slept = mono - suspendPoll
+			if wall > mono {
+				slept, unseen = wall-suspendPoll, wall-mono
+			}
+		}
+		if slept >= suspendSlack {
+			// The suspend began after the previous reading, so the system
+			// resumed no earlier than that plus the time spent suspended
+			notify(Suspend{At: now.wall, Slept: slept, unseen: unseen, resume: prev.wall.Add(slept)})
+		}
+		prev = now
+	}
+}
+
+// Notify each function passed to OnSuspend of s, in its own goroutine.
+func notifySuspend(s Suspend) {
+	suspend.mu.Lock()
+	defer suspend.mu.Unlock()
+	for f := range suspend.subs {
+		go (*f)(s)
+	}
+}
+
+// SetSkipSuspend sets whether t skips the ticks that fell due while the
+// system was suspended, as reported by OnSuspend. On resume, any tick still
+// waiting on the channel from before the suspend is dropped, and ticking
+// continues in phase with the time that has passed, including the time
+// spent suspended, rather than with the monotonic clock, which on some
+// platforms, such as Linux, stops while suspended. Otherwise, as by default,
+// a ticker may deliver a stale tick on resume, and on such platforms, keeps
+// ticking in phase with the time spent awake only. Only a Ticker created by
+// NewForwardingTicker sees its ticks to skip them; on one created by
+// NewTicker, SetSkipSuspend has no effect.
+func (t *Ticker) SetSkipSuspend(enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.c == nil {
+		return
+	}
+	t.skipSuspend = enabled
+	if enabled && !t.stopped {
+		t.watchSuspend()
+	} else {
+		t.unwatchSuspend()
+	}
+}
+
+// watchSuspend subscribes t to suspends, if not already. Callers must hold
+// the lock.
+func (t *Ticker) watchSuspend() {
+	if t.unwatch == nil {
+		t.unwatch = Clock{}.OnSuspend(t.resumed)
+	}
+}
+
+// unwatchSuspend unsubscribes t from suspends, if subscribed. Callers must
+// hold the lock.
+func (t *Ticker) unwatchSuspend() {
+	if t.unwatch != nil {
+		t.unwatch()
+		t.unwatch = nil
+	}
+}
+
+func (t *Ticker) resumed(s Suspend) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.skipSuspend || t.stopped || t.paused {
+		return
+	}
+	// The suspend is noticed only at the next check, by when a fresh tick
+	// may have replaced the stale one, so drop only a tick sent before the
+	// system could have resumed, and put back any other
+	select {
+	case tick := <-t.c:
+		if !tick.Round(0).Before(s.resume) {
+			select {
+			case t.c <- tick:
+			default:
+			}
+		}
+	default:
+	}
+	if s.unseen > 0 {
+		t.start = t.start.Add(-s.unseen)
+		t.restart(t.period - time.Since(t.start)%t.period)
+	}
+}
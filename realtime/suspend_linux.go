@@ -0,0 +1,28 @@
+//go:build linux
+
+package realtime
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// From linux/time.h
+const (
+	clockMonotonic = 1
+	clockBoottime  = 7
+)
+
+// suspendedTotal returns the time spent suspended since boot, as the
+// difference between CLOCK_BOOTTIME, which includes it, and CLOCK_MONOTONIC,
+// which does not.
+func suspendedTotal() (Duration, bool) {
+	var boot, mono syscall.Timespec
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_CLOCK_GETTIME, clockBoottime, uintptr(unsafe.Pointer(&boot)), 0); errno != 0 {
+		return 0, false
+	}
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_CLOCK_GETTIME, clockMonotonic, uintptr(unsafe.Pointer(&mono)), 0); errno != 0 {
+		return 0, false
+	}
+	return Duration(boot.Nano() - mono.Nano()), true
+}
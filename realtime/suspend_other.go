@@ -0,0 +1,5 @@
+//go:build !linux
+
+package realtime
+
+func suspendedTotal() (Duration, bool) { return 0, false }
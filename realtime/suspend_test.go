@@ -0,0 +1,133 @@
+package realtime_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock/realtime"
+)
+
+// The system can't be suspended from a test, so only check that watching
+// stops cleanly. Detection and skipping are tested on made-up readings.
+func TestOnSuspend(t *testing.T) {
+	var c Clock
+	got := make(chan Suspend, 2)
+	stopA := c.OnSuspend(func(s Suspend) { got <- s })
+	stopB := c.OnSuspend(func(s Suspend) { got <- s })
+	stopA()
+	stopA() // Stopping twice is harmless
+	stopB()
+	select {
+	case s := <-got:
+		t.Errorf("received %+v without a suspend", s)
+	default:
+	}
+}
+
+func TestWatchSuspend(t *testing.T) {
+	epoch := Unix(1000, 0)
+	// Readings taken a poll apart, each of which should report a suspend
+	// since the one before, if slept is nonzero
+	type step struct {
+		wall, mono, total Duration
+		exact             bool
+		slept, unseen     Duration
+	}
+	for _, tt := range []struct {
+		name  string
+		steps []step
+	}{
+		{"exact", []step{
+			{0, 0, 5 * Second, true, 0, 0},
+			{SuspendPoll, SuspendPoll, 5 * Second, true, 0, 0},
+			// Short suspends are ignored
+			{2*SuspendPoll + 500*Millisecond, 2 * SuspendPoll, 5*Second + 500*Millisecond, true, 0, 0},
+			{3*SuspendPoll + 3500*Millisecond, 3 * SuspendPoll, 8*Second + 500*Millisecond, true, 3 * Second, 3 * Second},
+		}},
+		{"monotonic stopped", []step{
+			{0, 0, 0, false, 0, 0},
+			{SuspendPoll + 3*Second, SuspendPoll, 0, false, 3 * Second, 3 * Second},
+			{2*SuspendPoll + 3*Second, 2 * SuspendPoll, 0, false, 0, 0},
+		}},
+		{"monotonic running", []step{
+			{0, 0, 0, false, 0, 0},
+			{SuspendPoll + 3*Second, SuspendPoll + 3*Second, 0, false, 3 * Second, 0},
+		}},
+		{"wall stepped back", []step{
+			{0, 0, 0, false, 0, 0},
+			{SuspendPoll - Hour, SuspendPoll, 0, false, 0, 0},
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := tt.steps
+			read := func() SuspendReading {
+				s := steps[0]
+				steps = steps[1:]
+				return NewSuspendReading(epoch.Add(s.wall), s.mono, s.total, s.exact)
+			}
+			var got []Suspend
+			notify := func(s Suspend) { got = append(got, s) }
+			stop, poll, done := make(chan struct{}), make(chan Time), make(chan struct{})
+			go func() {
+				WatchSuspend(stop, poll, read, notify)
+				close(done)
+			}()
+			var want []Suspend
+			for i := 1; i < len(tt.steps); i++ {
+				poll <- Time{}
+				if s := tt.steps[i]; s.slept != 0 {
+					prev := epoch.Add(tt.steps[i-1].wall)
+					want = append(want, NewSuspend(epoch.Add(s.wall), s.slept, s.unseen, prev.Add(s.slept)))
+				}
+			}
+			close(stop)
+			<-done
+
+			if len(got) != len(want) {
+				t.Fatalf("reported %+v, want %+v", got, want)
+			}
+			for i := range got {
+				if !got[i].At.Equal(want[i].At) || got[i].Slept != want[i].Slept || got[i].Unseen() != want[i].Unseen() || !got[i].Resume().Equal(want[i].Resume()) {
+					t.Errorf("reported %+v, want %+v", got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+// A tick sent before the system could have resumed is stale, and dropped,
+// while one sent since, which may have replaced it by the time the suspend
+// is noticed, is kept.
+func TestTickerResumed(t *testing.T) {
+	var c Clock
+	tk := c.NewForwardingTicker(Millisecond)
+	defer tk.Stop()
+	tk.SetSkipSuspend(true)
+	// Leave a tick waiting, sent no earlier than before, with no other to
+	// follow for now
+	waiting := func() (before Time) {
+		before = c.Now()
+		tk.Reset(Millisecond)
+		for len(tk.C()) == 0 {
+			c.Sleep(Millisecond)
+		}
+		tk.Reset(Hour)
+		return before
+	}
+
+	waiting()
+	tk.Resumed(NewSuspend(c.Now(), 3*Second, 0, c.Now().Add(Second)))
+	if len(tk.C()) != 0 {
+		t.Errorf("stale tick not dropped")
+	}
+
+	before := waiting()
+	tk.Resumed(NewSuspend(c.Now(), 3*Second, 0, before))
+	select {
+	case tick := <-tk.C():
+		if tick.Before(before) {
+			t.Errorf("kept tick at %v, sent before %v", tick, before)
+		}
+	default:
+		t.Errorf("fresh tick dropped")
+	}
+}
@@ -167,3 +167,101 @@ func BenchmarkTickerResetNaive(b *testing.B) {
 		ticker.Stop()
 	})
 }
+
+func TestTickerPause(t *testing.T) {
+	src := make(chan Time)
+	ticker := NewTickerFrom(Hour, src)
+	defer ticker.Stop()
+	start := ticker.Start()
+
+	t0 := time.Now()
+	ticker.Pause()
+	src <- start.Add(Hour)
+	src <- start // Stale once resumed, so forwarded only once the first is dropped
+	ticker.Resume()
+	// Still in phase, shifted by the time paused
+	resumed := ticker.Start()
+	if dt := resumed.Sub(start); dt < 0 || dt > time.Since(t0) {
+		t.Errorf("Resume() shifted the phase by %v, want at most %v", dt, time.Since(t0))
+	}
+
+	next := resumed.Add(Hour)
+	src <- next
+	if got := <-ticker.C(); !got.Equal(next) {
+		t.Fatalf("first tick after Resume = %v, want %v", got, next)
+	}
+	// The period is restored from the tick that was forwarded
+	if got := ticker.Start(); !got.Equal(next) {
+		t.Errorf("Start() = %v after the first tick, want %v", got, next)
+	}
+	src <- next.Add(Hour)
+	if got := <-ticker.C(); !got.Equal(next.Add(Hour)) {
+		t.Errorf("second tick after Resume = %v, want %v", got, next.Add(Hour))
+	}
+	if n := ticker.Missed(); n != 0 {
+		t.Errorf("Missed() = %d, want 0", n)
+	}
+}
+
+func TestTickerSetPeriod(t *testing.T) {
+	src := make(chan Time)
+	ticker := NewTickerFrom(Hour, src)
+	defer ticker.Stop()
+	start := ticker.Start()
+
+	// The next tick is still due when it was, and later ones at the new
+	// period, short of the time taken to read the clock
+	t0 := time.Now()
+	ticker.SetPeriod(Hour / 2)
+	if dt := ticker.Start().Sub(start.Add(Hour / 2)); dt < 0 || dt > time.Since(t0) {
+		t.Errorf("SetPeriod() shifted the phase by %v, want at most %v", dt, time.Since(t0))
+	}
+	src <- start.Add(Hour)
+	if got := <-ticker.C(); !got.Equal(start.Add(Hour)) {
+		t.Fatalf("first tick after SetPeriod = %v, want %v", got, start.Add(Hour))
+	}
+	if got := ticker.Start(); !got.Equal(start.Add(Hour)) {
+		t.Errorf("Start() = %v after the first tick, want %v", got, start.Add(Hour))
+	}
+	src <- start.Add(Hour + Hour/2)
+	if got := <-ticker.C(); !got.Equal(start.Add(Hour + Hour/2)) {
+		t.Errorf("second tick after SetPeriod = %v, want %v", got, start.Add(Hour+Hour/2))
+	}
+	if n := ticker.Missed(); n != 0 {
+		t.Errorf("Missed() = %d, want 0", n)
+	}
+}
+
+func TestTickerMissed(t *testing.T) {
+	const delta = Millisecond
+	ticker := time.NewForwardingTicker(delta)
+	defer ticker.Stop()
+
+	// One tick waits on the channel, and the rest are missed
+	time.Sleep(20 * delta)
+	ticker.Stop()
+	<-ticker.C()
+	if n := ticker.Missed(); n < 10 {
+		t.Errorf("Missed() = %d after 20 periods unreceived, want most of them", n)
+	}
+	if n := ticker.Missed(); n != 0 {
+		t.Errorf("Missed() = %d again, want 0", n)
+	}
+}
+
+// A Ticker from NewTicker delivers on the channel of its time.Ticker, with
+// no goroutine of its own to leak should it never be stopped.
+func TestTickerUnforwarded(t *testing.T) {
+	ticker := time.NewTicker(Hour)
+	defer ticker.Stop()
+	if ticker.C() != ticker.Ticker.C {
+		t.Errorf("C() is not the channel of the underlying time.Ticker")
+	}
+	ticker.Pause()
+	ticker.Resume()
+	ticker.SetPeriod(Millisecond)
+	<-ticker.C()
+	if n := ticker.Missed(); n != 0 {
+		t.Errorf("Missed() = %d, want 0", n)
+	}
+}
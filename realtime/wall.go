@@ -0,0 +1,110 @@
+package realtime
+
+import (
+	"sync"
+	"time"
+)
+
+// WallChange describes a step in the wall clock, such as one made by NTP,
+// by hand, or across a suspend and resume of the system, as reported by
+// NotifyWallChange.
+type WallChange struct {
+	At    Time     // Time at which the step was noticed
+	Delta Duration // Wall time passed beyond monotonic time; negative if it stepped back
+}
+
+// How often the wall clock is checked, and how far it may drift from the
+// monotonic clock between checks before that counts as a step.
+const (
+	wallPoll  = time.Second
+	wallSlack = 50 * time.Millisecond
+)
+
+// Channels notified of wall clock steps, and the goroutine watching for them.
+var wall struct {
+	subs map[chan<- WallChange]struct{}
+	stop chan struct{} // Closed to stop watching, nil unless running
+
+	mu sync.Mutex
+}
+
+// NotifyWallChange causes a WallChange to be sent on ch whenever the wall
+// clock is found to have stepped, rather than advancing along with the
+// monotonic clock, so that applications may invalidate caches or re-arm
+// timers set for absolute times. As time measurements use the monotonic
+// clock, Timers and Tickers are unaffected by such steps, but deadlines
+// computed from a wall time are not. The wall clock is checked about once a
+// second, and drift of less than 50ms between checks is ignored. As with
+// [os/signal.Notify], sending does not block, so ch should be buffered.
+func (Clock) NotifyWallChange(ch chan<- WallChange) {
+	wall.mu.Lock()
+	defer wall.mu.Unlock()
+	if wall.subs == nil {
+		wall.subs = make(map[chan<- WallChange]struct{})
+	}
+	wall.subs[ch] = struct{}{}
+	if wall.stop == nil {
+		wall.stop = make(chan struct{})
+		go func(stop chan struct{}) {
+			tk := time.NewTicker(wallPoll)
+			defer tk.Stop()
+			watchWall(stop, tk.C, readWall, notifyWall)
+		}(wall.stop)
+	}
+}
+
+// StopWallChange stops delivery of WallChange values to ch. Once no channels
+// remain, the wall clock is no longer checked.
+func (Clock) StopWallChange(ch chan<- WallChange) {
+	wall.mu.Lock()
+	defer wall.mu.Unlock()
+	delete(wall.subs, ch)
+	if len(wall.subs) == 0 && wall.stop != nil {
+		close(wall.stop)
+		wall.stop = nil
+	}
+}
+
+// A reading of the wall and monotonic clocks, compared with the one before
+// it to find steps.
+type wallReading struct {
+	wall Time     // Wall time, without a monotonic reading
+	mono Duration // Monotonic time since monoOrigin
+}
+
+func readWall() wallReading {
+	now := time.Now()
+	return wallReading{wall: now.Round(0), mono: now.Sub(monoOrigin)}
+}
+
+// Watch for steps until stop is closed, taking a reading with read each time
+// poll delivers, and passing each step found to notify.
+func watchWall(stop <-chan struct{}, poll <-chan Time, read func() wallReading, notify func(WallChange)) {
+	prev := read()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-poll:
+		}
+		now := read()
+		delta := now.wall.Sub(prev.wall) - (now.mono - prev.mono)
+		prev = now
+		if delta > -wallSlack && delta < wallSlack {
+			continue
+		}
+		notify(WallChange{At: now.wall, Delta: delta})
+	}
+}
+
+// Send c on each channel passed to NotifyWallChange, without blocking.
+func notifyWall(c WallChange) {
+	wall.mu.Lock()
+	defer wall.mu.Unlock()
+	for ch := range wall.subs {
+		select {
+		case ch <- c:
+		default:
+		}
+	}
+}
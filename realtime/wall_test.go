@@ -0,0 +1,74 @@
+package realtime_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock/realtime"
+)
+
+// The wall clock can't be stepped from a test, so only check that watching
+// stops cleanly. Detection is tested on made-up readings.
+func TestNotifyWallChange(t *testing.T) {
+	var c Clock
+	a, b := make(chan WallChange, 1), make(chan WallChange, 1)
+	c.NotifyWallChange(a)
+	c.NotifyWallChange(b)
+	c.StopWallChange(a)
+	c.StopWallChange(b)
+	c.StopWallChange(b) // Stopping twice is harmless
+	select {
+	case wc := <-a:
+		t.Errorf("stopped channel received %+v", wc)
+	case wc := <-b:
+		t.Errorf("received %+v without a step", wc)
+	default:
+	}
+}
+
+func TestWatchWall(t *testing.T) {
+	epoch := Unix(1000, 0)
+	// Readings taken a second apart, and the steps they should report since
+	// the reading before
+	readings := []struct{ wall, mono, delta Duration }{
+		{0, 0, 0},
+		{Second, Second, 0},
+		// Drift within the slack is ignored
+		{2*Second + 40*Millisecond, 2 * Second, 0},
+		{3*Second + 10*Millisecond, 3 * Second, 0},
+		{4*Second + 10*Millisecond + Hour, 4 * Second, Hour},
+		{5*Second - 2*Minute, 5 * Second, -(Hour + 2*Minute + 10*Millisecond)},
+		// As across a suspend, where the monotonic clock stops
+		{6*Second + 3*Minute, 6 * Second, 5 * Minute},
+	}
+	rest := readings
+	read := func() WallReading {
+		r := rest[0]
+		rest = rest[1:]
+		return NewWallReading(epoch.Add(r.wall), r.mono)
+	}
+	var got []WallChange
+	notify := func(c WallChange) { got = append(got, c) }
+	stop, poll, done := make(chan struct{}), make(chan Time), make(chan struct{})
+	go func() {
+		WatchWall(stop, poll, read, notify)
+		close(done)
+	}()
+	var want []WallChange
+	for _, r := range readings[1:] {
+		poll <- Time{}
+		if r.delta != 0 {
+			want = append(want, WallChange{At: epoch.Add(r.wall), Delta: r.delta})
+		}
+	}
+	close(stop)
+	<-done
+
+	if len(got) != len(want) {
+		t.Fatalf("reported %+v, want %+v", got, want)
+	}
+	for i := range got {
+		if !got[i].At.Equal(want[i].At) || got[i].Delta != want[i].Delta {
+			t.Errorf("reported %+v, want %+v", got[i], want[i])
+		}
+	}
+}
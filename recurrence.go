@@ -0,0 +1,42 @@
+package clock
+
+import (
+	"github.com/noodlebox/clock/internal/sched"
+)
+
+// Schedule is a generic interface for a recurrence, such as one given by a
+// cron expression or an RRULE. Next returns the first time after its
+// argument at which the recurrence occurs, and false if there are no more.
+// Each implementation in this module accepts any Schedule for its Time type
+// in its ScheduleFunc method, as does the ScheduleFunc helper for any Clock.
+type Schedule[T any] interface {
+	Next(after T) (T, bool)
+}
+
+// Scheduled is a recurring call started by ScheduleFunc.
+type Scheduled[T Time[T, D], D Duration] struct {
+	c *sched.Chain[T, D, Timer[T, D]]
+}
+
+// ScheduleFunc calls f in its own goroutine at each time given by s after
+// the current time on c, until s has no more or the returned Scheduled is
+// stopped. Each call is passed the time at which it was due. Calls are made
+// one at a time, in order; one that falls behind, as when a simulated clock
+// is stepped past several occurrences at once, is made as soon as possible,
+// so that none are skipped. A Schedule whose Next does not return a time
+// after its argument ends there.
+func ScheduleFunc[T Time[T, D], D Duration](c Clock[T, D], s Schedule[T], f func(T)) *Scheduled[T, D] {
+	return &Scheduled[T, D]{sched.Start[T, D](c.Now, c.AfterFunc, s, f)}
+}
+
+// Next returns the time of the next call, and false if there are none.
+func (s *Scheduled[T, D]) Next() (T, bool) {
+	return s.c.Next()
+}
+
+// Stop prevents any further calls. It returns true if the call stops them,
+// false if they had already ended or been stopped. It does not wait for a
+// call in progress to return.
+func (s *Scheduled[T, D]) Stop() bool {
+	return s.c.Stop()
+}
@@ -0,0 +1,44 @@
+package clock_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+// every occurs at each multiple of a period, up to a limit.
+type every struct {
+	period, limit steppedtime.Duration
+}
+
+func (e every) Next(after steppedtime.Time) (steppedtime.Time, bool) {
+	next := after - after%steppedtime.Time(e.period) + steppedtime.Time(e.period)
+	return next, next <= steppedtime.Time(e.limit)
+}
+
+func TestScheduleFunc(t *testing.T) {
+	s := steppedtime.NewClock()
+	ch := make(chan steppedtime.Time, 8)
+	sc := ScheduleFunc(FromSteppedtime(s), Schedule[steppedtime.Time](every{steppedtime.Second, 4 * steppedtime.Second}), func(at steppedtime.Time) { ch <- at })
+
+	if next, ok := sc.Next(); !ok || next != steppedtime.Time(steppedtime.Second) {
+		t.Errorf("Next() = %v, %v, want %v, true", next, ok, steppedtime.Time(steppedtime.Second))
+	}
+
+	// Stepping past several occurrences calls f for each, in order.
+	s.Step(3*steppedtime.Second + steppedtime.Second/2)
+	for i := 1; i <= 3; i++ {
+		if got, want := <-ch, steppedtime.Time(i)*steppedtime.Time(steppedtime.Second); got != want {
+			t.Errorf("call %d passed %v, want %v", i, got, want)
+		}
+	}
+
+	if !sc.Stop() {
+		t.Errorf("Stop() = false on a pending schedule")
+	}
+	s.Step(steppedtime.Second)
+	if len(ch) != 0 {
+		t.Errorf("f called after Stop")
+	}
+}
@@ -0,0 +1,47 @@
+package clock
+
+import (
+	"github.com/noodlebox/clock/relativetime"
+)
+
+// A reference clock for relativetime, backed by a Clock.
+type reference[T Time[T, D], D Duration] struct {
+	c       Clock[T, D]
+	seconds func(float64) D
+}
+
+func (r reference[T, D]) Now() T {
+	return r.c.Now()
+}
+
+func (r reference[T, D]) Seconds(n float64) D {
+	return r.seconds(n)
+}
+
+func (r reference[T, D]) AfterFunc(d D, f func()) Timer[T, D] {
+	return r.c.AfterFunc(d, f)
+}
+
+// AsReference adapts c to serve as a reference clock for relativetime, so
+// that a relative clock may be layered over any Clock, including one from a
+// third party. A reference clock must also construct durations from a count
+// of seconds, for which seconds is used. If seconds is nil, c must provide
+// a Seconds method itself, as do the Clocks returned by Adapt and the
+// helpers built on it, such as FromRealtime; otherwise, AsReference panics.
+func AsReference[T Time[T, D], D Duration](c Clock[T, D], seconds func(float64) D) relativetime.RClock[T, D, Timer[T, D]] {
+	if seconds == nil {
+		df, ok := c.(interface{ Seconds(float64) D })
+		if !ok {
+			panic("clock: AsReference needs a Seconds method or function")
+		}
+		seconds = df.Seconds
+	}
+	return reference[T, D]{c, seconds}
+}
+
+// NewRelative returns a new relativetime.Clock set to at, tracking c with a
+// scale factor of scale. The seconds argument is as for AsReference. Use
+// FromRelativetime to adapt the result to a Clock in turn.
+func NewRelative[T Time[T, D], D Duration](c Clock[T, D], seconds func(float64) D, at T, scale float64) *relativetime.Clock[T, D, Timer[T, D]] {
+	return relativetime.NewClock[T, D, Timer[T, D]](AsReference(c, seconds), at, scale)
+}
@@ -0,0 +1,45 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+func TestNewRelative(t *testing.T) {
+	s := steppedtime.NewClock()
+	rc := NewRelative(FromSteppedtime(s), nil, 0, 2.0)
+	rc.Start()
+	c := FromRelativetime(rc)
+
+	tm := c.NewTimer(10 * time.Second)
+	s.Step(4 * time.Second)
+	if got, want := c.Now(), steppedtime.Time(8*time.Second); got != want {
+		t.Errorf("Now() = %v after stepping the reference, want %v", got, want)
+	}
+	s.Step(time.Second)
+	select {
+	case now := <-tm.C():
+		if want := steppedtime.Time(10 * time.Second); now != want {
+			t.Errorf("Timer fired at %v, want %v", now, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Timer did not fire once the reference was stepped")
+	}
+}
+
+func TestAsReferenceSeconds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("AsReference did not panic without a way to make durations")
+		}
+	}()
+	AsReference[steppedtime.Time, time.Duration](noSeconds{FromSteppedtime(steppedtime.NewClock())}, nil)
+}
+
+// Hides the Seconds method of an adapted Clock.
+type noSeconds struct {
+	Clock[steppedtime.Time, time.Duration]
+}
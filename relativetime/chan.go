@@ -0,0 +1,110 @@
+package relativetime
+
+import (
+	"sync"
+)
+
+// RChanTimer is a generic interface for a reference Timer that also exposes
+// a channel on which it delivers the time, such as [time.Timer] through an
+// accessor, or the Timers of the other clocks in this module.
+type RChanTimer[T any, D Duration] interface {
+	RTimer[D]
+	C() <-chan T
+}
+
+// RChanClock is a generic interface for a reference clock whose only timers
+// deliver on channels, lacking the AfterFunc needed by RClock. Use
+// FromChanTimers to adapt it.
+type RChanClock[T Time[T, D], D Duration, TM RChanTimer[T, D]] interface {
+	Now() T
+	Seconds(float64) D
+	NewTimer(D) TM
+}
+
+// ChanRef adapts an RChanClock to serve as the reference for a Clock, waking
+// it by a goroutine selecting on the channel of each reference timer, rather
+// than by a callback. It must be created with FromChanTimers.
+type ChanRef[T Time[T, D], D Duration, TM RChanTimer[T, D]] struct {
+	ref RChanClock[T, D, TM]
+}
+
+// FromChanTimers returns a ChanRef adapting ref, so that a Clock may track
+// it with NewClock[T, D, *ChanWaker[T, D, TM]].
+func FromChanTimers[T Time[T, D], D Duration, TM RChanTimer[T, D]](ref RChanClock[T, D, TM]) ChanRef[T, D, TM] {
+	return ChanRef[T, D, TM]{ref}
+}
+
+// Now returns the current time on the reference clock.
+func (r ChanRef[T, D, TM]) Now() T {
+	return r.ref.Now()
+}
+
+// Seconds returns a Duration value representing n seconds.
+func (r ChanRef[T, D, TM]) Seconds(n float64) D {
+	return r.ref.Seconds(n)
+}
+
+// AfterFunc creates a timer on the reference clock and calls f each time a
+// value is received from its channel, until it is stopped.
+func (r ChanRef[T, D, TM]) AfterFunc(d D, f func()) *ChanWaker[T, D, TM] {
+	w := &ChanWaker[T, D, TM]{tm: r.ref.NewTimer(d), f: f}
+	w.mu.Lock()
+	w.listen()
+	w.mu.Unlock()
+	return w
+}
+
+// ChanWaker is a timer created by ChanRef.AfterFunc. While armed, a
+// goroutine waits on the channel of the reference timer to call its
+// function; it exits once the timer is stopped.
+type ChanWaker[T Time[T, D], D Duration, TM RChanTimer[T, D]] struct {
+	tm   TM
+	f    func()
+	quit chan struct{} // Closed to stop listening, nil unless listening
+
+	mu sync.Mutex
+}
+
+// Start a goroutine waiting on the timer's channel. Callers must hold the
+// lock.
+func (w *ChanWaker[T, D, TM]) listen() {
+	if w.quit != nil {
+		return
+	}
+	quit := make(chan struct{})
+	w.quit = quit
+	go func() {
+		for {
+			select {
+			case <-w.tm.C():
+				w.f()
+			case <-quit:
+				return
+			}
+		}
+	}()
+}
+
+// Reset changes the timer to expire after duration d. It returns true if
+// the timer had been active. A value left on the channel from before may
+// still wake the function early, once.
+func (w *ChanWaker[T, D, TM]) Reset(d D) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	active := w.tm.Reset(d)
+	w.listen()
+	return active
+}
+
+// Stop prevents the timer from firing, and ends the goroutine waiting on
+// it. It returns true if the call stops the timer.
+func (w *ChanWaker[T, D, TM]) Stop() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	active := w.tm.Stop()
+	if w.quit != nil {
+		close(w.quit)
+		w.quit = nil
+	}
+	return active
+}
@@ -1,9 +1,21 @@
 package relativetime
 
 import (
+	"errors"
+	"math"
+	"runtime"
 	"sync"
+	"sync/atomic"
+
+	"github.com/noodlebox/clock/internal/runner"
+	"github.com/noodlebox/clock/internal/tickbuf"
+	"github.com/noodlebox/clock/internal/weakchan"
 )
 
+// ErrClosed is returned by Close when the Clock was already closed, and by
+// the Try variants, such as TryNewTimer, once it is.
+var ErrClosed = errors.New("relativetime: clock closed")
+
 // RClock is a generic interface for the minimal API needed to serve as a
 // reference clock.
 type RClock[T Time[T, D], D Duration, TM RTimer[D]] interface {
@@ -36,8 +48,6 @@ type Duration interface {
 	Seconds() float64
 }
 
-const nwakers = 4
-
 // Clock is a clock that tracks a reference clock with a configurable scaling
 // factor.
 //
@@ -47,52 +57,152 @@ const nwakers = 4
 // example of using embedding with instantiated generic types for a drop in
 // replacement for a reference clock.
 type Clock[T Time[T, D], D Duration, RT RTimer[D]] struct {
-	waker  chan *clock[T, D, RT]
-	wakers [nwakers]*clock[T, D, RT]
+	wakers []*clock[T, D, RT] // Shards, each with its own queue and waker
 	keeper *clock[T, D, RT]
+	next   atomic.Uint32                  // Shard from which to begin the next search
+	snap   atomic.Pointer[snapshot[T, D]] // Keeper's settings, read without locks
+
+	uptime uptime[T, D] // Protected by the keeper's lock, and published
+
+	onWake   atomic.Pointer[func()]
+	collect  atomic.Bool   // Whether unreferenced Timers and Tickers are stopped
+	unbuffer atomic.Bool   // Whether timer channels are unbuffered
+	run      runner.Runner // Runs functions passed to AfterFunc or TickFunc
+
+	receivers sync.Map // Of channels to receivers set up by receive
+	sending   sync.Map // Of channels with values on their way by goroutine, to a channel closed once done
 
-	mu sync.Mutex // Protects collecting all wakers
+	closed  atomic.Bool
+	done    chan struct{} // Closed by Close
+	seq     atomic.Uint64 // TickID of the last firing, shared by all wakers
+	created atomic.Uint64 // Sequence number of the last timer first scheduled
+
+	latency   atomic.Pointer[latency]
+	recording atomic.Bool // Whether latency is being recorded
+
+	resume atomic.Pointer[resumePolicy]
+	gap    resumeGap[T] // Last gap absorbed, protected by the keeper's lock
 }
 
 // NewClock returns a new Clock set to at synchronized to the current time on
 // ref with a scale factor of scale.
 func NewClock[T Time[T, D], D Duration, RT RTimer[D]](ref RClock[T, D, RT], at T, scale float64) (c *Clock[T, D, RT]) {
 	rNow := ref.Now()
+	point := syncPoint[T, D]{active: false, scale: scale, now: at, rNow: rNow}
 	c = &Clock[T, D, RT]{
-		waker: make(chan *clock[T, D, RT], nwakers),
+		wakers: make([]*clock[T, D, RT], runtime.GOMAXPROCS(0)),
 		keeper: &clock[T, D, RT]{
-			ref:    ref,
-			active: false,
-			scale:  scale,
-			now:    at,
-			rNow:   rNow,
+			ref:       ref,
+			syncPoint: point,
 		},
+		uptime: uptime[T, D]{origin: at, since: rNow, marks: [2]T{rNow, rNow}},
+		done:   make(chan struct{}),
 	}
 	for i, _ := range c.wakers {
 		w := &clock[T, D, RT]{
-			ref:    ref,
-			active: false,
-			scale:  scale,
-			now:    at,
-			rNow:   rNow,
-			waking: make(chan struct{}, 1),
+			parent:    c,
+			ref:       ref,
+			syncPoint: point,
+			waking:    make(chan struct{}, 1),
 		}
-		c.waker <- w
 		c.wakers[i] = w
 	}
+	c.keeper.Lock()
+	c.publish()
+	c.keeper.Unlock()
 	return
 }
 
-type clock[T Time[T, D], D Duration, RT RTimer[D]] struct {
-	ref       RClock[T, D, RT]
+// Accounting of time spent on the reference clock while active or stopped.
+// Durations can't be summed generically, so each total is instead measured
+// from a mark, which is shifted forward by any time spent in the other
+// state. A Clock is stopped when created.
+type uptime[T Time[T, D], D Duration] struct {
+	origin T    // Local time at creation
+	since  T    // Reference time of the last change in state
+	marks  [2]T // Marks for the totals while stopped and active, respectively
+}
+
+func stateIndex(active bool) int {
+	if active {
+		return 1
+	}
+	return 0
+}
+
+// Enter the state given by active at rNow, from the other state.
+func (u *uptime[T, D]) enter(active bool, rNow T) {
+	i := stateIndex(active)
+	u.marks[i] = u.marks[i].Add(rNow.Sub(u.since))
+	u.since = rNow
+}
+
+// Return the total time spent in the state given by active as of rNow,
+// where current is the state at rNow.
+func (u *uptime[T, D]) total(active, current bool, rNow T) D {
+	i := stateIndex(active)
+	if active == current {
+		return rNow.Sub(u.marks[i])
+	}
+	return u.since.Sub(u.marks[i])
+}
+
+// The settings for tracking the reference clock, along with the last point
+// at which they were synced with it.
+type syncPoint[T Time[T, D], D Duration] struct {
 	scale     float64
 	active    bool
-	now, rNow T // last sync point
+	now, rNow T       // last sync point
+	carry     float64 // Seconds lost to rounding when now was last scaled
+}
 
-	queue  queue[T, D] // Upcoming events, in local time
-	waker  RTimer[D]   // Interface used here for a default value of nil
-	wakeAt T           // Local time of next scheduled waking
-	waking chan struct{}
+// Whether local time is changing.
+func (p *syncPoint[T, D]) moving() bool {
+	return p.active && p.scale != 0.0
+}
+
+// Given a reference time, extrapolate to the local time. Times before the
+// last sync point (p.rNow) are not guaranteed to be extrapolated correctly.
+func (p *syncPoint[T, D]) toLocal(ref interface{ Seconds(float64) D }, when T) T {
+	now, _ := p.extrapolate(ref, when)
+	return now
+}
+
+// Like toLocal, but also return the rounding error carried forward, so that
+// it may be restored by the next extrapolation rather than accumulating
+// over a long chain of sync points.
+func (p *syncPoint[T, D]) extrapolate(ref interface{ Seconds(float64) D }, when T) (T, float64) {
+	then := p.rNow
+
+	// No local change if stopped, scale is zero, or ref clock hasn't changed
+	if !p.moving() || when.Equal(then) {
+		return p.now, p.carry
+	}
+	dt := when.Sub(then)
+	if p.scale == 1.0 {
+		return p.now.Add(dt), p.carry
+	}
+	// Apply scale via conversion to float64 in seconds
+	exact := dt.Seconds()*p.scale + p.carry
+	dt = ref.Seconds(exact)
+	// We're at now now.
+	return p.now.Add(dt), exact - dt.Seconds()
+}
+
+type clock[T Time[T, D], D Duration, RT RTimer[D]] struct {
+	parent *Clock[T, D, RT]
+	ref    RClock[T, D, RT]
+	syncPoint[T, D]
+
+	queue   queue[T, D] // Upcoming events, in local time
+	waker   RTimer[D]   // Interface used here for a default value of nil
+	wakeAt  T           // Local time of next scheduled waking
+	wakeRef T           // Reference time of next scheduled waking
+	minWake float64     // Shortest duration to arm the waker with, in seconds
+	waking  chan struct{}
+	once    bool // Whether exact tickers fire at most once, after a gap
+
+	held map[*timer[T, D]]struct{} // Pending, but not in the queue
 
 	sync.RWMutex
 
@@ -106,7 +216,7 @@ type clock[T Time[T, D], D Duration, RT RTimer[D]] struct {
 // are not stale before any change to one of these fields.
 // Callers must hold a write lock.
 func (c *clock[T, D, RT]) advanceRef(rNow T) {
-	c.now = c.toLocal(rNow)
+	c.now, c.carry = c.extrapolate(c.ref, rNow)
 	c.rNow = rNow
 }
 
@@ -115,23 +225,9 @@ func (c *clock[T, D, RT]) sync() T {
 	return c.now
 }
 
-// Given a reference time, extrapolate to the local time. Times before the
-// last sync point (c.rNow) are not guaranteed to be extrapolated correctly.
 // Callers must hold at least a read lock.
 func (c *clock[T, D, RT]) toLocal(when T) T {
-	then := c.rNow
-
-	// No local change if stopped, scale is zero, or ref clock hasn't changed
-	if !c.active || c.scale == 0.0 || when.Equal(then) {
-		return c.now
-	}
-	dt := when.Sub(then)
-	if c.scale != 1.0 {
-		// Apply scale via conversion to float64 in seconds
-		dt = c.ref.Seconds(dt.Seconds() * c.scale)
-	}
-	// We're at now now.
-	return c.now.Add(dt)
+	return c.syncPoint.toLocal(c.ref, when)
 }
 
 func (c *clock[T, D, RT]) stopWaker() {
@@ -140,7 +236,7 @@ func (c *clock[T, D, RT]) stopWaker() {
 	}
 	c.waker.Stop()
 	var zero T
-	c.wakeAt = zero
+	c.wakeAt, c.wakeRef = zero, zero
 }
 
 func (c *clock[T, D, RT]) resetWaker() {
@@ -170,8 +266,20 @@ func (c *clock[T, D, RT]) resetWaker() {
 
 	c.wakeAt = next.when
 
-	// Duration on reference clock until next timer should trigger
-	dt := c.ref.Seconds(next.when.Sub(c.now).Seconds() / c.scale)
+	// Duration on reference clock until next timer should trigger, less any
+	// remainder already carried toward it
+	seconds := (next.when.Sub(c.now).Seconds() - c.carry) / c.scale
+	if seconds < c.minWake {
+		seconds = c.minWake
+	}
+	dt := c.ref.Seconds(seconds)
+	for dt.Seconds() <= 0 && seconds > 0 {
+		// Too short to represent, so wait for the reference to advance by
+		// at least its smallest step, rather than waking again at once
+		seconds *= 2
+		dt = c.ref.Seconds(seconds)
+	}
+	c.wakeRef = c.rNow.Add(dt)
 
 	if c.waker == nil {
 		c.waker = c.ref.AfterFunc(dt, c.wake)
@@ -180,20 +288,93 @@ func (c *clock[T, D, RT]) resetWaker() {
 	}
 }
 
-// Check schedule for pending events that should trigger now.
+// Check schedule for pending events that should trigger now. Timer functions
+// are called while holding the lock, so they must not call back into the
+// Clock; any function supplied by a user is instead started in a new
+// goroutine, which may do so freely.
 func (c *clock[T, D, RT]) checkSchedule() {
 	for t := c.queue.peek(); t != nil && !t.when.After(c.now); t = c.queue.peek() {
-		if t.period.Seconds() <= 0 {
-			c.unschedule(t)
-		} else {
-			t.when = c.now.Add(t.period)
-			c.reschedule(t)
+		c.fire(t)
+	}
+}
+
+// Trigger the timers due on every shard, as checkSchedule does for one, but
+// in a single pass across all of them, in the order they were due, so that
+// their TickIDs follow that order even when they are spread across shards.
+// Callers must hold write locks on every shard.
+func (c *Clock[T, D, RT]) checkAll() {
+	for {
+		var first *clock[T, D, RT]
+		var next *timer[T, D]
+		for _, w := range c.wakers {
+			t := w.queue.peek()
+			if t != nil && !t.when.After(w.now) && (next == nil || t.when.Before(next.when)) {
+				first, next = w, t
+			}
+		}
+		if first == nil {
+			return
+		}
+		first.fire(next)
+	}
+}
+
+// Trigger t, which must be first in the queue and due, then unschedule or
+// reschedule it. Callers must hold a write lock.
+func (c *clock[T, D, RT]) fire(t *timer[T, D]) {
+	t.id = TickID(c.parent.seq.Add(1))
+	if c.parent.recording.Load() {
+		c.parent.latency.Load().record(c.now.Sub(t.when).Seconds())
+	}
+	switch {
+	case t.period.Seconds() <= 0:
+		c.unschedule(t)
+	case t.exact && !c.once:
+		if due := skipped(t.when, c.now, t.period) + 1; t.burst > 0 && due > t.burst {
+			// Skip all but the last ticks that fit in its buffer,
+			// rather than fire once per period only to drop them
+			n := due - t.burst
+			t.missed += n
+			t.when = t.when.Add(c.ref.Seconds(t.period.Seconds() * float64(n)))
 		}
-		t.f(c.now)
+		when := t.when
+		t.when = when.Add(t.period)
+		c.reschedule(t)
+		t.f(when)
+		return
+	default:
+		t.missed += skipped(t.when, c.now, t.period)
+		t.when = c.now.Add(t.period)
+		c.reschedule(t)
+	}
+	t.f(c.now)
+}
+
+// Trigger t at once if it is already due, as when scheduled with a duration
+// <= 0, rather than waiting on the waker, which won't run while stopped.
+// Callers must hold a write lock, having synced.
+func (c *clock[T, D, RT]) fireIfDue(t *timer[T, D]) {
+	if t.index >= 0 && !t.when.After(c.now) {
+		c.checkSchedule()
 	}
 }
 
+// skipped returns the number of whole periods between when and now.
+func skipped[T Time[T, D], D Duration](when, now T, period D) int {
+	return int(now.Sub(when).Seconds() / period.Seconds())
+}
+
+// Add t to the queue, unless the Clock is closed. Callers must hold a write
+// lock.
 func (c *clock[T, D, RT]) schedule(t *timer[T, D]) {
+	if c.parent.closed.Load() {
+		t.index = -1
+		t.dropped = true
+		return
+	}
+	if t.seq == 0 {
+		t.seq = c.parent.created.Add(1)
+	}
 	c.queue.insert(t)
 }
 
@@ -206,7 +387,7 @@ func (c *clock[T, D, RT]) unschedule(t *timer[T, D]) {
 
 func (c *clock[T, D, RT]) reschedule(t *timer[T, D]) {
 	if t.index < 0 {
-		c.queue.insert(t)
+		c.schedule(t)
 		return
 	}
 	c.queue.fix(t)
@@ -221,49 +402,133 @@ func (c *clock[T, D, RT]) wake() {
 	}
 	c.Lock()
 	<-c.waking
-	c.sync()
+	// The waker is spent, so must be armed again even for the same time,
+	// should rounding leave the next timer not quite due
+	wakeRef := c.wakeRef
+	var zero T
+	c.wakeAt = zero
+	rNow := c.ref.Now()
+	c.advanceRef(rNow)
+	switch c.parent.resumeFor(wakeRef, rNow) {
+	case ResumePause:
+		c.Unlock()
+		c.parent.absorb(wakeRef, rNow)
+		return
+	case ResumeOnce:
+		c.once = true
+	}
+	if f := c.parent.onWake.Load(); f != nil {
+		if next := c.queue.peek(); next != nil && !next.when.After(c.now) {
+			(*f)()
+		}
+	}
 	c.checkSchedule()
+	c.once = false
 	c.resetWaker()
 	c.Unlock()
 }
 
-// Call f (with read access) on a clock.
-//	w := <-c.waker
-//	w.RLock()
-//	c.waker <- w
-//	f(w)
-//	w.RUnlock()
-
-// Call f (with write access) on a clock.
-//	w := <-c.waker
-//	w.Lock()
+// Acquire a write lock on one of the shards, for scheduling a new timer.
+// The shards stripe the timer queues across as many locks as there were Ps
+// at construction, so that goroutines creating timers concurrently are
+// unlikely to contend for the same one. This is lock striping only: shards
+// have no affinity to Ps, and a timer stays on the shard it was first
+// scheduled on, with no stealing between them. Starting from a rotating
+// position, the first shard not currently locked is taken. Only if every
+// shard is busy does the caller wait for one.
+//
+// The striping is paid for by changes that must reach every timer at once,
+// such as Step, Set, Start, Stop, and SetScale, which take every lock in
+// turn (see lockAll), and by each shard with a timer pending arming a waker
+// of its own on the reference clock. As scheduling timers is far more
+// common than such changes, the trade is worth making; BenchmarkShards
+// measures both sides of it.
+//
+//	w := c.acquire()
 //	f(w)
 //	w.Unlock()
-//	c.waker <- w
-
-// Call f (with write access) on all clocks. This method blocks at least
-// until locks have been acquired on each clock, with each clock unlocking
-// when finished. This ensures that any following calls will get a synced
-// clock. Other threads may race to acquire read locks on clocks, but once
-// this thread has acquired a lock, further calls will block until a clock
-// has finished.
+func (c *Clock[T, D, RT]) acquire() *clock[T, D, RT] {
+	n := uint32(len(c.wakers))
+	i := c.next.Add(1)
+	for j := uint32(0); j < n; j++ {
+		if w := c.wakers[(i+j)%n]; w.TryLock() {
+			return w
+		}
+	}
+	w := c.wakers[i%n]
+	w.Lock()
+	return w
+}
+
+// Call f (with write access) on every shard, then on the keeper, while
+// holding all of their locks at once, so that the change reaches every timer
+// at the same instant, and any following calls get a synced clock. Locks are
+// taken in a fixed order, so that concurrent calls can't deadlock, and f
+// runs on each in turn, as it is rarely costly enough to be worth a
+// goroutine per shard.
 func (c *Clock[T, D, RT]) sync(f func(*clock[T, D, RT])) {
-	c.mu.Lock()
-	var wg sync.WaitGroup
-	wg.Add(len(c.wakers))
+	c.lockAll()
 	for _, w := range c.wakers {
-		go func(w *clock[T, D, RT]) {
-			w.Lock()
-			wg.Done()
-			f(w)
-			w.Unlock()
-		}(w)
+		f(w)
 	}
-	c.keeper.Lock()
 	f(c.keeper)
+	c.publish()
+	c.unlockAll()
+}
+
+// Like sync, but once f has been called on every shard, trigger any timers
+// due, in order across all of them, and reset the wakers.
+func (c *Clock[T, D, RT]) syncFire(f func(*clock[T, D, RT])) {
+	c.lockAll()
+	c.syncFireLocked(f)
+	c.unlockAll()
+}
+
+// Like syncFire, for callers already holding every lock.
+func (c *Clock[T, D, RT]) syncFireLocked(f func(*clock[T, D, RT])) {
+	for _, w := range c.wakers {
+		f(w)
+	}
+	f(c.keeper)
+	c.publish()
+	c.checkAll()
+	for _, w := range c.wakers {
+		w.resetWaker()
+	}
+}
+
+// Take every lock, each shard's and then the keeper's, at a cost growing
+// with the number of shards.
+func (c *Clock[T, D, RT]) lockAll() {
+	for _, w := range c.wakers {
+		w.Lock()
+	}
+	c.keeper.Lock()
+}
+
+func (c *Clock[T, D, RT]) unlockAll() {
 	c.keeper.Unlock()
-	wg.Wait()
-	c.mu.Unlock()
+	for _, w := range c.wakers {
+		w.Unlock()
+	}
+}
+
+// An immutable copy of the keeper's settings and sync point, along with the
+// uptime accounting, as last published.
+type snapshot[T Time[T, D], D Duration] struct {
+	syncPoint[T, D]
+	uptime uptime[T, D]
+}
+
+// Publish a snapshot of the keeper's settings, sync point, and uptime, so
+// that Now, Scale, Active, and Elapsed and its variants may read them
+// without taking any locks, and so never wait on scheduling activity, on
+// one another, or on a change such as Start, Stop, or SetScale, which must
+// lock every shard. This should be called after any change to the keeper's
+// settings or to uptime. Callers must hold a write lock on the keeper.
+func (c *Clock[T, D, RT]) publish() {
+	s := snapshot[T, D]{c.keeper.syncPoint, c.uptime}
+	c.snap.Store(&s)
 }
 
 // Start begins tracking the reference clock, if not already running. It is
@@ -273,6 +538,9 @@ func (c *Clock[T, D, RT]) Start() {
 	c.sync(func(w *clock[T, D, RT]) {
 		// Sync up before changing setting
 		w.advanceRef(rNow)
+		if w == c.keeper && !w.active {
+			c.uptime.enter(true, rNow)
+		}
 		w.active = true
 
 		w.resetWaker()
@@ -286,6 +554,9 @@ func (c *Clock[T, D, RT]) Stop() {
 	c.sync(func(w *clock[T, D, RT]) {
 		// Sync up before changing setting
 		w.advanceRef(rNow)
+		if w == c.keeper && w.active {
+			c.uptime.enter(false, rNow)
+		}
 		w.active = false
 
 		w.resetWaker()
@@ -293,11 +564,31 @@ func (c *Clock[T, D, RT]) Stop() {
 }
 
 // Active returns true if currently tracking the reference clock.
-func (c *Clock[T, D, RT]) Active() (active bool) {
-	c.keeper.RLock()
-	active = c.keeper.active
-	c.keeper.RUnlock()
-	return
+func (c *Clock[T, D, RT]) Active() bool {
+	return c.snap.Load().active
+}
+
+// Elapsed returns the local time elapsed since the Clock was created, as
+// told by Now, so including any change made by Set or Step, and scaled as
+// set by SetScale. The time elapsed on the reference clock is the sum of
+// ElapsedActive and ElapsedStopped.
+func (c *Clock[T, D, RT]) Elapsed() D {
+	return c.Now().Sub(c.snap.Load().uptime.origin)
+}
+
+// ElapsedActive returns the time elapsed on the reference clock since the
+// Clock was created, excluding any intervals during which it was stopped.
+// It is unaffected by calls to Set, Step, or SetScale.
+func (c *Clock[T, D, RT]) ElapsedActive() D {
+	s := c.snap.Load()
+	return s.uptime.total(true, s.active, c.keeper.ref.Now())
+}
+
+// ElapsedStopped returns the total time elapsed on the reference clock while
+// the Clock was stopped, since it was created.
+func (c *Clock[T, D, RT]) ElapsedStopped() D {
+	s := c.snap.Load()
+	return s.uptime.total(false, s.active, c.keeper.ref.Now())
 }
 
 // SetScale sets the scaling factor for tracking the reference clock.
@@ -312,12 +603,23 @@ func (c *Clock[T, D, RT]) SetScale(scale float64) {
 	})
 }
 
+// SetMinWake sets the shortest duration on the reference clock for which a
+// waker is armed, so that one due sooner waits until d has passed instead.
+// This keeps a coarse reference, such as one stepped in whole milliseconds,
+// from being asked for ever shorter durations that it rounds down and
+// fires at once, spinning until the next step, at the cost of triggering
+// timers up to d late. If d <= 0, there is no minimum, which is the
+// default.
+func (c *Clock[T, D, RT]) SetMinWake(d D) {
+	seconds := d.Seconds()
+	c.sync(func(w *clock[T, D, RT]) {
+		w.minWake = seconds
+	})
+}
+
 // Scale returns the scaling factor for tracking the reference clock.
-func (c *Clock[T, D, RT]) Scale() (scale float64) {
-	c.keeper.RLock()
-	scale = c.keeper.scale
-	c.keeper.RUnlock()
-	return
+func (c *Clock[T, D, RT]) Scale() float64 {
+	return c.snap.Load().scale
 }
 
 // Set sets the local sync point with the current reference time to now. If
@@ -325,12 +627,9 @@ func (c *Clock[T, D, RT]) Scale() (scale float64) {
 // may lead to undefined behavior.
 func (c *Clock[T, D, RT]) Set(now T) {
 	rNow := c.keeper.ref.Now()
-	c.sync(func(w *clock[T, D, RT]) {
+	c.syncFire(func(w *clock[T, D, RT]) {
 		// Reset sync point to given time
-		w.now, w.rNow = now, rNow
-
-		w.checkSchedule()
-		w.resetWaker()
+		w.now, w.rNow, w.carry = now, rNow, 0
 	})
 }
 
@@ -338,12 +637,72 @@ func (c *Clock[T, D, RT]) Set(now T) {
 // negative value for dt may lead to undefined behavior.
 func (c *Clock[T, D, RT]) Step(dt D) {
 	rNow := c.keeper.ref.Now()
-	c.sync(func(w *clock[T, D, RT]) {
+	c.syncFire(func(w *clock[T, D, RT]) {
 		// Sync up before changing setting
 		w.advanceRef(rNow)
 		w.now = w.now.Add(dt)
+	})
+}
 
-		w.checkSchedule()
+// Seek advances the local time to t, stopping at each timer due before then
+// to trigger it at the time it was scheduled, in order, rather than in a
+// single jump as with Set. Each stop is found and reached while holding
+// every lock, so that a concurrent change, such as by Set or Step, falls
+// between stops rather than within one. Timers scheduled while seeking,
+// such as by functions passed to AfterFunc, are also triggered, if due
+// before t and scheduled before Seek passes the time they are due. If t is
+// earlier than the current time, Seek does nothing.
+func (c *Clock[T, D, RT]) Seek(t T) {
+	rNow := c.keeper.ref.Now()
+	for {
+		c.lockAll()
+		now := t
+		for _, w := range c.wakers {
+			if next := w.queue.peek(); next != nil && next.when.Before(now) {
+				now = next.when
+			}
+		}
+		c.syncFireLocked(func(w *clock[T, D, RT]) {
+			// Sync up before changing setting
+			w.advanceRef(rNow)
+			if now.After(w.now) {
+				w.now = now
+			}
+		})
+		c.unlockAll()
+		if now.Equal(t) {
+			return
+		}
+	}
+}
+
+// SetOffset adjusts the local time by delta, which may be negative, without
+// otherwise changing how the reference clock is tracked. Unlike a call to
+// Set with an adjusted value of Now, the adjustment can't race with others.
+// If fire is true, timers due at or before the adjusted time are triggered,
+// as with Step. Otherwise, every timer is shifted along with the clock, so
+// that the time remaining until each triggers is unchanged.
+func (c *Clock[T, D, RT]) SetOffset(delta D, fire bool) {
+	rNow := c.keeper.ref.Now()
+	if fire {
+		c.syncFire(func(w *clock[T, D, RT]) {
+			// Sync up before changing setting
+			w.advanceRef(rNow)
+			w.now = w.now.Add(delta)
+		})
+		return
+	}
+	c.sync(func(w *clock[T, D, RT]) {
+		// Sync up before changing setting
+		w.advanceRef(rNow)
+		w.now = w.now.Add(delta)
+
+		// Order is unaffected by a uniform shift
+		for _, t := range w.queue {
+			t.when = t.when.Add(delta)
+		}
+		var zero T
+		w.wakeAt = zero // Force the waker to be reset
 		w.resetWaker()
 	})
 }
@@ -351,31 +710,122 @@ func (c *Clock[T, D, RT]) Step(dt D) {
 // NextAt returns the time at which the next scheduled timer should trigger.
 // If no timers are scheduled, returns a zero value.
 func (c *Clock[T, D, RT]) NextAt() (when T) {
-	c.mu.Lock()
-	var wg sync.WaitGroup
-	wg.Add(len(c.wakers))
-	ch := make(chan T, 1)
-	var zero T
-	ch <- zero
 	for _, w := range c.wakers {
-		go func(w *clock[T, D, RT]) {
-			w.RLock()
-			next := w.queue.peek()
-			if next != nil {
-				when := <-ch
-				if when.IsZero() || when.After(next.when) {
-					ch <- next.when
-				} else {
-					ch <- when
-				}
-			}
-			wg.Done()
-			w.RUnlock()
-		}(w)
+		w.RLock()
+	}
+	for _, w := range c.wakers {
+		if next := w.queue.peek(); next != nil && (when.IsZero() || when.After(next.when)) {
+			when = next.when
+		}
+		w.RUnlock()
+	}
+	return
+}
+
+// NextRefAt returns the time on the reference clock at which the earliest
+// armed waker is due to trigger the next scheduled timer, and true, or false
+// if no waker is armed, as when no timers are scheduled or local time is not
+// changing. This is the translation of NextAt through the current offset and
+// scale, useful for seeing why a scaled clock has yet to fire.
+func (c *Clock[T, D, RT]) NextRefAt() (when T, ok bool) {
+	for _, w := range c.wakers {
+		w.RLock()
+		if !w.wakeAt.IsZero() && (!ok || w.wakeRef.Before(when)) {
+			when, ok = w.wakeRef, true
+		}
+		w.RUnlock()
+	}
+	return
+}
+
+// SetCollectUnreferenced sets whether Timers and Tickers created afterwards
+// are stopped automatically once they can no longer be observed, allowing
+// them to be recovered by the garbage collector without calling Stop,
+// similar to timers in Go 1.23. One created by NewTimer, After, NewTicker,
+// or Tick is stopped once its channel is unreachable, whether or not the
+// Timer or Ticker itself is, so that one may be dropped while still
+// receiving on the channel. A Ticker created by TickFunc is stopped once it
+// is unreachable. When this is enabled, Tickers created by NewTicker or Tick
+// hold a tick until it is received, as those of package time do, dropping
+// any others due meanwhile, rather than waiting for a receiver to be ready.
+// Timers created by AfterFunc, and Tickers created by NewBufferedTicker,
+// which hold on to their channels while ticks wait to be delivered, are
+// unaffected. It is disabled by default.
+func (c *Clock[T, D, RT]) SetCollectUnreferenced(enabled bool) {
+	c.collect.Store(enabled)
+}
+
+// SetUnbufferedTimers sets whether Timers created afterwards by NewTimer or
+// After behave as if unbuffered, as timers in Go 1.23 do: any value not yet
+// received is discarded by Reset or Stop, so that no stale value may be
+// received after they return, and both report such a value as the timer
+// still being active. The value is still held in the channel until then, so
+// its capacity and length are reported as one, unlike in Go 1.23, but no
+// goroutine is left waiting to deliver it. It is disabled by default.
+func (c *Clock[T, D, RT]) SetUnbufferedTimers(enabled bool) {
+	c.unbuffer.Store(enabled)
+}
+
+// SetCallbackLimit sets the maximum number of functions passed to AfterFunc
+// or TickFunc that may run at once. Once it is reached, further calls are
+// queued, starting in the order they were due as earlier calls return, so
+// that stepping over a long interval does not start a goroutine for every
+// timer at once. If n <= 0, there is no limit, which is the default. A
+// function that waits on another to be called may deadlock if the limit is
+// reached.
+func (c *Clock[T, D, RT]) SetCallbackLimit(n int) {
+	c.run.SetLimit(n)
+}
+
+// SetWakeHook sets a function to be called whenever timers are triggered
+// because time on the reference clock has passed, as opposed to an explicit
+// call to Set or Step. It is called just before the timers are triggered,
+// while holding internal locks, so f must not call any methods on the
+// clock. A nil f removes any hook previously set.
+func (c *Clock[T, D, RT]) SetWakeHook(f func()) {
+	if f == nil {
+		c.onWake.Store(nil)
+		return
+	}
+	c.onWake.Store(&f)
+}
+
+// Close stops the Clock's wakers on the reference clock, cancels every
+// pending or paused Timer and Ticker, and wakes any goroutines sleeping on it, so that
+// a Clock no longer needed may be torn down without leaking them.
+// Afterwards, Sleep returns immediately and Timers and Tickers never fire,
+// whether created, Reset, or Resumed later; a tick still on its way to a
+// slow receiver is dropped. A Timer cancelled this way counts as stopped
+// rather than expired, so that Stop returns true for it, and the Try
+// variants, such as TryNewTimer, return ErrClosed for those scheduled after.
+// Close returns ErrClosed if the Clock was already closed.
+func (c *Clock[T, D, RT]) Close() error {
+	if c.closed.Swap(true) {
+		return ErrClosed
 	}
-	wg.Wait()
-	c.mu.Unlock()
-	return <-ch
+	close(c.done)
+	for _, w := range c.wakers {
+		w.Lock()
+		for _, t := range w.queue {
+			t.index = -1
+			t.dropped = true
+			w.cancelled(t)
+		}
+		for t := range w.held {
+			t.paused = false
+			t.dropped = true
+			w.cancelled(t)
+		}
+		w.queue, w.held = nil, nil
+		w.stopWaker()
+		w.Unlock()
+	}
+	return nil
+}
+
+// Done returns a channel that is closed once the Clock is closed by Close.
+func (c *Clock[T, D, RT]) Done() <-chan struct{} {
+	return c.done
 }
 
 // Seconds returns a Duration value representing n Seconds. This is provided
@@ -384,12 +834,72 @@ func (c *Clock[T, D, RT]) Seconds(n float64) D {
 	return c.keeper.ref.Seconds(n)
 }
 
+// ScalingError returns an upper bound on the error in converting a duration
+// d on the reference clock to local time at the current scale, from
+// rounding in floating point and to a value of D. It is zero at a scale of
+// 0 or 1, as no conversion is needed. Errors from successive conversions do
+// not accumulate, as the Clock carries them forward internally.
+func (c *Clock[T, D, RT]) ScalingError(d D) D {
+	ref := c.keeper.ref
+	scale := c.Scale()
+	if scale == 0.0 || scale == 1.0 {
+		return ref.Seconds(0)
+	}
+	exact := d.Seconds() * scale
+	// Half an ulp for each of the two floating point operations...
+	bound := math.Abs(exact) * 0x1p-52
+	// ...plus what is lost by rounding to a D
+	bound += math.Abs(exact - ref.Seconds(exact).Seconds())
+	if bound == 0 {
+		return ref.Seconds(0)
+	}
+	// Round up to a D that is at least as large
+	b := bound
+	e := ref.Seconds(b)
+	for e.Seconds() < bound {
+		b *= 2
+		e = ref.Seconds(b)
+	}
+	return e
+}
+
+// The remaining helpers are built on Seconds, as that is all a reference
+// clock is required to provide, so very large counts of the smaller units
+// may lose precision.
+
+// Nanoseconds returns a Duration value representing n nanoseconds.
+func (c *Clock[T, D, RT]) Nanoseconds(n int64) D {
+	return c.Seconds(float64(n) / 1e9)
+}
+
+// Microseconds returns a Duration value representing n microseconds.
+func (c *Clock[T, D, RT]) Microseconds(n int64) D {
+	return c.Seconds(float64(n) / 1e6)
+}
+
+// Milliseconds returns a Duration value representing n milliseconds.
+func (c *Clock[T, D, RT]) Milliseconds(n int64) D {
+	return c.Seconds(float64(n) / 1e3)
+}
+
+// Minutes returns a Duration value representing n Minutes.
+func (c *Clock[T, D, RT]) Minutes(n float64) D {
+	return c.Seconds(n * 60)
+}
+
+// Hours returns a Duration value representing n Hours.
+func (c *Clock[T, D, RT]) Hours(n float64) D {
+	return c.Seconds(n * 3600)
+}
+
 // Now returns the current time.
-func (c *Clock[T, D, RT]) Now() (now T) {
-	c.keeper.RLock()
-	now = c.keeper.toLocal(c.keeper.ref.Now())
-	c.keeper.RUnlock()
-	return
+func (c *Clock[T, D, RT]) Now() T {
+	p := c.snap.Load()
+	if !p.moving() {
+		// Avoid reading the reference clock while local time isn't changing
+		return p.now
+	}
+	return p.toLocal(c.keeper.ref, c.keeper.ref.Now())
 }
 
 // Since returns the time elapsed since t. It is shorthand for
@@ -404,14 +914,14 @@ func (c *Clock[T, D, RT]) Until(t T) D {
 }
 
 // Sleep pauses the current goroutine for at least the duration d. A negative
-// or zero duration causes Sleep to return immediately.
+// or zero duration causes Sleep to return immediately. It also returns once
+// the Clock is closed.
 func (c *Clock[T, D, RT]) Sleep(d D) {
-	if d.Seconds() <= 0 {
+	if d.Seconds() <= 0 || c.closed.Load() {
 		return
 	}
 
-	w := <-c.waker
-	w.Lock()
+	w := c.acquire()
 	ch := make(chan struct{})
 	tm := &timer[T, D]{
 		f:    func(T) { close(ch) },
@@ -422,8 +932,10 @@ func (c *Clock[T, D, RT]) Sleep(d D) {
 		w.resetWaker()
 	}
 	w.Unlock()
-	c.waker <- w
-	<-ch
+	select {
+	case <-ch:
+	case <-c.done:
+	}
 }
 
 type scheduler[T Time[T, D], D Duration] interface {
@@ -431,17 +943,24 @@ type scheduler[T Time[T, D], D Duration] interface {
 	unschedule(t *timer[T, D])
 	reschedule(t *timer[T, D])
 	resetWaker()
+	checkSchedule()
+	fireIfDue(t *timer[T, D])
+	cancelled(t *timer[T, D])
+	hold(t *timer[T, D])
+	unhold(t *timer[T, D])
 	Lock()
 	Unlock()
 	sync() T
+	isClosed() bool
 }
 
 // A Ticker provides a channel that delivers “ticks” of a clock at
 // intervals.
 type Ticker[T Time[T, D], D Duration] struct {
-	c <-chan T
-	t *timer[T, D]
-	s scheduler[T, D]
+	c   <-chan T
+	t   *timer[T, D]
+	s   scheduler[T, D]
+	buf *tickbuf.Buffer[T] // Only for buffered tickers
 }
 
 // C returns the channel on which the ticks are delivered.
@@ -463,6 +982,8 @@ func (t *Ticker[T, D]) Reset(d D) {
 	t.s.Lock()
 	t.t.when = t.s.sync().Add(d)
 	t.t.period = d
+	t.t.paused = false
+	t.s.unhold(t.t)
 	isNext := t.t.index == 0
 	t.s.reschedule(t.t)
 	if isNext || t.t.index == 0 {
@@ -471,22 +992,131 @@ func (t *Ticker[T, D]) Reset(d D) {
 	t.s.Unlock()
 }
 
-// Stop turns off a ticker. After Stop, no more ticks will be sent. Stop does
-// not close the channel, to prevent a concurrent goroutine reading from the
-// channel from seeing an erroneous "tick".
+// SetPeriod changes the period of a ticker without disturbing its phase:
+// the next tick still arrives when it was due, and later ones follow at
+// intervals of the new period. Unlike Reset, it does not restart a stopped
+// ticker. The duration d must be greater than zero; if not, SetPeriod will
+// panic.
+func (t *Ticker[T, D]) SetPeriod(d D) {
+	if d.Seconds() <= 0 {
+		panic("non-positive interval for relativetime.Ticker.SetPeriod")
+	}
+	if t.t == nil {
+		panic("SetPeriod called on uninitialized relativetime.Ticker")
+	}
+
+	t.s.Lock()
+	t.t.period = d
+	t.s.Unlock()
+}
+
+// Stop turns off a ticker. After Stop, no more ticks will be sent, and any
+// ticks still buffered by a ticker created by NewBufferedTicker are
+// discarded. Stop does not close the channel, to prevent a concurrent
+// goroutine reading from the channel from seeing an erroneous "tick".
 func (t *Ticker[T, D]) Stop() {
 	if t.t == nil {
 		panic("Stop called on uninitialized relativetime.Ticker")
 	}
 
 	t.s.Lock()
+	t.t.paused = false
+	t.s.unhold(t.t)
 	isNext := t.t.index == 0
 	t.s.unschedule(t.t)
+	if t.t.index == -2 {
+		// Keep a pending send from rescheduling the ticker
+		t.t.index = -1
+	}
 	if isNext {
 		t.s.sync()
 		t.s.resetWaker()
 	}
 	t.s.Unlock()
+	if t.buf != nil {
+		t.buf.Stop()
+	}
+}
+
+// Pause suspends a running ticker, remembering the time left until its next
+// tick. No ticks are sent while paused. Pause has no effect on a ticker that
+// is already paused or has been stopped.
+func (t *Ticker[T, D]) Pause() {
+	if t.t == nil {
+		panic("Pause called on uninitialized relativetime.Ticker")
+	}
+
+	t.s.Lock()
+	if !t.t.paused && t.t.index != -1 {
+		now := t.s.sync()
+		t.t.paused = true
+		t.s.hold(t.t)
+		switch t.t.index {
+		case -2:
+			// A slow receiver is still owed a tick; the next one is due a
+			// full period after it is sent
+			t.t.remaining = t.t.period
+			t.t.index = -1
+		default:
+			t.t.remaining = t.t.when.Sub(now)
+			isNext := t.t.index == 0
+			t.s.unschedule(t.t)
+			if isNext {
+				t.s.resetWaker()
+			}
+		}
+	}
+	t.s.Unlock()
+}
+
+// Resume restarts a paused ticker in the same phase, so that its next tick
+// arrives once the time that was left when it was paused has elapsed.
+// Resume has no effect on a ticker that is not paused. Reset or Stop on a
+// paused ticker clears the pause.
+func (t *Ticker[T, D]) Resume() {
+	if t.t == nil {
+		panic("Resume called on uninitialized relativetime.Ticker")
+	}
+
+	t.s.Lock()
+	if t.t.paused {
+		t.t.paused = false
+		t.s.unhold(t.t)
+		t.t.when = t.s.sync().Add(t.t.remaining)
+		t.s.schedule(t.t)
+		if t.t.index == 0 {
+			t.s.resetWaker()
+		}
+	}
+	t.s.Unlock()
+}
+
+// SetTag attaches tag to the ticker, replacing any attached previously, for
+// use with Clock.CancelTag and Clock.TimersByTag. The tag must be
+// comparable; if not, SetTag will panic. A nil tag detaches it.
+func (t *Ticker[T, D]) SetTag(tag any) {
+	if t.t == nil {
+		panic("SetTag called on uninitialized relativetime.Ticker")
+	}
+	checkTag(tag, "Ticker.SetTag")
+
+	t.s.Lock()
+	t.t.tag = tag
+	t.s.Unlock()
+}
+
+// Missed returns the number of ticks that were not delivered since the
+// previous call to Missed, either because the receiver was not ready for
+// them or because the clock advanced past more than one period at once.
+func (t *Ticker[T, D]) Missed() (n int) {
+	if t.t == nil {
+		panic("Missed called on uninitialized relativetime.Ticker")
+	}
+
+	t.s.Lock()
+	n, t.t.missed = t.t.missed, 0
+	t.s.Unlock()
+	return
 }
 
 // NewTicker returns a new Ticker containing a channel that will send the
@@ -500,36 +1130,74 @@ func (c *Clock[T, D, RT]) NewTicker(d D) *Ticker[T, D] {
 		panic("non-positive interval for relativetime.Clock.NewTicker")
 	}
 
-	w := <-c.waker
-	w.Lock()
-	ch := make(chan T)
+	return c.newTicker(d)
+}
+
+func (c *Clock[T, D, RT]) newTicker(d D) *Ticker[T, D] {
+	w := c.acquire()
 	tm := &timer[T, D]{
 		when:   w.sync().Add(d),
 		period: d,
 	}
+	if c.collect.Load() {
+		ch, send := w.makeChan(tm, 1)
+		tm.f = func(when T) {
+			if !send(when) {
+				tm.missed++
+			}
+		}
+		w.schedule(tm)
+		if tm.index == 0 {
+			w.resetWaker()
+		}
+		w.Unlock()
+		return &Ticker[T, D]{c: ch, t: tm, s: w}
+	}
+	ch := make(chan T)
 	wait := make(chan struct{}, 1)
 	tm.f = func(when T) {
 		select {
 		case ch <- when:
 		default:
+			if c.received((<-chan T)(ch), when) {
+				return
+			}
 			w.unschedule(tm)
 			tm.index = -2
+			w.hold(tm)
 			select {
 			case wait <- struct{}{}:
 			default:
 				// Already waiting with a value
 				return
 			}
+			sent := make(chan struct{})
+			c.sending.Store((<-chan T)(ch), sent)
 			go func() {
-				ch <- when
+				select {
+				case ch <- when:
+				case <-c.done:
+					// Closed before it was received, so dropped
+				}
 				w.Lock()
+				c.sending.Delete((<-chan T)(ch))
+				close(sent)
 				<-wait
 				if tm.index > -2 {
 					// Reset() or Stop() was called while waiting
 					w.Unlock()
 					return
 				}
-				tm.when = w.sync().Add(tm.period)
+				w.unhold(tm)
+				if c.closed.Load() {
+					tm.index = -1
+					w.cancelled(tm)
+					w.Unlock()
+					return
+				}
+				now := w.sync()
+				tm.missed += skipped(when, now, tm.period)
+				tm.when = now.Add(tm.period)
 				w.schedule(tm)
 				if tm.index == 0 {
 					w.resetWaker()
@@ -543,8 +1211,83 @@ func (c *Clock[T, D, RT]) NewTicker(d D) *Ticker[T, D] {
 		w.resetWaker()
 	}
 	w.Unlock()
-	c.waker <- w
-	return &Ticker[T, D]{ch, tm, w}
+	return &Ticker[T, D]{c: ch, t: tm, s: w}
+}
+
+// NewBufferedTicker is like NewTicker, but rather than dropping ticks for
+// slow receivers, it buffers them to be delivered in order. A tick is sent
+// for every period elapsed, even when the clock jumps past several at once,
+// each carrying the time it was due. At most limit ticks are held until
+// received, or any number if limit <= 0; beyond that, ticks are dropped and
+// reported by Missed. Should the clock jump past more than limit periods at
+// once, only the last limit ticks are sent.
+func (c *Clock[T, D, RT]) NewBufferedTicker(d D, limit int) *Ticker[T, D] {
+	if d.Seconds() <= 0 {
+		panic("non-positive interval for relativetime.Clock.NewBufferedTicker")
+	}
+
+	ch := make(chan T)
+	buf := tickbuf.New[T](ch, limit)
+	var sent chan struct{}
+	buf.OnBusy(func(busy bool) {
+		if busy {
+			sent = make(chan struct{})
+			c.sending.Store((<-chan T)(ch), sent)
+		} else {
+			c.sending.Delete((<-chan T)(ch))
+			close(sent)
+		}
+	})
+	w := c.acquire()
+	tm := &timer[T, D]{
+		when:   w.sync().Add(d),
+		period: d,
+		exact:  true,
+		burst:  limit,
+	}
+	tm.f = func(when T) {
+		if !buf.Push(when) {
+			tm.missed++
+		}
+	}
+	w.schedule(tm)
+	if tm.index == 0 {
+		w.resetWaker()
+	}
+	w.Unlock()
+
+	return &Ticker[T, D]{c: ch, t: tm, s: w, buf: buf}
+}
+
+// TickFunc calls f in its own goroutine after each tick, with the period of
+// the ticks specified by the duration argument. Unlike NewTicker, no channel
+// is used, so the C method of the returned Ticker returns nil. Ticks are
+// never dropped, though a call to f may overlap with previous calls if they
+// have not yet returned. The duration d must be greater than zero; if not,
+// TickFunc will panic. Stop the ticker to release associated resources.
+func (c *Clock[T, D, RT]) TickFunc(d D, f func()) *Ticker[T, D] {
+	if d.Seconds() <= 0 {
+		panic("non-positive interval for relativetime.Clock.TickFunc")
+	}
+
+	w := c.acquire()
+	tm := &timer[T, D]{
+		f:      func(T) { c.run.Go(f) },
+		fn:     f,
+		when:   w.sync().Add(d),
+		period: d,
+	}
+	w.schedule(tm)
+	if tm.index == 0 {
+		w.resetWaker()
+	}
+	w.Unlock()
+
+	t := &Ticker[T, D]{t: tm, s: w}
+	if c.collect.Load() {
+		runtime.SetFinalizer(t, (*Ticker[T, D]).Stop)
+	}
+	return t
 }
 
 // Tick is a convenience wrapper for NewTicker providing access to the
@@ -557,7 +1300,7 @@ func (c *Clock[T, D, RT]) Tick(d D) <-chan T {
 		return nil
 	}
 
-	return c.NewTicker(d).c
+	return c.newTicker(d).c
 }
 
 // The Timer type represents a single event. When the Timer expires, the
@@ -568,6 +1311,8 @@ type Timer[T Time[T, D], D Duration] struct {
 	c <-chan T
 	t *timer[T, D]
 	s scheduler[T, D]
+
+	drain func() bool // Discards an undelivered value, for unbuffered timers
 }
 
 // C returns the channel on which the ticks are delivered.
@@ -585,9 +1330,13 @@ func (t *Timer[T, D]) Reset(d D) (active bool) {
 	t.s.Lock()
 
 	t.t.when = t.s.sync().Add(d)
-	active = t.t.index >= 0
+	active = t.t.index >= 0 || t.t.paused || t.t.dropped || t.drained()
+	t.t.paused = false
+	t.t.dropped = false
+	t.s.unhold(t.t)
 	isNext := t.t.index == 0
 	t.s.reschedule(t.t)
+	t.s.fireIfDue(t.t)
 	if isNext || t.t.index == 0 {
 		t.s.resetWaker()
 	}
@@ -597,9 +1346,11 @@ func (t *Timer[T, D]) Reset(d D) (active bool) {
 }
 
 // Stop prevents the Timer from firing. It returns true if the call stops the
-// timer, false if the timer has already expired or been stopped. Stop does
-// not close the channel, to prevent a read from the channel succeeding
-// incorrectly.
+// timer, false if the timer has already expired or been stopped. A timer
+// cancelled by closing its Clock has not expired, so the first Stop or Reset
+// after returns true, and any callback it would have run is never called.
+// Stop does not close the channel, to prevent a read from the channel
+// succeeding incorrectly.
 func (t *Timer[T, D]) Stop() (active bool) {
 	if t.t == nil {
 		panic("Stop called on uninitialized relativetime.Timer")
@@ -607,9 +1358,15 @@ func (t *Timer[T, D]) Stop() (active bool) {
 
 	t.s.Lock()
 
-	active = t.t.index >= 0
+	active = t.t.index >= 0 || t.t.paused || t.t.dropped || t.drained()
+	t.t.paused = false
+	t.s.unhold(t.t)
 	isNext := t.t.index == 0
 	t.s.unschedule(t.t)
+	if active && !t.t.dropped {
+		t.s.cancelled(t.t)
+	}
+	t.t.dropped = false
 	if isNext {
 		t.s.sync()
 		t.s.resetWaker()
@@ -619,28 +1376,185 @@ func (t *Timer[T, D]) Stop() (active bool) {
 	return
 }
 
+// Pending reports whether the Timer is waiting to fire: it has neither
+// fired nor been stopped since it was created or last reset, nor is it
+// paused.
+func (t *Timer[T, D]) Pending() bool {
+	if t.t == nil {
+		panic("Pending called on uninitialized relativetime.Timer")
+	}
+
+	t.s.Lock()
+	defer t.s.Unlock()
+	return t.t.index >= 0
+}
+
+// Discard any value an unbuffered timer has yet to deliver, reporting
+// whether there was one. Callers must hold the lock.
+func (t *Timer[T, D]) drained() bool {
+	return t.drain != nil && t.drain()
+}
+
+// Pause suspends an active timer, remembering the time left until it
+// expires. It returns true if the call pauses the timer, false if the timer
+// has already expired, been stopped, or been paused. A paused timer counts
+// as active for Reset and Stop, either of which clears the pause.
+func (t *Timer[T, D]) Pause() (paused bool) {
+	if t.t == nil {
+		panic("Pause called on uninitialized relativetime.Timer")
+	}
+
+	t.s.Lock()
+	if paused = t.t.index >= 0; paused {
+		t.t.paused = true
+		t.s.hold(t.t)
+		t.t.remaining = t.t.when.Sub(t.s.sync())
+		isNext := t.t.index == 0
+		t.s.unschedule(t.t)
+		if isNext {
+			t.s.resetWaker()
+		}
+	}
+	t.s.Unlock()
+	return
+}
+
+// Resume re-arms a paused timer to expire once the time that was left when
+// it was paused has elapsed. It returns true if the call resumes the timer,
+// false if the timer was not paused.
+func (t *Timer[T, D]) Resume() (resumed bool) {
+	if t.t == nil {
+		panic("Resume called on uninitialized relativetime.Timer")
+	}
+
+	t.s.Lock()
+	if resumed = t.t.paused; resumed {
+		t.t.paused = false
+		t.s.unhold(t.t)
+		t.t.when = t.s.sync().Add(t.t.remaining)
+		t.s.schedule(t.t)
+		t.s.fireIfDue(t.t)
+		if t.t.index == 0 {
+			t.s.resetWaker()
+		}
+	}
+	t.s.Unlock()
+	return
+}
+
+// FireNow triggers the timer at once, as if it had expired, if it is
+// active or paused. It returns true if the timer was triggered, false if it
+// had already expired or been stopped.
+func (t *Timer[T, D]) FireNow() (fired bool) {
+	if t.t == nil {
+		panic("FireNow called on uninitialized relativetime.Timer")
+	}
+
+	t.s.Lock()
+	if fired = t.t.index >= 0 || t.t.paused; fired {
+		t.t.paused = false
+		t.s.unhold(t.t)
+		t.t.when = t.s.sync()
+		t.s.reschedule(t.t)
+		t.s.checkSchedule()
+		t.s.resetWaker()
+	}
+	t.s.Unlock()
+	return
+}
+
+// SetTag attaches tag to the timer, replacing any attached previously, for
+// use with Clock.CancelTag and Clock.TimersByTag. The tag must be
+// comparable; if not, SetTag will panic. A nil tag detaches it.
+func (t *Timer[T, D]) SetTag(tag any) {
+	if t.t == nil {
+		panic("SetTag called on uninitialized relativetime.Timer")
+	}
+	checkTag(tag, "Timer.SetTag")
+
+	t.s.Lock()
+	t.t.tag = tag
+	t.s.Unlock()
+}
+
 // NewTimer creates a new Timer that will send the current time on its
-// channel after at least duration d.
+// channel after at least duration d. If d <= 0, it fires at once, even
+// while the clock is stopped.
 func (c *Clock[T, D, RT]) NewTimer(d D) *Timer[T, D] {
-	w := <-c.waker
-	w.Lock()
-	ch := make(chan T, 1)
+	return c.newTimer(d)
+}
+
+func (c *Clock[T, D, RT]) newTimer(d D) *Timer[T, D] {
+	if c.unbuffer.Load() {
+		return c.newUnbufferedTimer(d)
+	}
+	return c.newBufferedTimer(d)
+}
+
+func (c *Clock[T, D, RT]) newBufferedTimer(d D) *Timer[T, D] {
+	w := c.acquire()
 	tm := &timer[T, D]{
-		f: func(when T) {
-			select {
-			case ch <- when:
-			default:
-			}
-		},
 		when: w.sync().Add(d),
 	}
+	ch, send := w.makeChan(tm, 1)
+	tm.f = func(when T) { send(when) }
 	w.schedule(tm)
+	w.fireIfDue(tm)
 	if tm.index == 0 {
 		w.resetWaker()
 	}
 	w.Unlock()
-	c.waker <- w
-	return &Timer[T, D]{ch, tm, w}
+	return &Timer[T, D]{c: ch, t: tm, s: w}
+}
+
+// Return a new channel with room for size values for t to deliver on, along
+// with a function sending on it without blocking, which reports whether the
+// value was sent. If unreferenced timers are collected, t refers to the
+// channel only weakly, and is stopped once it is otherwise unreachable.
+func (c *clock[T, D, RT]) makeChan(t *timer[T, D], size int) (chan T, func(T) bool) {
+	if !c.parent.collect.Load() {
+		ch := make(chan T, size)
+		return ch, func(v T) bool {
+			select {
+			case ch <- v:
+				return true
+			default:
+				return false
+			}
+		}
+	}
+	ch, ref := weakchan.Make[T](size, func() {
+		c.Lock()
+		c.unhold(t)
+		isNext := t.index == 0
+		c.unschedule(t)
+		if isNext {
+			c.sync()
+			c.resetWaker()
+		}
+		c.Unlock()
+	})
+	return ch, ref.Send
+}
+
+// Timers made unbuffered hold their value in a channel with room for just
+// one, as a buffered timer does, rather than in a goroutine waiting to send
+// it, so that one never received, as by After in a select that took another
+// case, is simply collected along with its channel. Reset and Stop discard
+// it under the same lock held while firing, so it can't be received once
+// either has returned.
+func (c *Clock[T, D, RT]) newUnbufferedTimer(d D) *Timer[T, D] {
+	t := c.newBufferedTimer(d)
+	ch := t.c
+	t.drain = func() bool {
+		select {
+		case <-ch:
+			return true
+		default:
+			return false
+		}
+	}
+	return t
 }
 
 // After waits for the duration to elapse and then sends the current time on
@@ -649,24 +1563,25 @@ func (c *Clock[T, D, RT]) NewTimer(d D) *Timer[T, D] {
 // fires. If efficiency is a concern, use clock.NewTimer instead and call
 // Timer.Stop if the timer is no longer needed.
 func (c *Clock[T, D, RT]) After(d D) <-chan T {
-	return c.NewTimer(d).c
+	return c.newTimer(d).c
 }
 
 // AfterFunc waits for the duration to elapse and then calls f in its own
 // goroutine. It returns a Timer that can be used to cancel the call using
-// its Stop method.
+// its Stop method. As f is not called while holding any lock, it may safely
+// call methods on the clock or the returned Timer, such as Reset to
+// reschedule itself.
 func (c *Clock[T, D, RT]) AfterFunc(d D, f func()) *Timer[T, D] {
-	w := <-c.waker
-	w.Lock()
+	w := c.acquire()
 	tm := &timer[T, D]{
-		f:    func(T) { go f() },
+		f:    func(T) { c.run.Go(f) },
 		when: w.sync().Add(d),
 	}
 	w.schedule(tm)
+	w.fireIfDue(tm)
 	if tm.index == 0 {
 		w.resetWaker()
 	}
 	w.Unlock()
-	c.waker <- w
 	return &Timer[T, D]{t: tm, s: w}
 }
@@ -0,0 +1,810 @@
+package relativetime_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	stdtime "time"
+
+	. "github.com/noodlebox/clock/relativetime"
+	"github.com/noodlebox/clock/steppedtime"
+)
+
+func TestScalingCarry(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0/3)
+	c.Start()
+	for i := 0; i < 3000; i++ {
+		ref.Step(steppedtime.Nanosecond)
+		c.Start() // Syncs with the reference
+	}
+	if got, want := c.Now(), steppedtime.Time(1000); got < want-1 || got > want {
+		t.Errorf("Now() = %v after 3000 syncs at scale 1/3, want %v", got, want)
+	}
+}
+
+func TestScalingError(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	if e := c.ScalingError(steppedtime.Hour); e != 0 {
+		t.Errorf("ScalingError(1h) = %v at scale 1, want 0", e)
+	}
+	c.SetScale(1.0 / 3)
+	if e := c.ScalingError(steppedtime.Hour); e < steppedtime.Nanosecond || e > 2*steppedtime.Nanosecond {
+		t.Errorf("ScalingError(1h) = %v at scale 1/3, want 1-2ns", e)
+	}
+}
+
+func TestNextRefAt(t *testing.T) {
+	ref := steppedtime.NewClockAt(steppedtime.Time(steppedtime.Hour))
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 2.0)
+	tm := c.NewTimer(10 * steppedtime.Second)
+	if _, ok := c.NextRefAt(); ok {
+		t.Errorf("NextRefAt() reported a waker armed while stopped")
+	}
+
+	c.Start()
+	want := steppedtime.Time(steppedtime.Hour + 5*steppedtime.Second)
+	if got, ok := c.NextRefAt(); !ok || got != want {
+		t.Errorf("NextRefAt() = %v, %v; want %v, true", got, ok, want)
+	}
+
+	tm.Stop()
+	if _, ok := c.NextRefAt(); ok {
+		t.Errorf("NextRefAt() reported a waker armed with no timers")
+	}
+}
+
+func TestClose(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	c.Start()
+	tm := c.NewTimer(steppedtime.Second)
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(steppedtime.Hour)
+		close(done)
+	}()
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	<-done
+	if _, ok := c.NextRefAt(); ok {
+		t.Errorf("waker still armed after Close")
+	}
+	if !tm.Stop() {
+		t.Errorf("Stop() = false on a timer cancelled by Close")
+	}
+	if tm.Stop() {
+		t.Errorf("second Stop() = true on a timer cancelled by Close")
+	}
+	later := c.NewTimer(steppedtime.Second)
+	ref.Step(steppedtime.Hour)
+	c.Step(steppedtime.Hour)
+	select {
+	case <-tm.C():
+		t.Errorf("timer fired after Close")
+	case <-later.C():
+		t.Errorf("timer created after Close fired")
+	default:
+	}
+	c.Sleep(steppedtime.Hour) // Returns immediately once closed
+	if err := c.Close(); err != ErrClosed {
+		t.Errorf("second Close() = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestTryAfterClose(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	tm, err := c.TryAfterFunc(steppedtime.Second, func() {})
+	if err != nil {
+		t.Fatalf("TryAfterFunc() = %v before Close", err)
+	}
+	tk, err := c.TryNewTicker(steppedtime.Second)
+	if err != nil {
+		t.Fatalf("TryNewTicker() = %v before Close", err)
+	}
+	tk.Pause()
+	c.Close()
+
+	if _, err := c.TryNewTimer(steppedtime.Second); err != ErrClosed {
+		t.Errorf("TryNewTimer() = %v, want %v", err, ErrClosed)
+	}
+	if _, err := c.TryAfterFunc(steppedtime.Second, func() {}); err != ErrClosed {
+		t.Errorf("TryAfterFunc() = %v, want %v", err, ErrClosed)
+	}
+	if _, err := c.TryNewTicker(steppedtime.Second); err != ErrClosed {
+		t.Errorf("TryNewTicker() = %v, want %v", err, ErrClosed)
+	}
+	if _, err := c.TryTickFunc(steppedtime.Second, func() {}); err != ErrClosed {
+		t.Errorf("TryTickFunc() = %v, want %v", err, ErrClosed)
+	}
+	if _, err := tm.TryReset(steppedtime.Second); err != ErrClosed {
+		t.Errorf("Timer.TryReset() = %v, want %v", err, ErrClosed)
+	}
+	if err := tk.TryResume(); err != ErrClosed {
+		t.Errorf("Ticker.TryResume() = %v, want %v", err, ErrClosed)
+	}
+	if err := tk.TryReset(steppedtime.Second); err != ErrClosed {
+		t.Errorf("Ticker.TryReset() = %v, want %v", err, ErrClosed)
+	}
+	// Scheduled only after Close, yet never to fire
+	if !tm.Stop() {
+		t.Errorf("Stop() = false on a timer Reset after Close")
+	}
+}
+
+func TestCloseDropsTick(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	tk := c.NewTicker(steppedtime.Second)
+	before := runtime.NumGoroutine()
+
+	// Left waiting on a receiver that never comes
+	c.Step(steppedtime.Second)
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	for runtime.NumGoroutine() > before {
+		runtime.Gosched()
+	}
+	if v, ok := c.Receive(tk.C())(); ok {
+		t.Errorf("received %v after Close", v)
+	}
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	if _, ok := c.LatencyHistogram(); ok {
+		t.Errorf("LatencyHistogram() reported a histogram before recording")
+	}
+	c.StartLatencyHistogram(steppedtime.Millisecond, steppedtime.Second)
+
+	c.NewTimer(steppedtime.Second)
+	c.NewTimer(2 * steppedtime.Second)
+	c.Seek(steppedtime.Time(2 * steppedtime.Second)) // Both exactly on time
+	c.NewTimer(steppedtime.Second)
+	c.Step(3 * steppedtime.Second) // Two seconds late
+
+	h, ok := c.LatencyHistogram()
+	if !ok {
+		t.Fatalf("LatencyHistogram() reported no histogram while recording")
+	}
+	if want := []uint64{2, 0, 1}; h.Total != 3 || len(h.Counts) != len(want) ||
+		h.Counts[0] != want[0] || h.Counts[1] != want[1] || h.Counts[2] != want[2] {
+		t.Errorf("Counts = %v (Total %d), want %v", h.Counts, h.Total, want)
+	}
+	if h.Max != 2*steppedtime.Second {
+		t.Errorf("Max = %v, want %v", h.Max, 2*steppedtime.Second)
+	}
+
+	c.StopLatencyHistogram()
+	c.NewTimer(0)
+	if h, _ := c.LatencyHistogram(); h.Total != 3 {
+		t.Errorf("Total = %d after stopping, want 3", h.Total)
+	}
+}
+
+func TestFailover(t *testing.T) {
+	primary := steppedtime.NewClockAt(steppedtime.Time(steppedtime.Hour))
+	secondary := steppedtime.NewClock()
+	f := NewFailover[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer, *steppedtime.Timer](
+		primary, secondary, steppedtime.Millisecond, steppedtime.Second)
+	step := func(p, s steppedtime.Duration) steppedtime.Time {
+		primary.Step(p)
+		secondary.Step(s)
+		return f.Now()
+	}
+	start := f.Now()
+
+	if got, want := step(steppedtime.Second, steppedtime.Second), start.Add(steppedtime.Second); got != want || f.UsingSecondary() {
+		t.Fatalf("Now() = %v while healthy, want %v from the primary", got, want)
+	}
+
+	// The primary stalls, then jumps
+	if got, want := step(0, steppedtime.Second), start.Add(2*steppedtime.Second); got != want || !f.UsingSecondary() {
+		t.Errorf("Now() = %v after a stall, want %v from the secondary", got, want)
+	}
+	if got, want := step(steppedtime.Hour, steppedtime.Second), start.Add(3*steppedtime.Second); got != want || !f.UsingSecondary() {
+		t.Errorf("Now() = %v after a jump, want %v from the secondary", got, want)
+	}
+
+	// The primary recovers
+	if got, want := step(2*steppedtime.Second, 2*steppedtime.Second), start.Add(5*steppedtime.Second); got != want || f.UsingSecondary() {
+		t.Errorf("Now() = %v after recovery, want %v from the primary", got, want)
+	}
+
+	// Timers fire on either reference
+	ch := make(chan struct{})
+	f.AfterFunc(steppedtime.Second, func() { close(ch) })
+	step(0, steppedtime.Second)
+	<-ch
+
+	// And may serve as a reference
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *FailoverTimer[steppedtime.Duration]](f, 0, 1.0)
+	c.Start()
+	tm := c.NewTimer(steppedtime.Second)
+	step(steppedtime.Second, steppedtime.Second)
+	<-tm.C()
+}
+
+// chanOnly hides everything of a steppedtime.Clock but its channel timers.
+type chanOnly struct{ c *steppedtime.Clock }
+
+func (r chanOnly) Now() steppedtime.Time                              { return r.c.Now() }
+func (r chanOnly) Seconds(n float64) steppedtime.Duration             { return r.c.Seconds(n) }
+func (r chanOnly) NewTimer(d steppedtime.Duration) *steppedtime.Timer { return r.c.NewTimer(d) }
+
+func TestFromChanTimers(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *ChanWaker[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer]](
+		FromChanTimers[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](chanOnly{ref}), 0, 2.0)
+	c.Start()
+	tm := c.NewTimer(2 * steppedtime.Second)
+	ref.Step(steppedtime.Second)
+	if got := <-tm.C(); got != steppedtime.Time(2*steppedtime.Second) {
+		t.Errorf("timer fired at %v, want %v", got, steppedtime.Time(2*steppedtime.Second))
+	}
+
+	tm.Reset(2 * steppedtime.Second)
+	ref.Step(steppedtime.Second)
+	<-tm.C()
+	if err := c.Close(); err != nil {
+		t.Errorf("Close() = %v", err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 2.0)
+	c.Start()
+	tm := c.NewTimer(steppedtime.Second)
+	ticks := make(chan struct{}, 8)
+	c.TickFunc(3*steppedtime.Second, func() { ticks <- struct{}{} })
+	ref.Step(steppedtime.Second)
+
+	cl := c.Clone(true)
+	if !cl.Active() || cl.Scale() != 2.0 || cl.Now() != c.Now() {
+		t.Fatalf("clone active %v at scale %v and time %v, want true, 2, and %v", cl.Active(), cl.Scale(), cl.Now(), c.Now())
+	}
+
+	// The clone tracks the reference on its own, with a copy of the ticker
+	cl.SetScale(1.0)
+	ref.Step(steppedtime.Second)
+	if got, want := cl.Now(), steppedtime.Time(3*steppedtime.Second); got != want {
+		t.Errorf("clone Now() = %v, want %v", got, want)
+	}
+	if got, want := c.Now(), steppedtime.Time(4*steppedtime.Second); got != want {
+		t.Errorf("original Now() = %v, want %v", got, want)
+	}
+	<-ticks // From the original
+	<-ticks // From the copy
+	if tm.Stop() {
+		t.Errorf("timer on the original still pending")
+	}
+	cl.Close()
+
+	// A wake hook, reporting on the original, is not copied
+	var hooked atomic.Int32
+	c.SetWakeHook(func() { hooked.Add(1) })
+	cl = c.Clone(false)
+	c.Stop()
+	ctm := cl.NewTimer(steppedtime.Second)
+	ref.Step(steppedtime.Second)
+	<-ctm.C()
+	if n := hooked.Load(); n != 0 {
+		t.Errorf("wake hook called %d times by the clone, want 0", n)
+	}
+	c.Close()
+	cl.Close()
+}
+
+func TestTickID(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	tm := c.NewTimer(steppedtime.Second)
+	tk := c.NewTicker(steppedtime.Second)
+	defer tk.Stop()
+
+	c.Step(steppedtime.Second)
+	a, b := tm.TickID(), tk.TickID()
+	if a == 0 || b == 0 || a == b {
+		t.Fatalf("TickIDs %d and %d for simultaneous firings, want distinct", a, b)
+	}
+	if last := c.LastTickID(); last != 2 {
+		t.Errorf("LastTickID() = %d, want 2", last)
+	}
+}
+
+func TestTickIDOrder(t *testing.T) {
+	// Spread timers across several shards, however many CPUs there are
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+
+	// Firings across every shard are numbered in the order they were due,
+	// though all are delivered with the same time
+	var chs [10]chan Firing[steppedtime.Time]
+	for i := len(chs) - 1; i >= 0; i-- {
+		chs[i] = make(chan Firing[steppedtime.Time], 1)
+		defer c.NewNumberedTimer(steppedtime.Duration(i+1)*steppedtime.Millisecond, chs[i]).Stop()
+	}
+	c.Step(10 * steppedtime.Millisecond)
+	var prev TickID
+	for i, ch := range chs {
+		f := <-ch
+		if f.ID <= prev {
+			t.Errorf("timer %d fired with TickID %d, after one due earlier with %d", i, f.ID, prev)
+		}
+		prev = f.ID
+	}
+	if last := c.LastTickID(); prev != last {
+		t.Errorf("last Firing has TickID %d, want %d", prev, last)
+	}
+
+	// A shared channel receives Firings from Tickers too
+	ch := make(chan Firing[steppedtime.Time], 1)
+	tk := c.NewNumberedTicker(steppedtime.Millisecond, ch)
+	defer tk.Stop()
+	c.Step(steppedtime.Millisecond)
+	if f := <-ch; f.ID != prev+1 || f.When != c.Now() {
+		t.Errorf("Ticker delivered %v, want TickID %d at %v", f, prev+1, c.Now())
+	}
+}
+
+func TestSetMinWake(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	c.SetMinWake(10 * steppedtime.Millisecond)
+	c.Start()
+	tm := c.NewTimer(steppedtime.Microsecond)
+	if got, ok := c.NextRefAt(); !ok || got != steppedtime.Time(10*steppedtime.Millisecond) {
+		t.Errorf("NextRefAt() = %v, %v; want %v, true", got, ok, steppedtime.Time(10*steppedtime.Millisecond))
+	}
+
+	ref.Step(steppedtime.Millisecond)
+	select {
+	case <-tm.C():
+		t.Fatalf("timer fired before the minimum wake")
+	default:
+	}
+	ref.Step(9 * steppedtime.Millisecond)
+	<-tm.C()
+}
+
+func TestMonitorReference(t *testing.T) {
+	// A stepped reference never advances on its own, so it stalls
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	reports := make(chan Health[steppedtime.Time], 1)
+	m := c.MonitorReference(stdtime.Millisecond, stdtime.Millisecond, func(h Health[steppedtime.Time]) {
+		reports <- h
+	})
+	defer m.Stop()
+	if h := <-reports; !h.Stalled || h.Jumped || h.Healthy() {
+		t.Errorf("first report %+v, want stalled", h)
+	}
+	if m.Healthy() {
+		t.Errorf("Healthy() = true with a stalled reference")
+	}
+}
+
+func TestTimerOnStop(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	stopped := make(chan int, 3)
+	for i := 0; i < 2; i++ {
+		i := i
+		c.NewTimer(steppedtime.Second).OnStop(func() { stopped <- i })
+	}
+	tm := c.NewTimer(steppedtime.Second)
+	tm.SetTag("x")
+	tm.OnStop(func() { stopped <- 2 })
+	if n := c.CancelTag("x"); n != 1 || <-stopped != 2 {
+		t.Errorf("OnStop not called by CancelTag")
+	}
+	paused := c.NewTimer(steppedtime.Second)
+	paused.OnStop(func() { stopped <- 4 })
+	paused.Pause()
+	c.Close()
+	if got := <-stopped + <-stopped + <-stopped; got != 5 {
+		t.Errorf("OnStop not called for each timer cancelled by Close")
+	}
+	if !paused.Stop() || paused.Resume() {
+		t.Errorf("paused timer not cancelled by Close")
+	}
+}
+
+func TestEvent(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	c.Start()
+	fired := make(chan steppedtime.Time, 1)
+	e := c.NewEvent(func(now steppedtime.Time) { fired <- now })
+	s := e.Scheduler()
+
+	// Debounce: each touch pushes the deadline back by a second
+	touch := func() {
+		s.Lock()
+		e.SetWhen(s.Sync().Add(steppedtime.Second))
+		s.Reschedule(e)
+		s.ResetWaker()
+		s.Unlock()
+	}
+	touch()
+	ref.Step(steppedtime.Second / 2)
+	touch()
+	ref.Step(steppedtime.Second * 3 / 4)
+	select {
+	case now := <-fired:
+		t.Fatalf("Event fired at %v, before its deadline was reached", now)
+	case <-stdtime.After(10 * stdtime.Millisecond):
+	}
+	ref.Step(steppedtime.Second / 4)
+	select {
+	case now := <-fired:
+		if want := steppedtime.Time(3 * steppedtime.Second / 2); now != want {
+			t.Errorf("Event fired at %v, want %v", now, want)
+		}
+	case <-stdtime.After(stdtime.Second):
+		t.Fatalf("Event did not fire")
+	}
+
+	s.Lock()
+	if e.Scheduled() || s.Unschedule(e) {
+		t.Errorf("Event still scheduled after firing")
+	}
+	// An Event already due fires at once
+	e.SetWhen(s.Sync())
+	s.Schedule(e)
+	s.Unlock()
+	if now := <-fired; now != c.Now() {
+		t.Errorf("Event fired at %v, want %v", now, c.Now())
+	}
+}
+
+func TestNewChild(t *testing.T) {
+	ref := steppedtime.NewClock()
+	parent := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	parent.Start()
+	child := parent.NewChild(0, 2.0)
+	child.Start()
+	tm := child.NewTimer(10 * steppedtime.Second)
+
+	ref.Step(4 * steppedtime.Second)
+	if got, want := child.Now(), steppedtime.Time(8*steppedtime.Second); got != want {
+		t.Errorf("child Now() = %v, want %v", got, want)
+	}
+
+	// Pausing the parent pauses the child
+	parent.Stop()
+	ref.Step(steppedtime.Hour)
+	if got, want := child.Now(), steppedtime.Time(8*steppedtime.Second); got != want {
+		t.Errorf("child Now() = %v with parent stopped, want %v", got, want)
+	}
+
+	parent.Start()
+	ref.Step(steppedtime.Second)
+	select {
+	case now := <-tm.C():
+		if want := steppedtime.Time(10 * steppedtime.Second); now != want {
+			t.Errorf("child Timer fired at %v, want %v", now, want)
+		}
+	case <-stdtime.After(stdtime.Second):
+		t.Fatalf("child Timer did not fire")
+	}
+}
+
+func TestTimerPostpone(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	c.Start()
+	tm := c.NewTimer(steppedtime.Second)
+	if !tm.Postpone(steppedtime.Second) {
+		t.Errorf("Postpone() = false for an active timer")
+	}
+	if got, want := c.NextAt(), steppedtime.Time(2*steppedtime.Second); got != want {
+		t.Errorf("NextAt() = %v after Postpone, want %v", got, want)
+	}
+	if !tm.Advance(3 * steppedtime.Second) {
+		t.Errorf("Advance() = false for an active timer")
+	}
+	select {
+	case now := <-tm.C():
+		if now != 0 {
+			t.Errorf("Timer fired at %v, want at once", now)
+		}
+	case <-stdtime.After(stdtime.Second):
+		t.Fatalf("Timer did not fire once advanced past its time")
+	}
+	if tm.Postpone(steppedtime.Second) {
+		t.Errorf("Postpone() = true for an expired timer")
+	}
+}
+
+func TestResumePolicy(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	c.SetResumePolicy(ResumeOnce, 10*steppedtime.Second)
+	c.Start()
+	tk := c.NewBufferedTicker(steppedtime.Second, 100)
+	defer tk.Stop()
+
+	ref.Step(steppedtime.Hour)
+	select {
+	case <-tk.C():
+	case <-stdtime.After(stdtime.Second):
+		t.Fatalf("Ticker did not fire after a gap")
+	}
+	c.NextAt() // Wait out the firing
+	if n := len(tk.C()); n != 0 {
+		t.Errorf("Ticker fired %d more times after a gap, want once", n)
+	}
+	if n := tk.Missed(); n != 3599 {
+		t.Errorf("Missed() = %d after a gap, want 3599", n)
+	}
+	tk.Stop()
+
+	c.SetResumePolicy(ResumePause, 10*steppedtime.Second)
+	start := c.Now()
+	tm := c.NewTimer(5 * steppedtime.Second)
+	later := c.NewTimer(10 * steppedtime.Second)
+	defer later.Stop()
+	ref.Step(steppedtime.Hour)
+	select {
+	case now := <-tm.C():
+		if want := start.Add(5 * steppedtime.Second); now != want {
+			t.Errorf("Timer fired at %v after a paused gap, want %v", now, want)
+		}
+	case <-stdtime.After(stdtime.Second):
+		t.Fatalf("Timer did not fire after a gap")
+	}
+	if got, want := c.NextAt(), start.Add(10*steppedtime.Second); got != want {
+		t.Errorf("NextAt() = %v after a paused gap, want %v", got, want)
+	}
+	if got, want := c.Now(), start.Add(5*steppedtime.Second); got != want {
+		t.Errorf("Now() = %v after a paused gap, want %v", got, want)
+	}
+}
+
+func TestScalingCarryWaker(t *testing.T) {
+	ref := steppedtime.NewClock()
+	ref.SetCallbackLimit(1) // Run wakers one at a time, in order
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0/3)
+	c.Start()
+	// Leave a remainder of most of a nanosecond carried toward the timer
+	ref.Step(2 * steppedtime.Nanosecond)
+	c.Start() // Syncs with the reference
+	tm := c.NewTimer(steppedtime.Nanosecond)
+	for i := 0; i < 6; i++ {
+		// Any waker due by then runs before this
+		after := make(chan struct{})
+		ref.AfterFunc(steppedtime.Nanosecond, func() { close(after) })
+		ref.Step(steppedtime.Nanosecond)
+		<-after
+		select {
+		case now := <-tm.C():
+			if i < 1 || i > 2 {
+				t.Errorf("timer fired after %dns of the reference, want 1 or 2", i+1)
+			}
+			if now != 1 {
+				t.Errorf("timer fired at %v, want 1", now)
+			}
+			return
+		default:
+		}
+	}
+	t.Fatalf("timer did not fire")
+}
+
+// Test that Timers and Tickers are stopped once their channels are
+// unreachable, but not while a goroutine is still waiting on one.
+func TestCollectUnreferenced(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	c.SetCollectUnreferenced(true)
+	pending := func() int { return len(c.PendingTimers()) }
+
+	got := make(chan steppedtime.Time)
+	go func() { got <- <-c.NewTimer(steppedtime.Second).C() }()
+	for pending() == 0 {
+		runtime.Gosched()
+	}
+	for i := 0; i < 10; i++ {
+		c.After(steppedtime.Second)
+		c.Tick(steppedtime.Second)
+	}
+	for i := 0; i < 1000 && pending() > 1; i++ {
+		runtime.GC()
+		runtime.Gosched()
+	}
+	if n := pending(); n != 1 {
+		t.Fatalf("%d timers pending after collection, want 1", n)
+	}
+
+	c.Step(steppedtime.Second)
+	if when := <-got; when != steppedtime.Time(steppedtime.Second) {
+		t.Errorf("timer fired at %v, want %v", when, steppedtime.Time(steppedtime.Second))
+	}
+}
+
+func TestCancelTag(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	tm := c.NewTimer(steppedtime.Second)
+	tm.SetTag("x")
+	tm.Pause()
+	slow := c.NewTicker(steppedtime.Second)
+	slow.SetTag("x")
+	c.Step(steppedtime.Second) // Nobody receives, so the ticker is left sending
+	c.NewTimer(steppedtime.Second).SetTag("y")
+
+	if got, want := c.TimersByTag("x"), []steppedtime.Time{steppedtime.Time(2 * steppedtime.Second)}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("TimersByTag() = %v, want %v", got, want)
+	}
+	if n := c.CancelTag("x"); n != 2 {
+		t.Errorf("CancelTag() = %d with a paused timer and a ticker sending, want 2", n)
+	}
+	if tm.Resume() {
+		t.Errorf("Resume() = true after CancelTag")
+	}
+	<-slow.C()
+	if n := len(c.PendingTimers()); n != 1 {
+		t.Errorf("%d timers pending after CancelTag, want 1", n)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetTag() accepted a non-comparable tag")
+		}
+	}()
+	tm.SetTag(map[string]int{})
+}
+
+func BenchmarkParallelNewTimer(b *testing.B) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	c.Start()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			c.NewTimer(steppedtime.Hour).Stop()
+		}
+	})
+}
+
+func BenchmarkStep(b *testing.B) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	c.Start()
+	for i := 0; i < 1000; i++ {
+		defer c.NewTimer(steppedtime.Duration(i+1) * steppedtime.Hour).Stop()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Step(steppedtime.Nanosecond)
+	}
+}
+
+// Compare the lock striping of timer queues across shards, which spares
+// parallel NewTimer calls from contending for one lock, with its cost to
+// Step, which takes every shard's lock.
+func BenchmarkShards(b *testing.B) {
+	for _, n := range []int{1, 4, 16} {
+		// The number of shards is GOMAXPROCS as of construction
+		prev := runtime.GOMAXPROCS(n)
+		ref := steppedtime.NewClock()
+		c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+		runtime.GOMAXPROCS(prev)
+		c.Start()
+		for i := 0; i < 1000; i++ {
+			defer c.NewTimer(steppedtime.Duration(i+1) * steppedtime.Hour).Stop()
+		}
+
+		b.Run(fmt.Sprintf("NewTimer/shards=%d", n), func(b *testing.B) {
+			b.SetParallelism(4)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					c.NewTimer(steppedtime.Hour).Stop()
+				}
+			})
+		})
+		b.Run(fmt.Sprintf("Step/shards=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c.Step(steppedtime.Nanosecond)
+			}
+		})
+	}
+}
+
+func TestISODuration(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	const s = "P200DT0.000000001S"
+	want := 200*24*steppedtime.Hour + steppedtime.Nanosecond
+	if d, err := c.ParseISODuration(s); err != nil || d != want {
+		t.Errorf("ParseISODuration(%q) = %v, %v; want %v, nil", s, d, err, want)
+	}
+	if got := c.FormatISODuration(want); got != s {
+		t.Errorf("FormatISODuration(%v) = %q, want %q", want, got, s)
+	}
+	if _, err := c.ParseISODuration("P1Y"); err == nil {
+		t.Errorf("ParseISODuration(%q) succeeded, want an error", "P1Y")
+	}
+}
+
+func TestDurationOf(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	if got, want := c.DurationOf(1, steppedtime.Hour, 30, steppedtime.Minute), 90*steppedtime.Minute; got != want {
+		t.Errorf("DurationOf(1, Hour, 30, Minute) = %v, want %v", got, want)
+	}
+	if got, want := c.MustParseDuration("1h30m"), 90*steppedtime.Minute; got != want {
+		t.Errorf("MustParseDuration(%q) = %v, want %v", "1h30m", got, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("DurationOf did not panic on overflow")
+		}
+	}()
+	c.DurationOf(1<<40, steppedtime.Hour)
+}
+
+func TestReceive(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	tk := c.NewTicker(steppedtime.Second)
+	defer tk.Stop()
+
+	// Received at once, so the ticker keeps its phase
+	recv := c.Receive(tk.C())
+	c.Seek(steppedtime.Time(3 * steppedtime.Second / 2))
+	if v, ok := recv(); !ok || v != steppedtime.Time(steppedtime.Second) {
+		t.Errorf("recv() = %v, %v, want %v, true", v, ok, steppedtime.Time(steppedtime.Second))
+	}
+	if next := c.NextAt(); next != steppedtime.Time(2*steppedtime.Second) {
+		t.Errorf("NextAt() = %v after receiving, want %v", next, steppedtime.Time(2*steppedtime.Second))
+	}
+	recv = c.Receive(tk.C())
+	if _, ok := recv(); ok {
+		t.Errorf("recv() = _, true with nothing sent")
+	}
+
+	// A buffered ticker's tick is still on its way when the step returns
+	btk := c.NewBufferedTicker(steppedtime.Second, 0)
+	defer btk.Stop()
+	recv = c.Receive(btk.C())
+	c.Step(steppedtime.Second)
+	if v, ok := recv(); !ok || v != steppedtime.Time(5*steppedtime.Second/2) {
+		t.Errorf("recv() = %v, %v from a buffered ticker, want %v, true", v, ok, steppedtime.Time(5*steppedtime.Second/2))
+	}
+}
+
+func TestTickFunc(t *testing.T) {
+	ref := steppedtime.NewClock()
+	c := NewClock[steppedtime.Time, steppedtime.Duration, *steppedtime.Timer](ref, 0, 1.0)
+	c.SetCallbackLimit(1) // Run callbacks one at a time, in order
+	calls := make(chan string, 3)
+	tk := c.TickFunc(steppedtime.Second, func() { calls <- "tick" })
+	if tk.C() != nil {
+		t.Errorf("TickFunc returned a Ticker with a channel")
+	}
+
+	for i := 0; i < 3; i++ {
+		c.Step(steppedtime.Second)
+		if got := <-calls; got != "tick" {
+			t.Fatalf("got %q at tick %d", got, i)
+		}
+	}
+	tk.Stop()
+	c.Step(steppedtime.Second)
+	// Any call of f after Stop would come before this one
+	c.AfterFunc(steppedtime.Second, func() { calls <- "after" })
+	c.Step(steppedtime.Second)
+	if got := <-calls; got != "after" {
+		t.Errorf("f called after Stop")
+	}
+}
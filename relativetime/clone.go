@@ -0,0 +1,66 @@
+package relativetime
+
+// Clone returns a new Clock tracking the same reference as c, with the same
+// time, scale, and state, active or stopped, but otherwise independent of
+// it, as when simulating what would follow from different choices: changes
+// to either no longer affect the other. Settings made by SetMinWake,
+// SetCollectUnreferenced, SetUnbufferedTimers, and SetCallbackLimit are
+// carried over, but a hook set by SetWakeHook, which may report on whatever
+// observes c, and latency recording are not. The new Clock has no Timers
+// or Tickers, unless periodic is true, in which case each pending Ticker
+// created by TickFunc is copied, calling the same function with the same
+// period, phase, and tag on the new Clock. Other Tickers deliver to
+// channels held by users of c, so are never copied.
+func (c *Clock[T, D, RT]) Clone(periodic bool) *Clock[T, D, RT] {
+	ref := c.keeper.ref
+	c.keeper.Lock()
+	c.keeper.sync()
+	p, minWake := c.keeper.syncPoint, c.keeper.minWake
+	c.keeper.Unlock()
+
+	n := NewClock[T, D, RT](ref, p.now, p.scale)
+	n.keeper.syncPoint, n.keeper.minWake = p, minWake
+	for _, w := range n.wakers {
+		w.syncPoint, w.minWake = p, minWake
+	}
+	n.uptime = uptime[T, D]{origin: p.now, since: p.rNow, marks: [2]T{p.rNow, p.rNow}}
+	n.keeper.Lock()
+	n.publish()
+	n.keeper.Unlock()
+	n.collect.Store(c.collect.Load())
+	n.unbuffer.Store(c.unbuffer.Load())
+	n.run.SetLimit(c.run.Limit())
+
+	if periodic {
+		for _, w := range c.wakers {
+			w.RLock()
+			for _, t := range w.queue {
+				if t.fn != nil {
+					n.copyTicker(t)
+				}
+			}
+			w.RUnlock()
+		}
+	}
+	return n
+}
+
+// Schedule a copy of t, a Ticker created by TickFunc on another Clock.
+func (c *Clock[T, D, RT]) copyTicker(t *timer[T, D]) {
+	fn := t.fn
+	w := c.acquire()
+	tm := &timer[T, D]{
+		f:      func(T) { c.run.Go(fn) },
+		fn:     fn,
+		when:   t.when,
+		period: t.period,
+		tag:    t.tag,
+	}
+	w.sync()
+	w.schedule(tm)
+	if tm.index == 0 {
+		w.resetWaker()
+	}
+	w.fireIfDue(tm)
+	w.Unlock()
+}
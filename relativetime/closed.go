@@ -0,0 +1,88 @@
+package relativetime
+
+// Report whether the Clock has been closed, after which nothing scheduled
+// fires.
+func (c *clock[T, D, RT]) isClosed() bool {
+	return c.parent.closed.Load()
+}
+
+// TryNewTimer is like NewTimer, but returns ErrClosed if c is closed, as the
+// timer would never fire.
+func (c *Clock[T, D, RT]) TryNewTimer(d D) (*Timer[T, D], error) {
+	t := c.newTimer(d)
+	if c.closed.Load() {
+		return nil, ErrClosed
+	}
+	return t, nil
+}
+
+// TryAfterFunc is like AfterFunc, but returns ErrClosed if c is closed, as f
+// would never be called.
+func (c *Clock[T, D, RT]) TryAfterFunc(d D, f func()) (*Timer[T, D], error) {
+	t := c.AfterFunc(d, f)
+	if c.closed.Load() {
+		return nil, ErrClosed
+	}
+	return t, nil
+}
+
+// TryNewTicker is like NewTicker, but returns ErrClosed if c is closed, as
+// the ticker would never tick.
+func (c *Clock[T, D, RT]) TryNewTicker(d D) (*Ticker[T, D], error) {
+	t := c.NewTicker(d)
+	if c.closed.Load() {
+		return nil, ErrClosed
+	}
+	return t, nil
+}
+
+// TryTickFunc is like TickFunc, but returns ErrClosed if c is closed, as f
+// would never be called.
+func (c *Clock[T, D, RT]) TryTickFunc(d D, f func()) (*Ticker[T, D], error) {
+	t := c.TickFunc(d, f)
+	if c.closed.Load() {
+		return nil, ErrClosed
+	}
+	return t, nil
+}
+
+// TryReset is like Reset, but returns ErrClosed if the timer's Clock is
+// closed, as the timer would never fire.
+func (t *Timer[T, D]) TryReset(d D) (active bool, err error) {
+	active = t.Reset(d)
+	if t.s.isClosed() {
+		err = ErrClosed
+	}
+	return
+}
+
+// TryResume is like Resume, but returns ErrClosed if the timer's Clock is
+// closed, as the timer would never fire.
+func (t *Timer[T, D]) TryResume() (resumed bool, err error) {
+	resumed = t.Resume()
+	if t.s.isClosed() {
+		err = ErrClosed
+	}
+	return
+}
+
+// TryReset is like Reset, but returns ErrClosed if the ticker's Clock is
+// closed, as the ticker would never tick. The duration d must be greater
+// than zero; if not, TryReset will panic.
+func (t *Ticker[T, D]) TryReset(d D) error {
+	t.Reset(d)
+	if t.s.isClosed() {
+		return ErrClosed
+	}
+	return nil
+}
+
+// TryResume is like Resume, but returns ErrClosed if the ticker's Clock is
+// closed, as the ticker would never tick.
+func (t *Ticker[T, D]) TryResume() error {
+	t.Resume()
+	if t.s.isClosed() {
+		return ErrClosed
+	}
+	return nil
+}
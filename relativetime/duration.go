@@ -0,0 +1,108 @@
+package relativetime
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/noodlebox/clock/internal/durations"
+)
+
+// ParseDuration parses a duration string, as [time.ParseDuration] does. The
+// result is exact if D is [time.Duration], and otherwise built on Seconds.
+func (c *Clock[T, D, RT]) ParseDuration(s string) (D, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		var zero D
+		return zero, err
+	}
+	return c.fromStd(d), nil
+}
+
+// MustParseDuration is like ParseDuration, but panics if s cannot be parsed.
+func (c *Clock[T, D, RT]) MustParseDuration(s string) D {
+	return c.fromStd(durations.MustParse(s))
+}
+
+// DurationOf returns the sum of the given counts of units, written as
+// alternating pairs of a count and a unit, such as DurationOf(1, Hour, 30,
+// Minute). Counts are read as numbers of whatever D counts, as a count of
+// 1 is a single nanosecond for [time.Duration], so D must be an integer or
+// floating point type. It panics if not, if given an odd number of values,
+// or if the sum overflows.
+func (c *Clock[T, D, RT]) DurationOf(pairs ...D) D {
+	if len(pairs)%2 != 0 {
+		panic("DurationOf: count without a unit")
+	}
+	var sum D
+	v := reflect.ValueOf(&sum).Elem()
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		ints := make([]time.Duration, len(pairs))
+		for i, d := range pairs {
+			ints[i] = time.Duration(reflect.ValueOf(d).Int())
+		}
+		n := int64(durations.Of(ints...))
+		if v.OverflowInt(n) {
+			panic("DurationOf: duration out of range")
+		}
+		v.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		for i := 0; i < len(pairs); i += 2 {
+			f += reflect.ValueOf(pairs[i]).Float() * reflect.ValueOf(pairs[i+1]).Float()
+		}
+		if math.IsInf(f, 0) || v.OverflowFloat(f) {
+			panic("DurationOf: duration out of range")
+		}
+		v.SetFloat(f)
+	default:
+		panic("DurationOf: Duration type is not a number")
+	}
+	return sum
+}
+
+// ParseISODuration parses an ISO 8601 duration, such as "P1DT2H30M" or
+// "PT0.5S", optionally preceded by a sign. Days are taken as 24 hours and
+// weeks as 7 days; years and months, whose lengths vary, are rejected. The
+// result is exact if D is [time.Duration], and otherwise built on Seconds.
+func (c *Clock[T, D, RT]) ParseISODuration(s string) (D, error) {
+	d, err := durations.ParseISO(s)
+	if err != nil {
+		var zero D
+		return zero, err
+	}
+	return c.fromStd(d), nil
+}
+
+// FormatISODuration formats d as an ISO 8601 duration, such as "P1DT2H30M",
+// as accepted by ParseISODuration. It is exact if D is [time.Duration], and
+// otherwise rounded to the nearest nanosecond.
+func (c *Clock[T, D, RT]) FormatISODuration(d D) string {
+	return durations.FormatISO(toStd(d))
+}
+
+// Convert d to a D, exactly if D is time.Duration, as for every Clock in
+// this module, or else by way of Seconds.
+func (c *Clock[T, D, RT]) fromStd(d time.Duration) D {
+	if v, ok := any(d).(D); ok {
+		return v
+	}
+	return c.Seconds(d.Seconds())
+}
+
+// Convert d to a time.Duration, exactly if D is time.Duration, or else by
+// way of Seconds, rounded to the nearest nanosecond and clamped to range.
+func toStd[D Duration](d D) time.Duration {
+	if v, ok := any(d).(time.Duration); ok {
+		return v
+	}
+	ns := math.Round(d.Seconds() * 1e9)
+	switch {
+	case ns >= math.MaxInt64:
+		return math.MaxInt64
+	case ns <= math.MinInt64:
+		return math.MinInt64
+	}
+	return time.Duration(ns)
+}
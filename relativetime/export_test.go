@@ -0,0 +1,7 @@
+package relativetime
+
+// Hooks into the internals of the package, for its tests only.
+
+func (c *Clock[T, D, RT]) Receive(ch <-chan T) (recv func() (v T, ok bool)) {
+	return c.receive(ch)
+}
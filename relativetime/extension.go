@@ -0,0 +1,125 @@
+package relativetime
+
+// Scheduler is the low-level interface through which Timers and Tickers are
+// scheduled on a Clock, exported so that other packages may build their own
+// Timer-like primitives, such as debouncers or deadline queues, directly on
+// its queue. Each Clock keeps several Schedulers, one per shard, and each
+// Event belongs to the one returned by its Scheduler method.
+//
+// Every method but Lock must be called while holding the lock, which also
+// guards any Event belonging to the Scheduler. A typical use, equivalent to
+// AfterFunc, is:
+//
+//	s := e.Scheduler()
+//	s.Lock()
+//	e.SetWhen(s.Sync().Add(d))
+//	s.Schedule(e)
+//	s.ResetWaker()
+//	s.Unlock()
+type Scheduler[T Time[T, D], D Duration] interface {
+	// Lock and Unlock guard the Scheduler and its Events.
+	Lock()
+	Unlock()
+
+	// Sync brings the Scheduler up to date with the reference clock and
+	// returns the current local time.
+	Sync() T
+
+	// Schedule adds e to the queue, to fire at its time. If that is not
+	// after the time of the last Sync, e fires at once. It has no effect
+	// on an Event that is already scheduled, or once the Clock is closed.
+	Schedule(e *Event[T, D])
+
+	// Unschedule removes e from the queue, and reports whether it was
+	// scheduled.
+	Unschedule(e *Event[T, D]) bool
+
+	// Reschedule moves e to its time, after a change by SetWhen, scheduling
+	// it if it was not. As with Schedule, e fires at once if already due.
+	Reschedule(e *Event[T, D])
+
+	// ResetWaker arms the timer on the reference clock for the earliest
+	// Event in the queue. It should be called after any change to the
+	// queue that may have changed which Event is first.
+	ResetWaker()
+}
+
+// Event is a custom event scheduled on a Clock through a Scheduler. When it
+// fires, its function is called with the current local time while the
+// Scheduler is locked, so it must not call back into the Clock, its
+// Scheduler, or any Timer or Ticker; any real work should instead be handed
+// off, as to a new goroutine or a buffered channel. An Event fires once for
+// each time it is scheduled.
+type Event[T Time[T, D], D Duration] struct {
+	t timer[T, D]
+	s Scheduler[T, D]
+}
+
+// NewEvent returns a new Event, not yet scheduled, that calls f whenever it
+// fires.
+func (c *Clock[T, D, RT]) NewEvent(f func(now T)) *Event[T, D] {
+	w := c.acquire()
+	w.Unlock()
+	return &Event[T, D]{t: timer[T, D]{f: f, index: -1}, s: w}
+}
+
+// Scheduler returns the Scheduler on which e may be scheduled.
+func (e *Event[T, D]) Scheduler() Scheduler[T, D] {
+	return e.s
+}
+
+// When returns the time at which e is set to fire. Callers must hold the
+// Scheduler's lock.
+func (e *Event[T, D]) When() T {
+	return e.t.when
+}
+
+// SetWhen sets the time at which e is to fire. If e is already scheduled,
+// it must then be passed to Reschedule. Callers must hold the Scheduler's
+// lock.
+func (e *Event[T, D]) SetWhen(when T) {
+	e.t.when = when
+}
+
+// Scheduled reports whether e is waiting to fire. Callers must hold the
+// Scheduler's lock.
+func (e *Event[T, D]) Scheduled() bool {
+	return e.t.index >= 0
+}
+
+// TickID returns the TickID of the last time e fired, or zero if it has
+// not. Callers must hold the Scheduler's lock.
+func (e *Event[T, D]) TickID() TickID {
+	return e.t.id
+}
+
+// Each shard of a Clock serves as the Scheduler for the Events it holds.
+
+func (c *clock[T, D, RT]) Sync() T {
+	return c.sync()
+}
+
+func (c *clock[T, D, RT]) Schedule(e *Event[T, D]) {
+	if e.t.index >= 0 {
+		return
+	}
+	c.schedule(&e.t)
+	c.fireIfDue(&e.t)
+}
+
+func (c *clock[T, D, RT]) Unschedule(e *Event[T, D]) bool {
+	if e.t.index < 0 {
+		return false
+	}
+	c.unschedule(&e.t)
+	return true
+}
+
+func (c *clock[T, D, RT]) Reschedule(e *Event[T, D]) {
+	c.reschedule(&e.t)
+	c.fireIfDue(&e.t)
+}
+
+func (c *clock[T, D, RT]) ResetWaker() {
+	c.resetWaker()
+}
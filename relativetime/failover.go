@@ -0,0 +1,152 @@
+package relativetime
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// Failover is a reference clock which tracks a primary reference, falling
+// over to a secondary one when the primary stalls or jumps, and returning to
+// the primary once it has recovered. Its time stays continuous throughout,
+// in the timeline of the primary as it was when created, advancing at the
+// rate of whichever reference is healthy. It may serve as the reference for
+// a Clock, such as for embedded systems with an unreliable real-time clock.
+//
+// The references are compared whenever Now is called. The primary is judged
+// faulty once the time elapsed on it differs from that on the secondary by
+// more than a tolerance, and recovered once it has advanced by a recovery
+// period in step with the secondary. While healthy, the comparison restarts
+// after each recovery period, so the two should advance at about the same
+// rate: drift within the tolerance over a recovery period is harmless.
+type Failover[T Time[T, D], D Duration, RT1 RTimer[D], RT2 RTimer[D]] struct {
+	primary   RClock[T, D, RT1]
+	secondary RClock[T, D, RT2]
+	tolerance float64 // In seconds
+	recovery  float64 // In seconds
+
+	now    T    // Time last returned by Now
+	out    T    // Time at the last change of reference
+	p, s   T    // Readings of each reference at the last change
+	backup bool // Whether tracking the secondary
+
+	mu sync.Mutex
+}
+
+// NewFailover returns a Failover tracking primary, falling over to secondary
+// once they disagree by more than tolerance, and returning once primary has
+// advanced by recovery in step with secondary.
+func NewFailover[T Time[T, D], D Duration, RT1 RTimer[D], RT2 RTimer[D]](primary RClock[T, D, RT1], secondary RClock[T, D, RT2], tolerance, recovery D) *Failover[T, D, RT1, RT2] {
+	p := primary.Now()
+	return &Failover[T, D, RT1, RT2]{
+		primary:   primary,
+		secondary: secondary,
+		tolerance: tolerance.Seconds(),
+		recovery:  recovery.Seconds(),
+		now:       p,
+		out:       p,
+		p:         p,
+		s:         secondary.Now(),
+	}
+}
+
+// Now returns the current time, as tracked on whichever reference is
+// healthy. It never returns a time before one it returned previously.
+func (f *Failover[T, D, RT1, RT2]) Now() T {
+	p, s := f.primary.Now(), f.secondary.Now()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	dp, ds := p.Sub(f.p), s.Sub(f.s)
+	agree := math.Abs(dp.Seconds()-ds.Seconds()) <= f.tolerance
+
+	now := f.out.Add(dp)
+	if f.backup || !agree {
+		now = f.out.Add(ds)
+	}
+	if now.Before(f.now) {
+		now = f.now
+	}
+	f.now = now
+
+	switch {
+	case !agree:
+		// Faulty, so gather evidence of recovery afresh
+		f.backup = true
+	case dp.Seconds() >= f.recovery:
+		// Healthy, or recovered, so restart the comparison
+		f.backup = false
+	default:
+		return now
+	}
+	f.out, f.p, f.s = now, p, s
+	return now
+}
+
+// Seconds returns a Duration value representing n seconds.
+func (f *Failover[T, D, RT1, RT2]) Seconds(n float64) D {
+	return f.primary.Seconds(n)
+}
+
+// UsingSecondary reports whether the secondary reference is being tracked,
+// as of the last call to Now.
+func (f *Failover[T, D, RT1, RT2]) UsingSecondary() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.backup
+}
+
+// AfterFunc waits for the duration to elapse on either reference, whichever
+// is first, and then calls fn. Scheduling on both ensures that a stalled
+// reference cannot hold it back, though one that jumps ahead may call it
+// early, which a Clock tolerates by checking the time again as it wakes.
+func (f *Failover[T, D, RT1, RT2]) AfterFunc(d D, fn func()) *FailoverTimer[D] {
+	t := &FailoverTimer[D]{}
+	t.armed.Store(true)
+	t.mu.Lock()
+	t.p = f.primary.AfterFunc(d, func() { t.fire(false, fn) })
+	t.s = f.secondary.AfterFunc(d, func() { t.fire(true, fn) })
+	t.mu.Unlock()
+	return t
+}
+
+// FailoverTimer is a timer created by Failover.AfterFunc, scheduled on both
+// of its references.
+type FailoverTimer[D Duration] struct {
+	p, s  RTimer[D]
+	armed atomic.Bool // Cleared once either reference fires
+
+	mu sync.Mutex // Held while p and s are created
+}
+
+// Call fn, unless the other reference already has, and stop the other.
+func (t *FailoverTimer[D]) fire(secondary bool, fn func()) {
+	t.mu.Lock()
+	other := t.s
+	if secondary {
+		other = t.p
+	}
+	t.mu.Unlock()
+	if t.armed.CompareAndSwap(true, false) {
+		other.Stop()
+		fn()
+	}
+}
+
+// Reset changes the timer to expire after duration d on either reference.
+// It returns true if the timer had been active.
+func (t *FailoverTimer[D]) Reset(d D) bool {
+	active := t.armed.Swap(true)
+	t.p.Reset(d)
+	t.s.Reset(d)
+	return active
+}
+
+// Stop prevents the timer from firing. It returns true if the call stops
+// the timer.
+func (t *FailoverTimer[D]) Stop() bool {
+	active := t.armed.Swap(false)
+	t.p.Stop()
+	t.s.Stop()
+	return active
+}
@@ -0,0 +1,116 @@
+package relativetime
+
+import (
+	"sync"
+	"time"
+)
+
+// Health is a report on the reference clock of a Clock, as made by a
+// Monitor.
+type Health[T any] struct {
+	At      time.Time // System time of the check
+	RefNow  T         // Time on the reference at the check
+	Stalled bool      // The reference fell behind system time
+	Jumped  bool      // The reference moved back, or ran ahead of system time
+	Overdue bool      // A waker was due on the reference, but did not fire
+}
+
+// Healthy reports whether h found no fault.
+func (h Health[T]) Healthy() bool {
+	return !h.Stalled && !h.Jumped && !h.Overdue
+}
+
+// Monitor watches the reference clock of a Clock for faults that would
+// otherwise silently hold back or hurry its Timers and Tickers. It must be
+// created with Clock.MonitorReference.
+type Monitor[T Time[T, D], D Duration, RT RTimer[D]] struct {
+	c         *Clock[T, D, RT]
+	tolerance float64 // In seconds
+	f         func(Health[T])
+	last      Health[T]
+	done      chan struct{}
+	stop      sync.Once
+
+	mu sync.Mutex // Protects last
+}
+
+// MonitorReference starts watching the reference clock of c, checking it
+// against the system's monotonic clock every interval. A check finds the
+// reference stalled if it advanced by more than tolerance less than system
+// time since the last check, and jumped if it moved back or advanced by
+// more than tolerance beyond it, so the reference is assumed to run at the
+// rate of real time. Either way, a check also finds a waker overdue if time
+// on the reference passed that at which it was armed to fire by more than
+// tolerance without it doing so. If f is not nil, it is called with the
+// result of the first check, and of each check that changes whether the
+// reference is healthy, from the goroutine making them. Stop the Monitor to
+// release associated resources.
+func (c *Clock[T, D, RT]) MonitorReference(interval time.Duration, tolerance D, f func(Health[T])) *Monitor[T, D, RT] {
+	m := &Monitor[T, D, RT]{
+		c:         c,
+		tolerance: tolerance.Seconds(),
+		f:         f,
+		done:      make(chan struct{}),
+	}
+	m.last = Health[T]{At: time.Now(), RefNow: c.keeper.ref.Now()}
+	go m.run(interval)
+	return m
+}
+
+func (m *Monitor[T, D, RT]) run(interval time.Duration) {
+	tk := time.NewTicker(interval)
+	defer tk.Stop()
+	first := true
+	for {
+		select {
+		case <-tk.C:
+		case <-m.done:
+			return
+		}
+		m.mu.Lock()
+		prev := m.last
+		h := m.check(prev)
+		m.last = h
+		m.mu.Unlock()
+		if m.f != nil && (first || h.Healthy() != prev.Healthy()) {
+			m.f(h)
+		}
+		first = false
+	}
+}
+
+// Check the reference against system time since prev.
+func (m *Monitor[T, D, RT]) check(prev Health[T]) Health[T] {
+	h := Health[T]{At: time.Now(), RefNow: m.c.keeper.ref.Now()}
+	dRef := h.RefNow.Sub(prev.RefNow).Seconds()
+	drift := dRef - h.At.Sub(prev.At).Seconds()
+	h.Stalled = drift < -m.tolerance
+	h.Jumped = dRef < 0 || drift > m.tolerance
+	for _, w := range m.c.wakers {
+		w.RLock()
+		if w.waker != nil && !w.wakeRef.IsZero() && h.RefNow.Sub(w.wakeRef).Seconds() > m.tolerance {
+			h.Overdue = true
+		}
+		w.RUnlock()
+	}
+	return h
+}
+
+// Healthy reports whether the last check found no fault. It is true until
+// the first check is made.
+func (m *Monitor[T, D, RT]) Healthy() bool {
+	return m.Last().Healthy()
+}
+
+// Last returns the result of the last check made.
+func (m *Monitor[T, D, RT]) Last() Health[T] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last
+}
+
+// Stop stops the Monitor from making further checks. One already in
+// progress may still complete, and call f.
+func (m *Monitor[T, D, RT]) Stop() {
+	m.stop.Do(func() { close(m.done) })
+}
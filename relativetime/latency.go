@@ -0,0 +1,98 @@
+package relativetime
+
+import (
+	"sort"
+	"sync"
+)
+
+// Histogram is a snapshot of the latencies with which a Clock triggered its
+// Timers and Tickers, as recorded after a call to StartLatencyHistogram. The
+// latency of each firing is the local time at which it was triggered (its
+// value sent, or its function started) less the local time at which it was
+// due. Firings that fall behind due to a late waker on the reference clock,
+// or to stepping past them, have a positive latency; those due exactly when
+// triggered, as with Seek, count in the first bucket.
+type Histogram[D Duration] struct {
+	Bounds []D      // Upper bound of each bucket but the last, ascending
+	Counts []uint64 // Firings in each bucket, with one more than Bounds
+	Total  uint64   // Firings recorded
+	Mean   D
+	Max    D
+}
+
+// Default number of buckets, with bounds doubling from one microsecond.
+const latencyBuckets = 24
+
+// latency accumulates a Histogram, in seconds.
+type latency struct {
+	bounds []float64
+	counts []uint64
+	total  uint64
+	sum    float64
+	max    float64
+
+	mu sync.Mutex // Shared by all wakers
+}
+
+func (h *latency) record(seconds float64) {
+	i := sort.SearchFloat64s(h.bounds, seconds)
+	h.mu.Lock()
+	h.counts[i]++
+	h.total++
+	h.sum += seconds
+	if seconds > h.max {
+		h.max = seconds
+	}
+	h.mu.Unlock()
+}
+
+// StartLatencyHistogram begins recording the latency of every Timer and
+// Ticker firing on the Clock, discarding any recorded previously. Each is
+// counted in the first bucket whose upper bound, given in ascending order by
+// bounds, is at least its latency, or in a last unbounded one. If no bounds
+// are given, they double from one microsecond over 24 buckets. Recording
+// adds a little to the cost of each firing, so it is disabled by default.
+func (c *Clock[T, D, RT]) StartLatencyHistogram(bounds ...D) {
+	h := &latency{}
+	if len(bounds) == 0 {
+		for b := 1e-6; len(h.bounds) < latencyBuckets-1; b *= 2 {
+			h.bounds = append(h.bounds, b)
+		}
+	} else {
+		for _, b := range bounds {
+			h.bounds = append(h.bounds, b.Seconds())
+		}
+	}
+	h.counts = make([]uint64, len(h.bounds)+1)
+	c.latency.Store(h)
+	c.recording.Store(true)
+}
+
+// StopLatencyHistogram stops recording latencies. The Histogram recorded so
+// far remains available from LatencyHistogram until recording is started
+// again.
+func (c *Clock[T, D, RT]) StopLatencyHistogram() {
+	c.recording.Store(false)
+}
+
+// LatencyHistogram returns a snapshot of the latencies recorded since
+// StartLatencyHistogram was called, and false if it never was.
+func (c *Clock[T, D, RT]) LatencyHistogram() (hist Histogram[D], ok bool) {
+	h := c.latency.Load()
+	if h == nil {
+		return hist, false
+	}
+	ref := c.keeper.ref
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, b := range h.bounds {
+		hist.Bounds = append(hist.Bounds, ref.Seconds(b))
+	}
+	hist.Counts = append([]uint64(nil), h.counts...)
+	hist.Total = h.total
+	if h.total > 0 {
+		hist.Mean = ref.Seconds(h.sum / float64(h.total))
+	}
+	hist.Max = ref.Seconds(h.max)
+	return hist, true
+}
@@ -0,0 +1,13 @@
+package relativetime
+
+// NewChild returns a new Clock set to at, using c as its reference clock
+// with a scale factor of scale, so that clocks may be nested, as for a
+// subsystem running at its own speed within a simulation that may itself be
+// paused or scaled. Time on the child passes only while it passes on c, at
+// scale times the rate. Like any Clock, the child is stopped when created.
+//
+// Any Clock satisfies RClock, and its Timers satisfy RTimer, so this is
+// only shorthand for NewClock with c as ref, sparing the type parameters.
+func (c *Clock[T, D, RT]) NewChild(at T, scale float64) *Clock[T, D, *Timer[T, D]] {
+	return NewClock[T, D, *Timer[T, D]](c, at, scale)
+}
@@ -0,0 +1,25 @@
+package relativetime
+
+// OnStop sets f to be called in its own goroutine whenever the Timer is
+// stopped before firing, whether by Stop, by CancelTag, or by closing the
+// Clock, so that resources tied to the pending work may be released
+// deterministically. It is not called when the Timer fires, nor by Stop on
+// a Timer that has already fired or been stopped. A nil f removes any
+// function set previously. A paused Timer counts as stopped before firing.
+func (t *Timer[T, D]) OnStop(f func()) {
+	if t.t == nil {
+		panic("OnStop called on uninitialized relativetime.Timer")
+	}
+
+	t.s.Lock()
+	t.t.onStop = f
+	t.s.Unlock()
+}
+
+// Call any function set by OnStop for t, which was stopped without firing.
+// Callers must hold a write lock.
+func (c *clock[T, D, RT]) cancelled(t *timer[T, D]) {
+	if t.onStop != nil {
+		c.parent.run.Go(t.onStop)
+	}
+}
@@ -0,0 +1,66 @@
+package relativetime
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Pending describes a Timer or Ticker waiting to fire, as reported by
+// PendingTimers.
+type Pending[T any, D Duration] struct {
+	When   T   // Time at which it will next fire
+	Period D   // Period of a Ticker, or zero for a Timer
+	Tag    any // Tag attached by SetTag, if any
+}
+
+// PendingTimers returns every Timer and Ticker waiting to fire, in the order
+// they will fire. Those due at the same time are listed in the order they
+// were created, so that the result does not depend on how they happen to be
+// spread over shards. Paused Timers and Tickers are not included.
+func (c *Clock[T, D, RT]) PendingTimers() []Pending[T, D] {
+	type entry struct {
+		p   Pending[T, D]
+		seq uint64
+	}
+	var es []entry
+	for _, w := range c.wakers {
+		w.RLock()
+		for _, t := range w.queue {
+			es = append(es, entry{Pending[T, D]{When: t.when, Period: t.period, Tag: t.tag}, t.seq})
+		}
+		w.RUnlock()
+	}
+	sort.Slice(es, func(i, j int) bool {
+		if a, b := es[i].p.When, es[j].p.When; !a.Equal(b) {
+			return a.Before(b)
+		}
+		return es[i].seq < es[j].seq
+	})
+	var ps []Pending[T, D]
+	for _, e := range es {
+		ps = append(ps, e.p)
+	}
+	return ps
+}
+
+// CheckQueue returns an error describing the first inconsistency found in
+// the queues of pending Timers and Tickers of c, or nil if there is none,
+// for tests such as those of package clocktest/fuzz. Each queue must be a
+// heap ordered by the time each is due, with each knowing its place in it,
+// while each held out of a queue, as while paused, must know it is not in
+// one.
+func (c *Clock[T, D, RT]) CheckQueue() error {
+	c.lockAll()
+	defer c.unlockAll()
+	for i, w := range append(c.wakers[:len(c.wakers):len(c.wakers)], c.keeper) {
+		if err := w.queue.check(); err != nil {
+			return fmt.Errorf("shard %d: %w", i, err)
+		}
+		for t := range w.held {
+			if t.index >= 0 {
+				return fmt.Errorf("shard %d: held timer recorded at %d", i, t.index)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package relativetime
+
+// Postpone moves the time at which an active timer expires later by delta,
+// in place, without the window in which a Stop followed by a Reset leaves it
+// inactive, and without recomputing its deadline from the current time. It
+// returns true if the timer was moved, false if it had already expired or
+// been stopped. A paused timer has its remaining time extended instead. A
+// negative delta moves it earlier, as with Advance.
+func (t *Timer[T, D]) Postpone(delta D) (active bool) {
+	if t.t == nil {
+		panic("Postpone called on uninitialized relativetime.Timer")
+	}
+
+	t.s.Lock()
+	if t.t.paused {
+		t.t.remaining = t.t.when.Add(t.t.remaining).Add(delta).Sub(t.t.when)
+		active = true
+	} else if t.t.index >= 0 {
+		isNext := t.t.index == 0
+		t.t.when = t.t.when.Add(delta)
+		t.s.sync()
+		t.s.reschedule(t.t)
+		t.s.fireIfDue(t.t)
+		if isNext || t.t.index == 0 {
+			t.s.resetWaker()
+		}
+		active = true
+	}
+	t.s.Unlock()
+	return
+}
+
+// Advance moves the time at which an active timer expires earlier by delta,
+// firing it at once if that time has already passed. It is equivalent to
+// Postpone with delta negated.
+func (t *Timer[T, D]) Advance(delta D) (active bool) {
+	// D need not support negation, but T gives a way around it
+	var zero T
+	return t.Postpone(zero.Sub(zero.Add(delta)))
+}
@@ -0,0 +1,86 @@
+package relativetime
+
+import (
+	"sync"
+
+	"github.com/noodlebox/clock/internal/receive"
+)
+
+// A receiver set up by receive, holding the first value it was sent.
+type receiver[T any] struct {
+	v  T
+	ok bool
+
+	mu sync.Mutex
+}
+
+// Stand ready to receive a value sent on ch by a Timer or Ticker of c, as a
+// goroutine blocked receiving from ch would be, until recv is called. Unlike
+// such a goroutine, it is ready as soon as receive returns, rather
+// than whenever the goroutine happens to be scheduled, so that a Ticker
+// created by NewTicker delivers its tick at once while the clock is moved,
+// instead of leaving it to a goroutine once a receiver is ready. Then recv
+// returns the first value sent on ch, if any: that received, or one already
+// buffered in ch, or else one still on its way, as for a Ticker created by
+// NewBufferedTicker, which recv waits for. It reports false if nothing was
+// sent. Only one receiver may be set up for ch at once.
+func (c *Clock[T, D, RT]) receive(ch <-chan T) (recv func() (v T, ok bool)) {
+	r := new(receiver[T])
+	c.receivers.Store(ch, r)
+	return func() (v T, ok bool) {
+		c.receivers.Delete(ch)
+		r.mu.Lock()
+		v, ok = r.v, r.ok
+		r.mu.Unlock()
+		if ok {
+			return v, true
+		}
+		select {
+		case v = <-ch:
+			return v, true
+		default:
+		}
+		if sent, sending := c.sending.Load(ch); sending {
+			// Unless received elsewhere, it arrives before sent is closed
+			select {
+			case v = <-ch:
+				return v, true
+			case <-sent.(chan struct{}):
+			}
+		}
+		return v, false
+	}
+}
+
+// Hand v to a receiver set up by receive for ch, if it has yet to receive
+// anything, reporting whether it did.
+func (c *Clock[T, D, RT]) received(ch <-chan T, v T) bool {
+	p, ok := c.receivers.Load(ch)
+	if !ok {
+		return false
+	}
+	r := p.(*receiver[T])
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ok {
+		return false
+	}
+	r.v, r.ok = v, true
+	return true
+}
+
+// Implemented by every Clock, for receive.Ready to reach one of unknown type
+type anyReceiver interface {
+	receiveAny(ch any) func() (any, bool)
+}
+
+func init() {
+	receive.Ready = func(c, ch any) func() (any, bool) {
+		return c.(anyReceiver).receiveAny(ch)
+	}
+}
+
+func (c *Clock[T, D, RT]) receiveAny(ch any) func() (any, bool) {
+	recv := c.receive(ch.(<-chan T))
+	return func() (any, bool) { return recv() }
+}
@@ -0,0 +1,84 @@
+package relativetime
+
+// ResumePolicy chooses how a Clock recovers when its reference clock is
+// found to have leapt forward while the process was suspended, as across a
+// laptop sleep. See SetResumePolicy.
+type ResumePolicy int
+
+const (
+	// ResumeCatchUp jumps forward over the gap, firing everything that fell
+	// due during it: every Timer, and each Ticker once, except for those
+	// created by NewBufferedTicker, which tick for every period missed.
+	// This is the default.
+	ResumeCatchUp ResumePolicy = iota
+	// ResumeOnce jumps forward over the gap, but fires each Ticker at most
+	// once for it, counting the rest as missed, so that no Ticker bursts.
+	ResumeOnce
+	// ResumePause treats the gap as if the Clock had been stopped for its
+	// duration, so that local time resumes from where it was and nothing
+	// falls due that was not already.
+	ResumePause
+)
+
+// Settings made by SetResumePolicy.
+type resumePolicy struct {
+	policy    ResumePolicy
+	threshold float64 // In seconds of reference time
+}
+
+// A stretch of reference time already treated as a gap by ResumePause.
+type resumeGap[T any] struct {
+	from, to T
+}
+
+// SetResumePolicy sets how c recovers once a waker on the reference clock
+// fires later than it was due by more than threshold, as when the process
+// was suspended, or the reference clock otherwise leapt forward. Only such a
+// late waker reveals the gap, so one passing while no timers are pending
+// goes unnoticed, and Now reflects it at once, even under ResumePause, until
+// the next timer falls due. Threshold should comfortably exceed the usual
+// scheduling delays of the reference clock, such as a second for real time.
+// The default policy is ResumeCatchUp.
+func (c *Clock[T, D, RT]) SetResumePolicy(p ResumePolicy, threshold D) {
+	c.resume.Store(&resumePolicy{policy: p, threshold: threshold.Seconds()})
+}
+
+// Return the policy to apply to a waker due at wakeRef that fired at rNow,
+// or ResumeCatchUp if it was not late enough to count as a gap.
+func (c *Clock[T, D, RT]) resumeFor(wakeRef, rNow T) ResumePolicy {
+	p := c.resume.Load()
+	if p == nil || wakeRef.IsZero() || rNow.Sub(wakeRef).Seconds() <= p.threshold {
+		return ResumeCatchUp
+	}
+	return p.policy
+}
+
+// Treat the reference time from from to to as a gap, shifting local time
+// back by the time that passed on it over the gap, except for any part
+// already treated so, as when several wakers notice the same gap.
+func (c *Clock[T, D, RT]) absorb(from, to T) {
+	c.keeper.Lock()
+	if c.gap.to.After(from) {
+		from = c.gap.to
+	}
+	if !to.After(from) {
+		c.keeper.Unlock()
+		return
+	}
+	c.gap = resumeGap[T]{from, to}
+	c.keeper.Unlock()
+
+	rNow := c.keeper.ref.Now()
+	c.syncFire(func(w *clock[T, D, RT]) {
+		// Sync up before changing setting
+		w.advanceRef(rNow)
+		if w.moving() {
+			// Carry the rounding error forward, as advanceRef does
+			exact := from.Sub(to).Seconds()*w.scale + w.carry
+			dt := w.ref.Seconds(exact)
+			w.now, w.carry = w.now.Add(dt), exact-dt.Seconds()
+		}
+		var zero T
+		w.wakeAt = zero // Force the waker to be reset
+	})
+}
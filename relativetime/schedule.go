@@ -1,10 +1,27 @@
 package relativetime
 
+import (
+	"fmt"
+)
+
 type timer[T Time[T, D], D Duration] struct {
 	f      func(T)
+	fn     func() // Function passed to TickFunc, for copying by Clone
 	when   T
 	period D
 	index  int
+	missed int  // Periods skipped or dropped, for tickers
+	exact  bool // Whether to fire for every period, even if late
+	burst  int  // Most periods an exact ticker fires for at once, if > 0
+	tag    any
+	id     TickID // Of its last firing
+	seq    uint64 // Order in which it was first scheduled, from 1
+	onStop func() // Called if stopped or cancelled before firing
+
+	dropped bool // Cancelled by Close, and yet to be reported by Stop or Reset
+
+	paused    bool // Whether held while paused, rather than queued
+	remaining D    // Time left until it fires, while paused
 }
 
 type queue[T Time[T, D], D Duration] []*timer[T, D]
@@ -16,6 +33,20 @@ func (q queue[T, D]) peek() *timer[T, D] {
 	return q[0]
 }
 
+// check returns an error describing the first timer found out of place in
+// the heap, or recorded at the wrong index, or nil if there is none.
+func (q queue[T, D]) check() error {
+	for i, t := range q {
+		if t.index != i {
+			return fmt.Errorf("timer at %d recorded at %d", i, t.index)
+		}
+		if p := (i - 1) / 4; i > 0 && q[p].when.After(t.when) {
+			return fmt.Errorf("timer at %d due at %v, before its parent at %d due at %v", i, t.when, p, q[p].when)
+		}
+	}
+	return nil
+}
+
 // Heap management
 
 // If container/heap isn't good enough for the Go runtime, then it's not good
@@ -0,0 +1,42 @@
+package relativetime
+
+// Variants of Timer and Ticker exposing their channels as a field named C,
+// matching the shape of [time.Timer] and [time.Ticker]. Being generic, these
+// are available for any instantiation of Clock, so there is no need to
+// generate wrappers for each concrete set of types. See the aliases in
+// [github.com/noodlebox/clock/mocktime] for an example.
+
+// StdTimer is a variant of [Timer] whose channel is available as the field
+// C, rather than through a method. A StdTimer must be created with
+// NewStdTimer or StdAfterFunc.
+type StdTimer[T Time[T, D], D Duration] struct {
+	C <-chan T // The channel on which the ticks are delivered.
+	*Timer[T, D]
+}
+
+// StdTicker is a variant of [Ticker] whose channel is available as the
+// field C, rather than through a method. A StdTicker must be created with
+// NewStdTicker.
+type StdTicker[T Time[T, D], D Duration] struct {
+	C <-chan T // The channel on which the ticks are delivered.
+	*Ticker[T, D]
+}
+
+// NewStdTimer is like NewTimer, but returns a StdTimer.
+func (c *Clock[T, D, RT]) NewStdTimer(d D) *StdTimer[T, D] {
+	t := c.NewTimer(d)
+	return &StdTimer[T, D]{t.c, t}
+}
+
+// StdAfterFunc is like AfterFunc, but returns a StdTimer. As with
+// [time.AfterFunc], the C field of the returned StdTimer is nil.
+func (c *Clock[T, D, RT]) StdAfterFunc(d D, f func()) *StdTimer[T, D] {
+	t := c.AfterFunc(d, f)
+	return &StdTimer[T, D]{t.c, t}
+}
+
+// NewStdTicker is like NewTicker, but returns a StdTicker.
+func (c *Clock[T, D, RT]) NewStdTicker(d D) *StdTicker[T, D] {
+	t := c.NewTicker(d)
+	return &StdTicker[T, D]{t.c, t}
+}
@@ -0,0 +1,117 @@
+package relativetime
+
+import (
+	"sort"
+)
+
+// CancelTag stops every pending Timer and Ticker with the given tag
+// attached, including those paused, as if by calling its Stop method, and
+// returns how many were stopped. A nil tag matches nothing.
+func (c *Clock[T, D, RT]) CancelTag(tag any) (n int) {
+	if tag == nil || !isComparable(tag) {
+		return 0
+	}
+
+	for _, w := range c.wakers {
+		n += w.cancelTag(tag)
+	}
+	return
+}
+
+// Stop every timer with tag attached, returning how many were stopped.
+func (c *clock[T, D, RT]) cancelTag(tag any) int {
+	c.Lock()
+	defer c.Unlock()
+	ts := c.tagged(tag)
+	for _, t := range ts {
+		c.unschedule(t)
+		c.unhold(t)
+		if t.index == -2 {
+			// Keep a pending send from rescheduling the ticker
+			t.index = -1
+		}
+		t.paused = false
+		c.cancelled(t)
+	}
+	if len(ts) > 0 {
+		c.sync()
+		c.resetWaker()
+	}
+	return len(ts)
+}
+
+// TimersByTag returns the times at which each pending Timer or Ticker with
+// the given tag attached will next fire, in order. A Ticker waiting on a
+// slow receiver is included, at the time its next tick was due when it
+// began to wait, but paused Timers and Tickers, having no time at which to
+// fire, are not. A nil tag matches nothing.
+func (c *Clock[T, D, RT]) TimersByTag(tag any) []T {
+	if tag == nil || !isComparable(tag) {
+		return nil
+	}
+
+	var whens []T
+	for _, w := range c.wakers {
+		w.RLock()
+		for _, t := range w.tagged(tag) {
+			if !t.paused {
+				whens = append(whens, t.when)
+			}
+		}
+		w.RUnlock()
+	}
+	sort.Slice(whens, func(i, j int) bool { return whens[i].Before(whens[j]) })
+	return whens
+}
+
+// Return pending timers with tag attached, whether queued or held. Callers
+// must hold at least a read lock.
+func (c *clock[T, D, RT]) tagged(tag any) (ts []*timer[T, D]) {
+	for _, t := range c.queue {
+		if t.tag == tag {
+			ts = append(ts, t)
+		}
+	}
+	for t := range c.held {
+		if t.tag == tag {
+			ts = append(ts, t)
+		}
+	}
+	return
+}
+
+// Track t as pending while not in the queue, as while paused or while a
+// ticker waits on a slow receiver, so that it may still be found by its
+// tag. Callers must hold a write lock.
+func (c *clock[T, D, RT]) hold(t *timer[T, D]) {
+	if c.held == nil {
+		c.held = make(map[*timer[T, D]]struct{})
+	}
+	c.held[t] = struct{}{}
+}
+
+// Stop tracking t as held. Callers must hold a write lock.
+func (c *clock[T, D, RT]) unhold(t *timer[T, D]) {
+	delete(c.held, t)
+}
+
+// Report whether v may be compared with ==, as a tag must be, without
+// panicking. A value of a comparable type may still hold one that is not,
+// such as an interface field holding a slice, so this is checked by trying.
+func isComparable(v any) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	_ = v == v
+	return true
+}
+
+// Panic if tag is not comparable, rather than later, while a clock's locks
+// are held, when it is compared.
+func checkTag(tag any, method string) {
+	if !isComparable(tag) {
+		panic("non-comparable tag for relativetime." + method)
+	}
+}
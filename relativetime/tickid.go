@@ -0,0 +1,111 @@
+package relativetime
+
+// TickID is a sequence number given to each firing of a Timer or Ticker on a
+// Clock, starting from 1, in the order they fired. Unlike their times, which
+// collide when many are due at once or the clock is stepped past several,
+// TickIDs totally order the events from one Clock. Zero means never fired.
+//
+// Those fired by a change to the clock, as by Set or Step, are numbered in
+// the order they were due. Those fired as time passes on the reference clock
+// are numbered as each shard of the Clock's queue wakes to fire them, which,
+// for timers due at nearly the same instant on different shards, may not be
+// the order they were due.
+type TickID uint64
+
+// A Firing is a firing of a Timer or Ticker, as delivered by one created by
+// NewNumberedTimer or NewNumberedTicker.
+type Firing[T any] struct {
+	When T      // Time at which it fired
+	ID   TickID // Sequence number of the firing
+}
+
+// LastTickID returns the TickID of the most recent firing of any Timer or
+// Ticker on c, or zero if none has fired.
+func (c *Clock[T, D, RT]) LastTickID() TickID {
+	return TickID(c.seq.Load())
+}
+
+// NewNumberedTimer is like NewTimer, but sends a Firing on ch when it fires,
+// so that the receiver has its TickID along with its time. Any number of
+// Timers and Tickers may share ch, for a single receiver to order them all.
+// If ch is not ready to receive, the Firing is dropped. The C method of the
+// returned Timer returns nil.
+func (c *Clock[T, D, RT]) NewNumberedTimer(d D, ch chan<- Firing[T]) *Timer[T, D] {
+	w := c.acquire()
+	tm := &timer[T, D]{
+		when: w.sync().Add(d),
+	}
+	tm.f = func(when T) {
+		select {
+		case ch <- Firing[T]{when, tm.id}:
+		default:
+		}
+	}
+	w.schedule(tm)
+	w.fireIfDue(tm)
+	if tm.index == 0 {
+		w.resetWaker()
+	}
+	w.Unlock()
+	return &Timer[T, D]{t: tm, s: w}
+}
+
+// NewNumberedTicker is like NewTicker, but sends a Firing on ch for each
+// tick, so that the receiver has its TickID along with its time. Any number
+// of Timers and Tickers may share ch, for a single receiver to order them
+// all. If ch is not ready to receive, the Firing is dropped and reported by
+// Missed. The C method of the returned Ticker returns nil. The duration d
+// must be greater than zero; if not, NewNumberedTicker will panic.
+func (c *Clock[T, D, RT]) NewNumberedTicker(d D, ch chan<- Firing[T]) *Ticker[T, D] {
+	if d.Seconds() <= 0 {
+		panic("non-positive interval for relativetime.Clock.NewNumberedTicker")
+	}
+
+	w := c.acquire()
+	tm := &timer[T, D]{
+		when:   w.sync().Add(d),
+		period: d,
+	}
+	tm.f = func(when T) {
+		select {
+		case ch <- Firing[T]{when, tm.id}:
+		default:
+			tm.missed++
+		}
+	}
+	w.schedule(tm)
+	if tm.index == 0 {
+		w.resetWaker()
+	}
+	w.Unlock()
+	return &Ticker[T, D]{t: tm, s: w}
+}
+
+// TickID returns the TickID of the last time the Timer fired, or zero if it
+// never has. It is recorded before the time is sent, so that a receiver may
+// read it afterwards, as long as the Timer is not reset to fire again in the
+// meantime; a Timer created by NewNumberedTimer delivers it instead.
+func (t *Timer[T, D]) TickID() TickID {
+	if t.t == nil {
+		panic("TickID called on uninitialized relativetime.Timer")
+	}
+
+	t.s.Lock()
+	defer t.s.Unlock()
+	return t.t.id
+}
+
+// TickID returns the TickID of the last tick of the Ticker, or zero if it
+// has not ticked, whether or not the tick was delivered. As the Ticker may
+// tick again before a receiver gets to read it, a Ticker created by
+// NewNumberedTicker, which delivers each tick's TickID along with it, is
+// better suited to ordering ticks.
+func (t *Ticker[T, D]) TickID() TickID {
+	if t.t == nil {
+		panic("TickID called on uninitialized relativetime.Ticker")
+	}
+
+	t.s.Lock()
+	defer t.s.Unlock()
+	return t.t.id
+}
@@ -0,0 +1,73 @@
+package steppedtime
+
+import (
+	"sync"
+	"time"
+
+	"github.com/noodlebox/clock/realtime"
+)
+
+// RealClock is the source of real time that drives AutoStep, such as
+// [realtime.Clock], which is used by default, or a mocktime.Clock, to test
+// code using AutoStep without waiting.
+type RealClock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// WithRealClock sets the source of real time used by AutoStep.
+func WithRealClock(rc RealClock) Option {
+	return func(c *Clock) {
+		c.driver = rc
+	}
+}
+
+// AutoStep starts stepping the Clock forward by simStep every realInterval
+// of real time, so that a simulation runs by itself, as for a demo or a
+// soak test. Steps keep to a fixed cadence from the call to AutoStep, so
+// the time taken by each, including triggering timers, does not accumulate
+// as drift, though once a step falls a whole interval behind, the missed
+// ones are skipped rather than run in a burst. The returned function stops
+// stepping; once it returns, no further steps are made. Stepping also stops
+// once the Clock is closed. The interval realInterval must be greater than
+// zero; if not, AutoStep will panic.
+func (c *Clock) AutoStep(realInterval time.Duration, simStep Duration) (stop func()) {
+	if realInterval <= 0 {
+		panic("non-positive interval for steppedtime.Clock.AutoStep")
+	}
+	var rc RealClock = realtime.Clock{}
+	if c.driver != nil {
+		rc = c.driver
+	}
+	c.lock()
+	done := c.doneChan()
+	c.unlock()
+
+	quit := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		next := rc.Now()
+		for {
+			next = next.Add(realInterval)
+			if now := rc.Now(); now.After(next) {
+				// Fell behind; skip ahead to the next interval due
+				next = next.Add(now.Sub(next).Truncate(realInterval) + realInterval)
+			}
+			select {
+			case <-rc.After(next.Sub(rc.Now())):
+			case <-quit:
+				return
+			case <-done:
+				return
+			}
+			c.Step(simStep)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(quit) })
+		<-exited
+	}
+}
@@ -1,32 +1,114 @@
 package steppedtime
 
 import (
+	"context"
+	"errors"
+	"runtime"
 	"sync"
+	"sync/atomic"
+
+	"github.com/noodlebox/clock/internal/runner"
+	"github.com/noodlebox/clock/internal/tickbuf"
 )
 
 // Clock represents a simulation clock that only advances when explicitly
 // stepped. Its methods are thread-safe. The zero-value of a Clock is
 // perfectly valid.
 type Clock struct {
-	now   Time
+	now   atomic.Int64 // Read freely, but only changed while holding mu
 	queue queue
+	free  []*timer // Expired timers, for reuse
+
+	collect atomic.Bool   // Whether unreferenced Timers and Tickers are stopped
+	run     runner.Runner // Runs functions passed to AfterFunc or TickFunc
+
+	done   chan struct{} // Closed by Close, created lazily
+	closed bool
+	seq    uint64 // TickID of the last firing
+
+	maxTimers int    // Limit on pending timers created, if positive
+	rejected  uint64 // Timers refused for exceeding maxTimers
+	sleeping  int    // Goroutines sleeping, which are not limited
+
+	held map[*timer]struct{} // Paused timers, pending but not in the queue
+
+	maxDepth    int    // Most timers ever pending at once
+	rescheduled uint64 // Pending timers moved other than by ticking
+
+	driver RealClock // Source of real time for AutoStep, if not realtime
+	trace  *tracer   // Set by SetTrace
 
-	mu sync.Mutex
+	mu sync.Mutex // Protects queue, free, done, seq, trace, and statistics
 }
 
-// NewClock returns a new Clock.
-func NewClock() *Clock {
-	return &Clock{}
+// ErrClosed is returned by SleepContext when the Clock is closed before the
+// sleep completes, by the Try variants of the methods scheduling Timers and
+// Tickers once it is closed, and by Close when it was already closed.
+var ErrClosed = errors.New("steppedtime: clock closed")
+
+// An Option configures a Clock as it is created by NewClock or NewClockAt.
+type Option func(*Clock)
+
+// WithQueueCapacity preallocates room for n timers in the Clock's queue, to
+// avoid growing it while a large burst of timers is scheduled.
+func WithQueueCapacity(n int) Option {
+	return func(c *Clock) {
+		c.queue = make(queue, 0, n)
+	}
+}
+
+// NewClock returns a new Clock, starting at time zero.
+func NewClock(opts ...Option) *Clock {
+	c := &Clock{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewClockAt returns a new Clock, starting at time t.
+func NewClockAt(t Time, opts ...Option) *Clock {
+	c := NewClock(opts...)
+	c.now.Store(int64(t))
+	return c
+}
+
+// SetCollectUnreferenced sets whether Timers and Tickers created afterwards
+// are stopped automatically once they can no longer be observed, allowing
+// them to be recovered by the garbage collector without calling Stop,
+// similar to timers in Go 1.23. One created by NewTimer, After, NewTicker,
+// or Tick is stopped once its channel is unreachable, whether or not the
+// Timer or Ticker itself is, so that one may be dropped while still
+// receiving on the channel. A Ticker created by TickFunc is stopped once it
+// is unreachable. Timers created by AfterFunc, At, or NewTimerChan, whose
+// channel belongs to the caller, and Tickers created by NewBufferedTicker,
+// which hold on to their channels while ticks wait to be delivered, are
+// unaffected. It is disabled by default.
+func (c *Clock) SetCollectUnreferenced(enabled bool) {
+	c.collect.Store(enabled)
+}
+
+// SetCallbackLimit sets the maximum number of functions passed to AfterFunc
+// or TickFunc that may run at once. Once it is reached, further calls are
+// queued, starting in the order they were due as earlier calls return, so
+// that stepping over a long interval does not start a goroutine for every
+// timer at once. If n <= 0, there is no limit, which is the default. A
+// function that waits on another to be called may deadlock if the limit is
+// reached.
+func (c *Clock) SetCallbackLimit(n int) {
+	c.run.SetLimit(n)
 }
 
 func (c *Clock) lock()   { c.mu.Lock() }
 func (c *Clock) unlock() { c.mu.Unlock() }
 
+func (c *Clock) load() Time { return Time(c.now.Load()) }
+
 // Set sets the current time to now. If any timers are active, a value of now
 // earlier than the previous setting may lead to undefined behavior.
 func (c *Clock) Set(now Time) {
 	c.lock()
-	c.now = now
+	c.now.Store(int64(now))
 
 	// Check whether we're due for any scheduled events
 	c.checkSchedule()
@@ -37,19 +119,18 @@ func (c *Clock) Set(now Time) {
 // value for dt may lead to undefined behavior.
 func (c *Clock) Step(dt Duration) {
 	c.lock()
-	c.now = c.now.Add(dt)
+	c.now.Add(int64(dt))
 
 	// Check whether we're due for any scheduled events
 	c.checkSchedule()
 	c.unlock()
 }
 
-// Now returns the current time.
-func (c *Clock) Now() (now Time) {
-	c.lock()
-	now = c.now
-	c.unlock()
-	return
+// Now returns the current time. It does not wait for timers to be triggered
+// by a concurrent call to Set or Step, which may be observed to have
+// already changed the time.
+func (c *Clock) Now() Time {
+	return c.load()
 }
 
 // Since returns the time elapsed since t. It is shorthand for
@@ -64,28 +145,102 @@ func (c *Clock) Until(t Time) Duration {
 }
 
 // Sleep pauses the current goroutine for at least the duration d. A negative
-// or zero duration causes Sleep to return immediately.
+// or zero duration causes Sleep to return immediately. It also returns once
+// the Clock is closed.
 func (c *Clock) Sleep(d Duration) {
+	c.SleepContext(context.Background(), d)
+}
+
+// SleepContext is like Sleep, but also returns early if ctx is done, with
+// the error from ctx, or if the Clock is closed, with ErrClosed. Otherwise,
+// it returns nil.
+func (c *Clock) SleepContext(ctx context.Context, d Duration) error {
 	if d <= 0 {
-		return
+		return nil
 	}
 
 	ch := make(chan struct{})
 	c.lock()
-	c.schedule(&timer{
-		f:    func(Time) { close(ch) },
-		when: c.now.Add(d),
-	})
+	if c.closed {
+		c.unlock()
+		return ErrClosed
+	}
+	done := c.doneChan()
+	tm := c.alloc()
+	tm.f = func(Time) {
+		c.sleeping--
+		close(ch)
+	}
+	tm.when = c.load().Add(d)
+	gen := tm.gen
+	c.schedule(tm)
+	c.sleeping++
 	c.unlock()
-	<-ch
+
+	var err error
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-done:
+		err = ErrClosed
+	}
+
+	c.lock()
+	if tm.gen == gen && tm.index != -1 {
+		c.unschedule(tm)
+		c.sleeping--
+		c.release(tm)
+	}
+	c.unlock()
+	return err
+}
+
+// Close wakes any goroutines sleeping on c and cancels every pending or
+// paused Timer and Ticker, so that a Clock that will never be stepped again
+// may be torn down without leaking them. Afterwards, Sleep returns
+// immediately, SleepContext and the Try variants return ErrClosed, and
+// Timers and Tickers never fire, whether created, Reset, or Resumed later.
+// Close returns ErrClosed if c was already closed.
+func (c *Clock) Close() error {
+	c.lock()
+	defer c.unlock()
+	if c.closed {
+		return ErrClosed
+	}
+	c.closed = true
+	close(c.doneChan())
+	for _, t := range c.queue {
+		c.traceEvent("cancel", t)
+		t.index = -1
+		c.cancelled(t)
+	}
+	for t := range c.held {
+		c.traceEvent("cancel", t)
+		t.paused = false
+		c.cancelled(t)
+	}
+	c.queue, c.held = nil, nil
+	c.sleeping = 0
+	return nil
+}
+
+// Return a channel closed by Close. Callers must hold the lock.
+func (c *Clock) doneChan() chan struct{} {
+	if c.done == nil {
+		c.done = make(chan struct{})
+	}
+	return c.done
 }
 
 // A Ticker provides a channel that delivers “ticks” of a clock at
 // intervals.
 type Ticker struct {
-	c <-chan Time
-	t *timer
-	s *Clock
+	c   <-chan Time
+	t   *timer
+	s   *Clock
+	buf *tickbuf.Buffer[Time] // Only for buffered tickers
 }
 
 // C returns the channel on which the ticks are delivered.
@@ -100,28 +255,142 @@ func (t *Ticker) Reset(d Duration) {
 	if d <= 0 {
 		panic("non-positive interval for steppedtime.Ticker.Reset")
 	}
+	t.reset(d, false)
+}
+
+// Reset the ticker, refusing to restart it if it is not pending and limit
+// is true and there is no room for it.
+func (t *Ticker) reset(d Duration, limit bool) error {
 	if t.t == nil {
 		panic("Reset called on uninitialized steppedtime.Ticker")
 	}
 
 	t.s.lock()
-	t.t.when = t.s.now.Add(d)
+	defer t.s.unlock()
+	if limit && t.t.index == -1 {
+		if err := t.s.admit(); err != nil {
+			return err
+		}
+	}
+	t.t.when = t.s.load().Add(d)
 	t.t.period = d
+	t.t.paused = false
+	t.s.unhold(t.t)
 	t.s.reschedule(t.t)
+	return nil
+}
+
+// SetPeriod changes the period of a ticker without disturbing its phase:
+// the next tick still arrives when it was due, and later ones follow at
+// intervals of the new period. Unlike Reset, it does not restart a stopped
+// ticker. The duration d must be greater than zero; if not, SetPeriod will
+// panic.
+func (t *Ticker) SetPeriod(d Duration) {
+	if d <= 0 {
+		panic("non-positive interval for steppedtime.Ticker.SetPeriod")
+	}
+	if t.t == nil {
+		panic("SetPeriod called on uninitialized steppedtime.Ticker")
+	}
+
+	t.s.lock()
+	t.t.period = d
 	t.s.unlock()
 }
 
-// Stop turns off a ticker. After Stop, no more ticks will be sent. Stop does
-// not close the channel, to prevent a concurrent goroutine reading from the
-// channel from seeing an erroneous "tick".
+// Stop turns off a ticker. After Stop, no more ticks will be sent, and any
+// ticks still buffered by a ticker created by NewBufferedTicker are
+// discarded. Stop does not close the channel, to prevent a concurrent
+// goroutine reading from the channel from seeing an erroneous "tick".
 func (t *Ticker) Stop() {
 	if t.t == nil {
 		panic("Stop called on uninitialized steppedtime.Ticker")
 	}
 
 	t.s.lock()
+	t.t.paused = false
+	t.s.unhold(t.t)
 	t.s.unschedule(t.t)
 	t.s.unlock()
+	if t.buf != nil {
+		t.buf.Stop()
+	}
+}
+
+// Pause suspends a running ticker, remembering the time left until its next
+// tick. No ticks are sent while paused. Pause has no effect on a ticker that
+// is already paused or has been stopped.
+func (t *Ticker) Pause() {
+	if t.t == nil {
+		panic("Pause called on uninitialized steppedtime.Ticker")
+	}
+
+	t.s.lock()
+	if !t.t.paused && t.t.index >= 0 {
+		t.t.paused = true
+		t.t.remaining = t.t.when.Sub(t.s.load())
+		t.s.hold(t.t)
+		t.s.unschedule(t.t)
+	}
+	t.s.unlock()
+}
+
+// Resume restarts a paused ticker in the same phase, so that its next tick
+// arrives once the time that was left when it was paused has elapsed.
+// Resume has no effect on a ticker that is not paused. Reset or Stop on a
+// paused ticker clears the pause.
+func (t *Ticker) Resume() {
+	t.resume(false)
+}
+
+// Resume the ticker, refusing if limit is true and there is no room for it.
+func (t *Ticker) resume(limit bool) error {
+	if t.t == nil {
+		panic("Resume called on uninitialized steppedtime.Ticker")
+	}
+
+	t.s.lock()
+	defer t.s.unlock()
+	if t.t.paused {
+		if limit {
+			if err := t.s.admit(); err != nil {
+				return err
+			}
+		}
+		t.t.paused = false
+		t.s.unhold(t.t)
+		t.t.when = t.s.load().Add(t.t.remaining)
+		t.s.schedule(t.t)
+	}
+	return nil
+}
+
+// SetTag attaches tag to the ticker, replacing any attached previously, for
+// use with Clock.CancelTag and Clock.TimersByTag. The tag must be
+// comparable; if not, SetTag will panic. A nil tag detaches it.
+func (t *Ticker) SetTag(tag any) {
+	if t.t == nil {
+		panic("SetTag called on uninitialized steppedtime.Ticker")
+	}
+	checkTag(tag, "Ticker.SetTag")
+
+	t.s.lock()
+	t.t.tag = tag
+	t.s.unlock()
+}
+
+// Missed returns the number of ticks that were not delivered since the
+// previous call to Missed, either because the receiver was not ready for
+// them or because the clock was stepped past more than one period at once.
+func (t *Ticker) Missed() (n int) {
+	if t.t == nil {
+		panic("Missed called on uninitialized steppedtime.Ticker")
+	}
+
+	t.s.lock()
+	n, t.t.missed = t.t.missed, 0
+	t.s.unlock()
+	return
 }
 
 // NewTicker returns a new Ticker containing a channel that will send the
@@ -135,21 +404,95 @@ func (c *Clock) NewTicker(d Duration) *Ticker {
 		panic("non-positive interval for steppedtime.Clock.NewTicker")
 	}
 
-	ch := make(chan Time, 1)
-	c.lock()
+	t, _ := c.newTicker(d, false)
+	return t
+}
+
+func (c *Clock) newTicker(d Duration, limit bool) (*Ticker, error) {
+	if err := c.lockAdmit(limit); err != nil {
+		return nil, err
+	}
 	tm := &timer{
-		f: func(when Time) {
-			select {
-			case ch <- when:
-			default:
-			}
-		},
-		when:   c.now.Add(d),
+		when:   c.load().Add(d),
 		period: d,
 	}
+	ch := c.makeChan(tm)
 	c.schedule(tm)
 	c.unlock()
-	return &Ticker{ch, tm, c}
+	return &Ticker{c: ch, t: tm, s: c}, nil
+}
+
+// NewBufferedTicker is like NewTicker, but rather than dropping ticks for
+// slow receivers, it buffers them to be delivered in order. A tick is sent
+// for every period elapsed, even when the clock is stepped past several at
+// once, each carrying the time it was due. At most limit ticks are held
+// until received, or any number if limit <= 0; beyond that, ticks are
+// dropped and reported by Missed. Should the clock be stepped past more
+// than limit periods at once, only the last limit ticks are sent.
+func (c *Clock) NewBufferedTicker(d Duration, limit int) *Ticker {
+	if d <= 0 {
+		panic("non-positive interval for steppedtime.Clock.NewBufferedTicker")
+	}
+
+	t, _ := c.newBufferedTicker(d, limit, false)
+	return t
+}
+
+func (c *Clock) newBufferedTicker(d Duration, n int, limit bool) (*Ticker, error) {
+	ch := make(chan Time)
+	buf := tickbuf.New[Time](ch, n)
+	if err := c.lockAdmit(limit); err != nil {
+		return nil, err
+	}
+	tm := &timer{
+		when:   c.load().Add(d),
+		period: d,
+		exact:  true,
+		burst:  n,
+	}
+	tm.f = func(when Time) {
+		if !buf.Push(when) {
+			tm.missed++
+		}
+	}
+	c.schedule(tm)
+	c.unlock()
+
+	return &Ticker{c: ch, t: tm, s: c, buf: buf}, nil
+}
+
+// TickFunc calls f in its own goroutine after each tick, with the period of
+// the ticks specified by the duration argument. Unlike NewTicker, no channel
+// is used, so the C method of the returned Ticker returns nil. Ticks are
+// never dropped, though a call to f may overlap with previous calls if they
+// have not yet returned. The duration d must be greater than zero; if not,
+// TickFunc will panic. Stop the ticker to release associated resources.
+func (c *Clock) TickFunc(d Duration, f func()) *Ticker {
+	if d <= 0 {
+		panic("non-positive interval for steppedtime.Clock.TickFunc")
+	}
+
+	t, _ := c.tickFunc(d, f, false)
+	return t
+}
+
+func (c *Clock) tickFunc(d Duration, f func(), limit bool) (*Ticker, error) {
+	if err := c.lockAdmit(limit); err != nil {
+		return nil, err
+	}
+	tm := &timer{
+		f:      func(Time) { c.run.Go(f) },
+		when:   c.load().Add(d),
+		period: d,
+	}
+	c.schedule(tm)
+	c.unlock()
+
+	t := &Ticker{t: tm, s: c}
+	if c.collect.Load() {
+		runtime.SetFinalizer(t, (*Ticker).Stop)
+	}
+	return t, nil
 }
 
 // Tick is a convenience wrapper for NewTicker providing access to the
@@ -162,17 +505,34 @@ func (c *Clock) Tick(d Duration) <-chan Time {
 		return nil
 	}
 
-	return c.NewTicker(d).c
+	t, _ := c.newTicker(d, false)
+	return t.c
 }
 
 // The Timer type represents a single event. When the Timer expires, the
 // current time will be sent on the channel returned by C(), unless the Timer
-// was created by AfterFunc. A Timer must be created with NewTimer or
-// AfterFunc.
+// was created by AfterFunc or At. A Timer must be created with NewTimer,
+// NewTimerChan, AfterFunc, or At.
 type Timer struct {
-	c <-chan Time
-	t *timer
-	s *Clock
+	c    chan Time
+	t    *timer
+	gen  uint64 // Generation of t belonging to this Timer
+	f    func(Time)
+	s    *Clock
+	tag  any
+	lane Lane
+	id   TickID // Of its last firing, recorded by t as it fires
+
+	onStop func() // Set by OnStop
+}
+
+// timer returns the underlying timer, or nil if it has since expired and
+// been recycled. Callers must hold the lock.
+func (t *Timer) timer() *timer {
+	if t.t.gen != t.gen {
+		return nil
+	}
+	return t.t
 }
 
 // C returns the channel on which the ticks are delivered.
@@ -183,14 +543,38 @@ func (t *Timer) C() <-chan Time {
 // Reset changes the timer to expire after duration d. It returns true if the
 // timer had been active, false if the timer had expired or been stopped.
 func (t *Timer) Reset(d Duration) (active bool) {
+	active, _ = t.reset(d, false)
+	return
+}
+
+// Reset the timer, refusing to restart it if it is not pending and limit is
+// true and there is no room for it.
+func (t *Timer) reset(d Duration, limit bool) (active bool, err error) {
 	if t.t == nil {
 		panic("Reset called on uninitialized steppedtime.Timer")
 	}
 
 	t.s.lock()
-	t.t.when = t.s.now.Add(d)
-	active = (t.t.index != -1)
-	t.s.reschedule(t.t)
+	tm := t.timer()
+	if limit && (tm == nil || tm.index == -1) {
+		if err = t.s.admit(); err != nil {
+			t.s.unlock()
+			return tm != nil && tm.paused, err
+		}
+	}
+	if tm == nil {
+		// Expired and recycled, so start afresh
+		tm = t.s.alloc()
+		tm.f, tm.ch, tm.tag, tm.lane, tm.out = t.f, t.c, t.tag, t.lane, t
+		tm.onStop = t.onStop
+		t.t, t.gen = tm, tm.gen
+	}
+	tm.when = t.s.load().Add(d)
+	active = (tm.index != -1) || tm.paused
+	tm.paused = false
+	t.s.unhold(tm)
+	t.s.reschedule(tm)
+	t.s.fireIfDue(tm)
 	t.s.unlock()
 	return
 }
@@ -205,29 +589,149 @@ func (t *Timer) Stop() (active bool) {
 	}
 
 	t.s.lock()
-	active = (t.t.index != -1)
-	t.s.unschedule(t.t)
+	if tm := t.timer(); tm != nil {
+		active = (tm.index != -1) || tm.paused
+		tm.paused = false
+		t.s.unhold(tm)
+		t.s.unschedule(tm)
+		if active {
+			t.s.cancelled(tm)
+		}
+	}
+	t.s.unlock()
+	return
+}
+
+// Pause suspends an active timer, remembering the time left until it
+// expires. It returns true if the call pauses the timer, false if the timer
+// has already expired, been stopped, or been paused. A paused timer counts
+// as active for Reset and Stop, either of which clears the pause.
+func (t *Timer) Pause() (paused bool) {
+	if t.t == nil {
+		panic("Pause called on uninitialized steppedtime.Timer")
+	}
+
+	t.s.lock()
+	if tm := t.timer(); tm != nil && tm.index != -1 {
+		tm.paused, paused = true, true
+		tm.remaining = tm.when.Sub(t.s.load())
+		t.s.hold(tm)
+		t.s.unschedule(tm)
+	}
+	t.s.unlock()
+	return
+}
+
+// Resume re-arms a paused timer to expire once the time that was left when
+// it was paused has elapsed. It returns true if the call resumes the timer,
+// false if the timer was not paused.
+func (t *Timer) Resume() (resumed bool) {
+	resumed, _ = t.resume(false)
+	return
+}
+
+// Resume the timer, refusing if limit is true and there is no room for it.
+func (t *Timer) resume(limit bool) (resumed bool, err error) {
+	if t.t == nil {
+		panic("Resume called on uninitialized steppedtime.Timer")
+	}
+
+	t.s.lock()
+	if tm := t.timer(); tm != nil && tm.paused {
+		if limit {
+			if err = t.s.admit(); err != nil {
+				t.s.unlock()
+				return false, err
+			}
+		}
+		tm.paused, resumed = false, true
+		t.s.unhold(tm)
+		tm.when = t.s.load().Add(tm.remaining)
+		t.s.schedule(tm)
+		t.s.fireIfDue(tm)
+	}
+	t.s.unlock()
+	return
+}
+
+// FireNow triggers the timer at once, as if it had expired, if it is
+// active or paused. It returns true if the timer was triggered, false if it
+// had already expired or been stopped.
+func (t *Timer) FireNow() (fired bool) {
+	if t.t == nil {
+		panic("FireNow called on uninitialized steppedtime.Timer")
+	}
+
+	t.s.lock()
+	if tm := t.timer(); tm != nil && (tm.index != -1 || tm.paused) && !t.s.closed {
+		tm.paused = false
+		t.s.unhold(tm)
+		tm.when = t.s.load()
+		t.s.reschedule(tm)
+		t.s.checkSchedule()
+		fired = true
+	}
 	t.s.unlock()
 	return
 }
 
+// SetTag attaches tag to the timer, replacing any attached previously, for
+// use with Clock.CancelTag and Clock.TimersByTag. The tag must be
+// comparable; if not, SetTag will panic. A nil tag detaches it.
+func (t *Timer) SetTag(tag any) {
+	if t.t == nil {
+		panic("SetTag called on uninitialized steppedtime.Timer")
+	}
+	checkTag(tag, "Timer.SetTag")
+
+	t.s.lock()
+	t.tag = tag
+	if tm := t.timer(); tm != nil {
+		tm.tag = tag
+	}
+	t.s.unlock()
+}
+
 // NewTimer creates a new Timer that will send the current time on its
-// channel after at least duration d.
+// channel after at least duration d. If d <= 0, it fires at once, without
+// waiting for the clock to be stepped.
 func (c *Clock) NewTimer(d Duration) *Timer {
-	ch := make(chan Time, 1)
-	c.lock()
-	tm := &timer{
-		f: func(when Time) {
-			select {
-			case ch <- when:
-			default:
-			}
-		},
-		when: c.now.Add(d),
+	t, _ := c.newTimer(d, nil, false)
+	return t
+}
+
+// NewTimerChan is like NewTimer, but sends on ch rather than allocating a
+// new channel. As with NewTimer, sending never blocks; the time is dropped
+// if ch is not ready to receive it. The same channel may be shared by many
+// timers.
+func (c *Clock) NewTimerChan(d Duration, ch chan Time) *Timer {
+	t, _ := c.newTimer(d, ch, false)
+	return t
+}
+
+// Create a Timer sending on ch, or on a new channel if ch is nil, refusing
+// if limit is true and there is no room for it.
+func (c *Clock) newTimer(d Duration, ch chan Time, limit bool) (*Timer, error) {
+	if err := c.lockAdmit(limit); err != nil {
+		return nil, err
 	}
+	tm := c.alloc()
+	if ch == nil {
+		ch = c.makeChan(tm)
+	} else {
+		tm.ch = ch
+	}
+	tm.when = c.load().Add(d)
 	c.schedule(tm)
+	t := &Timer{c: ch, t: tm, gen: tm.gen, f: tm.f, s: c}
+	if !tm.keep {
+		// A timer kept to be collected must not hold on to its channel
+		// through t, and is never recycled, so keeps its own id
+		tm.out = t
+	}
+	c.fireIfDue(tm)
 	c.unlock()
-	return &Timer{ch, tm, c}
+	return t, nil
 }
 
 // After waits for the duration to elapse and then sends the current time on
@@ -236,19 +740,94 @@ func (c *Clock) NewTimer(d Duration) *Timer {
 // fires. If efficiency is a concern, use clock.NewTimer instead and call
 // Timer.Stop if the timer is no longer needed.
 func (c *Clock) After(d Duration) <-chan Time {
-	return c.NewTimer(d).c
+	t, _ := c.newTimer(d, nil, false)
+	return t.c
 }
 
 // AfterFunc waits for the duration to elapse and then calls f in its own
 // goroutine. It returns a Timer that can be used to cancel the call using
-// its Stop method.
+// its Stop method. As f is not called while holding any lock, it may safely
+// call methods on the clock or the returned Timer, such as Reset to
+// reschedule itself.
 func (c *Clock) AfterFunc(d Duration, f func()) *Timer {
+	t, _ := c.afterFunc(d, f, false)
+	return t
+}
+
+func (c *Clock) afterFunc(d Duration, f func(), limit bool) (*Timer, error) {
+	tf := func(Time) { c.run.Go(f) }
+	if err := c.lockAdmit(limit); err != nil {
+		return nil, err
+	}
+	tm := c.alloc()
+	tm.f = tf
+	tm.when = c.load().Add(d)
+	c.schedule(tm)
+	t := &Timer{t: tm, gen: tm.gen, f: tf, s: c}
+	tm.out = t
+	c.fireIfDue(tm)
+	c.unlock()
+	return t, nil
+}
+
+// At waits for the clock to reach the time when and then calls f in its own
+// goroutine, with the current time as it fires, complementing AfterFunc for
+// simulations that schedule events at absolute times. If when is not after
+// the current time, f is called at once. It returns a Timer that can be used
+// to cancel the call using its Stop method; as with AfterFunc, Reset
+// reschedules it relative to the current time.
+func (c *Clock) At(when Time, f func(Time)) *Timer {
+	t, _ := c.at(when, f, false)
+	return t
+}
+
+func (c *Clock) at(when Time, f func(Time), limit bool) (*Timer, error) {
+	tf := func(now Time) { c.run.Go(func() { f(now) }) }
+	if err := c.lockAdmit(limit); err != nil {
+		return nil, err
+	}
+	tm := c.alloc()
+	tm.f = tf
+	tm.when = when
+	c.schedule(tm)
+	t := &Timer{t: tm, gen: tm.gen, f: tf, s: c}
+	tm.out = t
+	c.fireIfDue(tm)
+	c.unlock()
+	return t, nil
+}
+
+// Create a Timer calling f, while holding the lock, with the time it fires,
+// for a wrapper to deliver as it sees fit. The C method of the Timer returns
+// nil.
+func (c *Clock) newTimerFunc(d Duration, f func(Time)) *Timer {
+	c.lock()
+	tm := c.alloc()
+	tm.f = f
+	tm.when = c.load().Add(d)
+	c.schedule(tm)
+	t := &Timer{t: tm, gen: tm.gen, f: f, s: c}
+	tm.out = t
+	c.fireIfDue(tm)
+	c.unlock()
+	return t
+}
+
+// Create a Ticker calling send, while holding the lock, with the time of
+// each tick, counting it as missed if send reports that it was not
+// delivered. The C method of the Ticker returns nil.
+func (c *Clock) newTickerFunc(d Duration, send func(Time) bool) *Ticker {
 	c.lock()
 	tm := &timer{
-		f:    func(Time) { go f() },
-		when: c.now.Add(d),
+		when:   c.load().Add(d),
+		period: d,
+	}
+	tm.f = func(when Time) {
+		if !send(when) {
+			tm.missed++
+		}
 	}
 	c.schedule(tm)
 	c.unlock()
-	return &Timer{t: tm, s: c}
+	return &Ticker{t: tm, s: c}
 }
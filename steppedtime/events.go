@@ -0,0 +1,54 @@
+package steppedtime
+
+// An Event describes a Timer or Ticker fired by StepEvents.
+type Event struct {
+	When     Time // Time at which it fired
+	Periodic bool // Whether it was a Ticker
+	Tag      any  // Tag attached by SetTag, if any
+	Lane     Lane
+	ID       TickID // Sequence number of the firing
+}
+
+// StepEvents returns a function that, when called, advances the current time
+// by dt as Step does, but yields an Event for each Timer or Ticker fired,
+// in order, as the step progresses, so that a simulation may interleave its
+// own logic between events without the use of callbacks. Before each is
+// yielded, the clock is advanced to the time it fired, and no lock is held,
+// so that yield may freely use the clock, such as to schedule further
+// events, which are yielded in turn if due within the step. If yield
+// returns false, the step ends there, at the time of the last event. The
+// clock never moves backwards: if it has been advanced past the end of the
+// step in the meantime, it is left where it is. Its signature matches
+// iter.Seq[Event], for use with range over functions where available.
+func (c *Clock) StepEvents(dt Duration) func(yield func(Event) bool) {
+	return func(yield func(Event) bool) {
+		c.lock()
+		end := c.load().Add(dt)
+		c.unlock()
+		for {
+			c.lock()
+			t := c.queue.peek()
+			if t == nil || t.when.After(end) {
+				// Never rewind the clock, should yield or a concurrent
+				// Step have already moved it past the end
+				if end.After(c.load()) {
+					c.now.Store(int64(end))
+				}
+				c.unlock()
+				return
+			}
+			when := t.when
+			if now := c.load(); when.Before(now) {
+				when = now
+			}
+			e := Event{When: when, Periodic: t.period > 0, Tag: t.tag, Lane: t.lane}
+			c.now.Store(int64(when))
+			c.fire(t, when)
+			e.ID = TickID(c.seq)
+			c.unlock()
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
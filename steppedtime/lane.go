@@ -0,0 +1,49 @@
+package steppedtime
+
+// A Lane orders Timers and Tickers due at the same time, so that simulations
+// may control the order of events within a single step, such as physics
+// before bookkeeping. Those in lower lanes fire first; the order within a
+// lane is unspecified. Any value may be used, with Immediate and Normal
+// provided for convenience. Times are sent on channels in this order, and
+// functions passed to AfterFunc, At, or TickFunc are started in it, though
+// they only run strictly in order with a callback limit of 1 (see
+// Clock.SetCallbackLimit).
+type Lane int
+
+const (
+	Immediate Lane = -1 // Ahead of the default lane
+	Normal    Lane = 0  // The default lane for every Timer and Ticker
+)
+
+// SetLane moves the timer to lane, to order it among others due at the same
+// time.
+func (t *Timer) SetLane(lane Lane) {
+	if t.t == nil {
+		panic("SetLane called on uninitialized steppedtime.Timer")
+	}
+
+	t.s.lock()
+	t.lane = lane
+	if tm := t.timer(); tm != nil {
+		tm.lane = lane
+		if tm.index != -1 {
+			t.s.reschedule(tm)
+		}
+	}
+	t.s.unlock()
+}
+
+// SetLane moves the ticker to lane, to order it among others due at the same
+// time.
+func (t *Ticker) SetLane(lane Lane) {
+	if t.t == nil {
+		panic("SetLane called on uninitialized steppedtime.Ticker")
+	}
+
+	t.s.lock()
+	t.t.lane = lane
+	if t.t.index != -1 {
+		t.s.reschedule(t.t)
+	}
+	t.s.unlock()
+}
@@ -0,0 +1,161 @@
+package steppedtime
+
+import (
+	"errors"
+)
+
+// ErrTooManyTimers is returned by the Try variants of the methods creating,
+// resetting, or resuming Timers and Tickers, when a Clock already has as
+// many pending as allowed by WithMaxTimers.
+var ErrTooManyTimers = errors.New("steppedtime: too many timers")
+
+// WithMaxTimers limits the number of Timers and Tickers that may be pending
+// on the Clock at once to n, so that a leak or a misbehaving client cannot
+// grow its queue without bound. The limit is enforced by the Try variants of
+// the methods that would schedule one, such as TryNewTimer and
+// Timer.TryReset, which fail with ErrTooManyTimers rather than go beyond
+// it, counting each refusal in TimerStats; code serving untrusted clients
+// should use these. The other methods are never refused, so that code
+// unaware of the limit does not fail, but what they schedule counts toward
+// it. Goroutines in Sleep are neither counted nor limited. If n <= 0, there
+// is no limit, which is the default.
+func WithMaxTimers(n int) Option {
+	return func(c *Clock) {
+		c.maxTimers = n
+	}
+}
+
+// TimerStats reports on the Timers and Tickers of a Clock, for monitoring
+// its limit set by WithMaxTimers.
+type TimerStats struct {
+	Pending  int    // Timers and Tickers waiting to fire
+	Sleeping int    // Goroutines in Sleep, which are not limited
+	Max      int    // Limit set by WithMaxTimers, or 0 if none
+	Rejected uint64 // Timers and Tickers refused for exceeding Max
+}
+
+// TimerStats returns statistics on the Timers and Tickers of c.
+func (c *Clock) TimerStats() TimerStats {
+	c.lock()
+	defer c.unlock()
+	return TimerStats{
+		Pending:  len(c.queue) - c.sleeping,
+		Sleeping: c.sleeping,
+		Max:      c.maxTimers,
+		Rejected: c.rejected,
+	}
+}
+
+// Return ErrClosed if c is closed, or ErrTooManyTimers, counting the
+// refusal, if there is no room for another pending Timer or Ticker. Callers
+// must hold the lock.
+func (c *Clock) admit() error {
+	if c.closed {
+		return ErrClosed
+	}
+	if c.maxTimers > 0 && len(c.queue)-c.sleeping >= c.maxTimers {
+		c.rejected++
+		return ErrTooManyTimers
+	}
+	return nil
+}
+
+// Acquire the lock to schedule a new timer. If limit is true and there is
+// no room for one, or c is closed, the lock is instead released and the
+// error from admit returned.
+func (c *Clock) lockAdmit(limit bool) error {
+	c.lock()
+	if !limit {
+		return nil
+	}
+	if err := c.admit(); err != nil {
+		c.unlock()
+		return err
+	}
+	return nil
+}
+
+// TryNewTimer is like NewTimer, but returns ErrTooManyTimers if c has no
+// room for another timer.
+func (c *Clock) TryNewTimer(d Duration) (*Timer, error) {
+	return c.newTimer(d, nil, true)
+}
+
+// TryNewTimerChan is like NewTimerChan, but returns ErrTooManyTimers if c
+// has no room for another timer.
+func (c *Clock) TryNewTimerChan(d Duration, ch chan Time) (*Timer, error) {
+	return c.newTimer(d, ch, true)
+}
+
+// TryAfterFunc is like AfterFunc, but returns ErrTooManyTimers if c has no
+// room for another timer.
+func (c *Clock) TryAfterFunc(d Duration, f func()) (*Timer, error) {
+	return c.afterFunc(d, f, true)
+}
+
+// TryAt is like At, but returns ErrTooManyTimers if c has no room for
+// another timer.
+func (c *Clock) TryAt(when Time, f func(Time)) (*Timer, error) {
+	return c.at(when, f, true)
+}
+
+// TryNewTicker is like NewTicker, but returns ErrTooManyTimers if c has no
+// room for another ticker.
+func (c *Clock) TryNewTicker(d Duration) (*Ticker, error) {
+	if d <= 0 {
+		panic("non-positive interval for steppedtime.Clock.TryNewTicker")
+	}
+
+	return c.newTicker(d, true)
+}
+
+// TryNewBufferedTicker is like NewBufferedTicker, but returns
+// ErrTooManyTimers if c has no room for another ticker.
+func (c *Clock) TryNewBufferedTicker(d Duration, limit int) (*Ticker, error) {
+	if d <= 0 {
+		panic("non-positive interval for steppedtime.Clock.TryNewBufferedTicker")
+	}
+
+	return c.newBufferedTicker(d, limit, true)
+}
+
+// TryTickFunc is like TickFunc, but returns ErrTooManyTimers if c has no
+// room for another ticker.
+func (c *Clock) TryTickFunc(d Duration, f func()) (*Ticker, error) {
+	if d <= 0 {
+		panic("non-positive interval for steppedtime.Clock.TryTickFunc")
+	}
+
+	return c.tickFunc(d, f, true)
+}
+
+// TryReset is like Reset, but if the timer is not pending, and so would be
+// scheduled anew, it returns ErrTooManyTimers if its Clock has no room for
+// it, leaving the timer as it was.
+func (t *Timer) TryReset(d Duration) (active bool, err error) {
+	return t.reset(d, true)
+}
+
+// TryResume is like Resume, but returns ErrTooManyTimers if the timer's Clock
+// has no room for it, leaving it paused.
+func (t *Timer) TryResume() (resumed bool, err error) {
+	return t.resume(true)
+}
+
+// TryReset is like Reset, but if the ticker is not pending, and so would be
+// scheduled anew, it returns ErrTooManyTimers if its Clock has no room for
+// it, leaving the ticker as it was. The duration d must be greater than
+// zero; if not, TryReset will panic.
+func (t *Ticker) TryReset(d Duration) error {
+	if d <= 0 {
+		panic("non-positive interval for steppedtime.Ticker.TryReset")
+	}
+
+	return t.reset(d, true)
+}
+
+// TryResume is like Resume, but returns ErrTooManyTimers if the ticker's
+// Clock has no room for it, leaving it paused.
+func (t *Ticker) TryResume() error {
+	return t.resume(true)
+}
@@ -0,0 +1,28 @@
+package steppedtime
+
+// OnStop sets f to be called in its own goroutine whenever the Timer is
+// stopped before firing, whether by Stop, by CancelTag, or by closing the
+// Clock, so that resources tied to the pending work may be released
+// deterministically. It is not called when the Timer fires, nor by Stop on
+// a Timer that has already fired or been stopped. A nil f removes any
+// function set previously. A paused Timer counts as stopped before firing.
+func (t *Timer) OnStop(f func()) {
+	if t.t == nil {
+		panic("OnStop called on uninitialized steppedtime.Timer")
+	}
+
+	t.s.lock()
+	t.onStop = f
+	if tm := t.timer(); tm != nil {
+		tm.onStop = f
+	}
+	t.s.unlock()
+}
+
+// Call any function set by OnStop for t, which was stopped without firing.
+// Callers must hold the lock.
+func (c *Clock) cancelled(t *timer) {
+	if t.onStop != nil {
+		c.run.Go(t.onStop)
+	}
+}
@@ -0,0 +1,33 @@
+package steppedtime
+
+// Postpone moves the time at which an active timer expires later by delta,
+// in place, without the window in which a Stop followed by a Reset leaves it
+// inactive, and without recomputing its deadline from the current time. It
+// returns true if the timer was moved, false if it had already expired or
+// been stopped. A paused timer has its remaining time extended instead. A
+// negative delta moves it earlier, as with Advance.
+func (t *Timer) Postpone(delta Duration) (active bool) {
+	if t.t == nil {
+		panic("Postpone called on uninitialized steppedtime.Timer")
+	}
+
+	t.s.lock()
+	if tm := t.timer(); tm != nil && tm.paused {
+		tm.remaining += delta
+		active = true
+	} else if tm != nil && tm.index != -1 {
+		tm.when = tm.when.Add(delta)
+		t.s.reschedule(tm)
+		t.s.fireIfDue(tm)
+		active = true
+	}
+	t.s.unlock()
+	return
+}
+
+// Advance moves the time at which an active timer expires earlier by delta,
+// firing it at once if that time has already passed. It is equivalent to
+// Postpone(-delta).
+func (t *Timer) Advance(delta Duration) (active bool) {
+	return t.Postpone(-delta)
+}
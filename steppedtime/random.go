@@ -0,0 +1,63 @@
+package steppedtime
+
+import (
+	"math/rand"
+)
+
+// Distribution draws a random Duration using r, as the size of a step taken
+// by StepRandom. Besides those returned by Exponential, Uniform, and Fixed,
+// any function may be used, as for an empirical distribution.
+type Distribution func(r *rand.Rand) Duration
+
+// Exponential returns a Distribution of durations exponentially distributed
+// with the given mean, as for the time between arrivals of a Poisson
+// process.
+func Exponential(mean Duration) Distribution {
+	return func(r *rand.Rand) Duration {
+		return Duration(r.ExpFloat64() * float64(mean))
+	}
+}
+
+// Uniform returns a Distribution of durations uniformly distributed in the
+// interval [lo, hi).
+func Uniform(lo, hi Duration) Distribution {
+	if hi < lo {
+		panic("empty interval for steppedtime.Uniform")
+	}
+	return func(r *rand.Rand) Duration {
+		return lo + Duration(r.Float64()*float64(hi-lo))
+	}
+}
+
+// Fixed returns a Distribution always drawing d, for comparing a
+// simulation against its deterministic counterpart.
+func Fixed(d Duration) Distribution {
+	return func(*rand.Rand) Duration { return d }
+}
+
+// A Source drawing from the top-level functions of math/rand, which are
+// safe for concurrent use.
+type globalSource struct{}
+
+func (globalSource) Int63() int64 { return rand.Int63() }
+func (globalSource) Seed(int64)   {}
+
+var globalRand = rand.New(globalSource{})
+
+// StepRandom advances the current time by a step drawn from dist using rng,
+// as with Step, and returns the size of the step, for Monte Carlo and
+// queueing simulations. A negative draw is taken as zero. If rng is nil,
+// the top-level functions of math/rand are used; pass a seeded *rand.Rand
+// for a reproducible run, but as it is not safe for concurrent use, it must
+// then not be shared between goroutines.
+func (c *Clock) StepRandom(rng *rand.Rand, dist Distribution) Duration {
+	if rng == nil {
+		rng = globalRand
+	}
+	dt := dist(rng)
+	if dt < 0 {
+		dt = 0
+	}
+	c.Step(dt)
+	return dt
+}
@@ -0,0 +1,38 @@
+package steppedtime
+
+import (
+	"github.com/noodlebox/clock/internal/sched"
+)
+
+// Schedule is a recurrence, such as one given by a cron expression. Next
+// returns the first time after its argument at which it occurs, and false
+// if there are no more. It is the instantiation of clock.Schedule for Time.
+type Schedule interface {
+	Next(after Time) (Time, bool)
+}
+
+// Scheduled is a recurring call started by ScheduleFunc.
+type Scheduled struct {
+	c *sched.Chain[Time, Duration, *Timer]
+}
+
+// ScheduleFunc calls f in its own goroutine at each time given by s after
+// the current time, until s has no more or the returned Scheduled is
+// stopped. Each call is passed the time at which it was due. Stepping past
+// several occurrences at once makes a call for each of them, in order, as
+// soon as possible. A Schedule whose Next does not return a time after its
+// argument ends there.
+func (c *Clock) ScheduleFunc(s Schedule, f func(Time)) *Scheduled {
+	return &Scheduled{sched.Start[Time, Duration](c.Now, c.AfterFunc, s, f)}
+}
+
+// Next returns the time of the next call, and false if there are none.
+func (s *Scheduled) Next() (Time, bool) {
+	return s.c.Next()
+}
+
+// Stop prevents any further calls. It returns true if the call stops them,
+// false if they had already ended or been stopped.
+func (s *Scheduled) Stop() bool {
+	return s.c.Stop()
+}
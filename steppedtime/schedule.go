@@ -2,15 +2,35 @@ package steppedtime
 
 import (
 	"container/heap"
+
+	"github.com/noodlebox/clock/internal/weakchan"
 )
 
 type timer struct {
 	f      func(Time)
+	ch     chan Time // Sent on in place of calling f, if not nil
 	when   Time
 	period Duration
 	index  int
+	gen    uint64 // Incremented each time the timer is recycled
+	missed int    // Periods skipped or dropped, for tickers
+	exact  bool   // Whether to fire for every period, even if late
+	burst  int    // Most periods an exact ticker fires for at once, if > 0
+	lane   Lane   // Order among timers due at the same time
+	tag    any
+	serial uint64 // Identifies it in a trace, if nonzero
+	id     TickID // Of its last firing
+	out    *Timer // Where id is recorded, as t may be recycled
+	keep   bool   // Never to be recycled, as when it may be collected
+	onStop func() // Called if stopped or cancelled before firing
+
+	paused    bool     // Whether held while paused, rather than queued
+	remaining Duration // Time left until it fires, while paused
 }
 
+// Maximum number of expired timers kept for reuse
+const maxFree = 1 << 12
+
 type queue []*timer
 
 // Implement sort.Interface
@@ -19,6 +39,9 @@ func (q queue) Len() int {
 }
 
 func (q queue) Less(i, j int) bool {
+	if q[i].when == q[j].when {
+		return q[i].lane < q[j].lane
+	}
 	return q[i].when.Before(q[j].when)
 }
 
@@ -50,21 +73,135 @@ func (q queue) peek() *timer {
 	return q[0]
 }
 
-// Check schedule for pending events that should trigger now.
+// Check schedule for pending events that should trigger now. Timer functions
+// are called while holding the lock, so they must not call back into the
+// Clock; any function supplied by a user is instead started in a new
+// goroutine, which may do so freely.
 func (c *Clock) checkSchedule() {
-	for t := c.queue.peek(); t != nil && !t.when.After(c.now); t = c.queue.peek() {
-		if t.period.Seconds() <= 0 {
-			c.unschedule(t)
-		} else {
-			t.when = c.now.Add(t.period)
-			c.reschedule(t)
+	now := c.load()
+	for t := c.queue.peek(); t != nil && !t.when.After(now); t = c.queue.peek() {
+		c.fire(t, now)
+	}
+}
+
+// fire triggers t, which is due at or before now, rescheduling it if it is
+// periodic. Callers must hold the lock.
+func (c *Clock) fire(t *timer, now Time) {
+	c.seq++
+	t.id = TickID(c.seq)
+	c.traceEvent("fire", t)
+	if t.period.Seconds() <= 0 {
+		heap.Remove(&c.queue, t.index)
+		if t.out != nil {
+			t.out.id = t.id
+		}
+		t.deliver(now)
+		c.release(t)
+		return
+	}
+	if t.exact {
+		if due := int(now.Sub(t.when)/t.period) + 1; t.burst > 0 && due > t.burst {
+			// Skip all but the last ticks that fit in its buffer, rather
+			// than fire once per period only to drop them
+			n := due - t.burst
+			t.missed += n
+			t.when = t.when.Add(Duration(n) * t.period)
+		}
+		when := t.when
+		t.when = when.Add(t.period)
+		heap.Fix(&c.queue, t.index)
+		t.deliver(when)
+		return
+	}
+	t.missed += int(now.Sub(t.when) / t.period)
+	t.when = now.Add(t.period)
+	heap.Fix(&c.queue, t.index)
+	t.deliver(now)
+}
+
+// deliver sends when on t's channel without blocking, counting it as missed
+// if the channel is full, or if t has no channel, calls t.f with it.
+func (t *timer) deliver(when Time) {
+	if t.ch == nil {
+		t.f(when)
+		return
+	}
+	select {
+	case t.ch <- when:
+	default:
+		t.missed++
+	}
+}
+
+// fireIfDue triggers t at once if it is already due, as when scheduled with
+// a duration <= 0, rather than waiting for the next Set or Step. Callers
+// must hold the lock.
+func (c *Clock) fireIfDue(t *timer) {
+	if t.index != -1 && !t.when.After(c.load()) {
+		c.checkSchedule()
+	}
+}
+
+// alloc returns an unscheduled timer, reusing an expired one if available.
+// Callers must hold the lock.
+func (c *Clock) alloc() *timer {
+	n := len(c.free) - 1
+	if n < 0 {
+		return &timer{index: -1}
+	}
+	t := c.free[n]
+	c.free[n] = nil
+	c.free = c.free[:n]
+	return t
+}
+
+// release recycles an expired one-shot timer. Any Timer referring to it
+// will notice the change in generation, and no longer use it. Callers must
+// hold the lock.
+func (c *Clock) release(t *timer) {
+	if t.keep || len(c.free) >= maxFree {
+		return
+	}
+	t.f, t.ch, t.tag, t.lane, t.out, t.onStop, t.serial = nil, nil, nil, Normal, nil, nil, 0
+	t.gen++
+	c.free = append(c.free, t)
+}
+
+// Return a new channel with room for one value for t to deliver on. If
+// unreferenced timers are collected, t refers to the channel only weakly,
+// and is stopped once it is otherwise unreachable; as that is done through t
+// directly, it is never recycled. Callers must hold the lock.
+func (c *Clock) makeChan(t *timer) chan Time {
+	if !c.collect.Load() {
+		t.ch = make(chan Time, 1)
+		return t.ch
+	}
+	t.keep = true
+	ch, ref := weakchan.Make[Time](1, func() {
+		c.lock()
+		c.unhold(t)
+		c.unschedule(t)
+		c.unlock()
+	})
+	t.f = func(when Time) {
+		if !ref.Send(when) {
+			t.missed++
 		}
-		t.f(c.now)
 	}
+	return ch
 }
 
+// schedule adds t to the queue, unless the Clock is closed. Callers must
+// hold the lock.
 func (c *Clock) schedule(t *timer) {
+	if c.closed {
+		return
+	}
 	heap.Push(&c.queue, t)
+	c.traceEvent("schedule", t)
+	if len(c.queue) > c.maxDepth {
+		c.maxDepth = len(c.queue)
+	}
 }
 
 func (c *Clock) unschedule(t *timer) {
@@ -72,6 +209,7 @@ func (c *Clock) unschedule(t *timer) {
 		return
 	}
 	heap.Remove(&c.queue, t.index)
+	c.traceEvent("cancel", t)
 }
 
 func (c *Clock) reschedule(t *timer) {
@@ -79,5 +217,7 @@ func (c *Clock) reschedule(t *timer) {
 		c.schedule(t)
 		return
 	}
+	c.rescheduled++
 	heap.Fix(&c.queue, t.index)
+	c.traceEvent("reschedule", t)
 }
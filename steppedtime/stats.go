@@ -0,0 +1,53 @@
+package steppedtime
+
+import (
+	"fmt"
+)
+
+// QueueStats reports on the scheduling activity of a Clock over its
+// lifetime, for profiling a simulation. A MaxDepth far above the number of
+// timers expected to be pending at once suggests a leak, and a Rescheduled
+// count far above Fired suggests timers reset over and over without ever
+// firing, such as by a debounce that is never allowed to settle.
+type QueueStats struct {
+	Depth       int    // Timers and Tickers currently pending, including sleepers
+	MaxDepth    int    // Most ever pending at once
+	Fired       uint64 // Firings of any Timer or Ticker, as numbered by TickID
+	Rescheduled uint64 // Pending timers moved, as by Reset, other than by ticking
+}
+
+// QueueStats returns statistics on the queue of pending timers of c.
+func (c *Clock) QueueStats() QueueStats {
+	c.lock()
+	defer c.unlock()
+	return QueueStats{
+		Depth:       len(c.queue),
+		MaxDepth:    c.maxDepth,
+		Fired:       c.seq,
+		Rescheduled: c.rescheduled,
+	}
+}
+
+// CheckQueue returns an error describing the first inconsistency found in
+// the queue of pending Timers and Tickers of c, or nil if there is none, for
+// tests such as those of package clocktest/fuzz. The queue must be a heap
+// ordered by the time each is due, with each knowing its place in it, while
+// each held out of it, as while paused, must know it is not in it.
+func (c *Clock) CheckQueue() error {
+	c.lock()
+	defer c.unlock()
+	for i, t := range c.queue {
+		if t.index != i {
+			return fmt.Errorf("timer at %d recorded at %d", i, t.index)
+		}
+		if p := (i - 1) / 2; i > 0 && c.queue.Less(i, p) {
+			return fmt.Errorf("timer at %d due at %v, before its parent at %d due at %v", i, t.when, p, c.queue[p].when)
+		}
+	}
+	for t := range c.held {
+		if t.index >= 0 {
+			return fmt.Errorf("held timer recorded at %d", t.index)
+		}
+	}
+	return nil
+}
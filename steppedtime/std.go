@@ -0,0 +1,41 @@
+package steppedtime
+
+// Variants of Timer and Ticker exposing their channels as a field named C,
+// matching the shape of [time.Timer] and [time.Ticker]. These may be more
+// convenient when adapting existing code written against the time package,
+// at the cost of being unable to satisfy an interface requiring C().
+
+// StdTimer is a variant of [Timer] whose channel is available as the field
+// C, rather than through a method. A StdTimer must be created with
+// NewStdTimer or StdAfterFunc.
+type StdTimer struct {
+	C <-chan Time // The channel on which the ticks are delivered.
+	*Timer
+}
+
+// StdTicker is a variant of [Ticker] whose channel is available as the
+// field C, rather than through a method. A StdTicker must be created with
+// NewStdTicker.
+type StdTicker struct {
+	C <-chan Time // The channel on which the ticks are delivered.
+	*Ticker
+}
+
+// NewStdTimer is like NewTimer, but returns a StdTimer.
+func (c *Clock) NewStdTimer(d Duration) *StdTimer {
+	t := c.NewTimer(d)
+	return &StdTimer{t.c, t}
+}
+
+// StdAfterFunc is like AfterFunc, but returns a StdTimer. As with
+// [time.AfterFunc], the C field of the returned StdTimer is nil.
+func (c *Clock) StdAfterFunc(d Duration, f func()) *StdTimer {
+	t := c.AfterFunc(d, f)
+	return &StdTimer{t.c, t}
+}
+
+// NewStdTicker is like NewTicker, but returns a StdTicker.
+func (c *Clock) NewStdTicker(d Duration) *StdTicker {
+	t := c.NewTicker(d)
+	return &StdTicker{t.c, t}
+}
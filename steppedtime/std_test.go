@@ -0,0 +1,41 @@
+package steppedtime_test
+
+import (
+	"testing"
+
+	. "github.com/noodlebox/clock/steppedtime"
+)
+
+func TestStdTimer(t *testing.T) {
+	c := NewClock()
+	tm := c.NewStdTimer(Second)
+	if tm.C != tm.Timer.C() {
+		t.Fatalf("StdTimer.C does not match Timer.C()")
+	}
+	c.Step(Second)
+	select {
+	case now := <-tm.C:
+		if now != Time(Second) {
+			t.Errorf("StdTimer fired at %v, want %v", now, Time(Second))
+		}
+	default:
+		t.Errorf("StdTimer did not fire")
+	}
+	if tm.Stop() {
+		t.Errorf("Stop reported an expired StdTimer as active")
+	}
+}
+
+func TestStdTicker(t *testing.T) {
+	c := NewClock()
+	tk := c.NewStdTicker(Second)
+	defer tk.Stop()
+	for i := 1; i <= 3; i++ {
+		c.Step(Second)
+		select {
+		case <-tk.C:
+		default:
+			t.Fatalf("StdTicker did not tick on step %d", i)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package steppedtime
+
+import (
+	"sort"
+)
+
+// CancelTag stops every pending Timer and Ticker with the given tag
+// attached, including those paused, as if by calling its Stop method, and
+// returns how many were stopped. A nil tag matches nothing.
+func (c *Clock) CancelTag(tag any) (n int) {
+	if tag == nil || !isComparable(tag) {
+		return 0
+	}
+
+	c.lock()
+	defer c.unlock()
+	for _, t := range c.tagged(tag) {
+		c.unschedule(t)
+		c.unhold(t)
+		t.paused = false
+		c.cancelled(t)
+		n++
+	}
+	return
+}
+
+// TimersByTag returns the times at which each pending Timer or Ticker with
+// the given tag attached will next fire, in order. Paused Timers and
+// Tickers, having no time at which to fire, are not included. A nil tag
+// matches nothing.
+func (c *Clock) TimersByTag(tag any) []Time {
+	if tag == nil || !isComparable(tag) {
+		return nil
+	}
+
+	c.lock()
+	var whens []Time
+	for _, t := range c.tagged(tag) {
+		if !t.paused {
+			whens = append(whens, t.when)
+		}
+	}
+	c.unlock()
+	sort.Slice(whens, func(i, j int) bool { return whens[i] < whens[j] })
+	return whens
+}
+
+// Return pending timers with tag attached, whether queued or paused.
+// Callers must hold the lock.
+func (c *Clock) tagged(tag any) (ts []*timer) {
+	for _, t := range c.queue {
+		if t.tag == tag {
+			ts = append(ts, t)
+		}
+	}
+	for t := range c.held {
+		if t.tag == tag {
+			ts = append(ts, t)
+		}
+	}
+	return
+}
+
+// Track t as pending while paused, though not in the queue, so that it may
+// still be found by its tag. Callers must hold the lock.
+func (c *Clock) hold(t *timer) {
+	if c.held == nil {
+		c.held = make(map[*timer]struct{})
+	}
+	c.held[t] = struct{}{}
+}
+
+// Stop tracking t as paused. Callers must hold the lock.
+func (c *Clock) unhold(t *timer) {
+	delete(c.held, t)
+}
+
+// Report whether v may be compared with ==, as a tag must be, without
+// panicking. A value of a comparable type may still hold one that is not,
+// such as an interface field holding a slice, so this is checked by trying.
+func isComparable(v any) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	_ = v == v
+	return true
+}
+
+// Panic if tag is not comparable, rather than later, while the lock is
+// held, when it is compared.
+func checkTag(tag any, method string) {
+	if !isComparable(tag) {
+		panic("non-comparable tag for steppedtime." + method)
+	}
+}
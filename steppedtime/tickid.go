@@ -0,0 +1,91 @@
+package steppedtime
+
+// TickID is a sequence number given to each firing of a Timer or Ticker on a
+// Clock, starting from 1, in the order they fired. Unlike their times, which
+// collide when many are due at once or the clock is stepped past several,
+// TickIDs totally order the events from one Clock. Zero means never fired.
+type TickID uint64
+
+// A Firing is a firing of a Timer or Ticker, as delivered by one created by
+// NewNumberedTimer or NewNumberedTicker.
+type Firing struct {
+	When Time   // Time at which it fired
+	ID   TickID // Sequence number of the firing
+}
+
+// LastTickID returns the TickID of the most recent firing of any Timer or
+// Ticker on c, or zero if none has fired.
+func (c *Clock) LastTickID() TickID {
+	c.lock()
+	defer c.unlock()
+	return TickID(c.seq)
+}
+
+// NewNumberedTimer is like NewTimer, but sends a Firing on ch when it fires,
+// so that the receiver has its TickID along with its time. Any number of
+// Timers and Tickers may share ch, for a single receiver to order them all.
+// If ch is not ready to receive, the Firing is dropped. The C method of the
+// returned Timer returns nil.
+func (c *Clock) NewNumberedTimer(d Duration, ch chan<- Firing) *Timer {
+	// The firing being made is always the last one numbered
+	return c.newTimerFunc(d, func(when Time) {
+		select {
+		case ch <- Firing{when, TickID(c.seq)}:
+		default:
+		}
+	})
+}
+
+// NewNumberedTicker is like NewTicker, but sends a Firing on ch for each
+// tick, so that the receiver has its TickID along with its time. Any number
+// of Timers and Tickers may share ch, for a single receiver to order them
+// all. If ch is not ready to receive, the Firing is dropped and reported by
+// Missed. The C method of the returned Ticker returns nil. The duration d
+// must be greater than zero; if not, NewNumberedTicker will panic.
+func (c *Clock) NewNumberedTicker(d Duration, ch chan<- Firing) *Ticker {
+	if d <= 0 {
+		panic("non-positive interval for steppedtime.Clock.NewNumberedTicker")
+	}
+
+	return c.newTickerFunc(d, func(when Time) bool {
+		select {
+		case ch <- Firing{when, TickID(c.seq)}:
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+// TickID returns the TickID of the last time the Timer fired, or zero if it
+// never has. It is recorded before the time is sent, so that a receiver may
+// read it afterwards, as long as the Timer is not reset to fire again in the
+// meantime; a Timer created by NewNumberedTimer delivers it instead.
+func (t *Timer) TickID() TickID {
+	if t.t == nil {
+		panic("TickID called on uninitialized steppedtime.Timer")
+	}
+
+	t.s.lock()
+	defer t.s.unlock()
+	if t.t.keep {
+		// Never recycled, so not recorded on t
+		return t.t.id
+	}
+	return t.id
+}
+
+// TickID returns the TickID of the last tick of the Ticker, or zero if it
+// has not ticked, whether or not the tick was delivered. As the Ticker may
+// tick again before a receiver gets to read it, a Ticker created by
+// NewNumberedTicker, which delivers each tick's TickID along with it, is
+// better suited to ordering ticks.
+func (t *Ticker) TickID() TickID {
+	if t.t == nil {
+		panic("TickID called on uninitialized steppedtime.Ticker")
+	}
+
+	t.s.lock()
+	defer t.s.unlock()
+	return t.t.id
+}
@@ -2,6 +2,8 @@ package steppedtime
 
 import (
 	"time"
+
+	"github.com/noodlebox/clock/internal/durations"
 )
 
 // See [time.Duration].
@@ -57,6 +59,32 @@ func (*Clock) ParseDuration(s string) (Duration, error) {
 	return time.ParseDuration(s)
 }
 
+// MustParseDuration is like ParseDuration, but panics if s cannot be parsed.
+func (*Clock) MustParseDuration(s string) Duration {
+	return durations.MustParse(s)
+}
+
+// ParseISODuration parses an ISO 8601 duration, such as "P1DT2H30M" or
+// "PT0.5S", optionally preceded by a sign. Days are taken as 24 hours and
+// weeks as 7 days; years and months, whose lengths vary, are rejected.
+func (*Clock) ParseISODuration(s string) (Duration, error) {
+	return durations.ParseISO(s)
+}
+
+// FormatISODuration formats d as an ISO 8601 duration, such as "P1DT2H30M",
+// as accepted by ParseISODuration.
+func (*Clock) FormatISODuration(d Duration) string {
+	return durations.FormatISO(d)
+}
+
+// DurationOf returns the sum of the given counts of units, written as
+// alternating pairs of a count and a unit, such as DurationOf(1, Hour, 30,
+// Minute). It panics if given an odd number of values, or if the sum
+// overflows.
+func (*Clock) DurationOf(pairs ...Duration) Duration {
+	return durations.Of(pairs...)
+}
+
 // Time represents the number of nanoseconds since the start of the clock.
 type Time int64
 
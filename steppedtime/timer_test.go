@@ -0,0 +1,956 @@
+package steppedtime_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	stdtime "time"
+
+	"github.com/noodlebox/clock/mocktime"
+	. "github.com/noodlebox/clock/steppedtime"
+)
+
+// Test that expired timers may be recycled without affecting stale handles.
+func TestTimerRecycled(t *testing.T) {
+	c := NewClock()
+	a := c.NewTimer(Second)
+	c.Step(Second)
+	<-a.C()
+
+	// b likely reuses the timer underlying a
+	b := c.NewTimer(Second)
+	if a.Stop() {
+		t.Errorf("Stop reported an expired timer as active")
+	}
+	if a.Reset(2 * Second) {
+		t.Errorf("Reset reported an expired timer as active")
+	}
+
+	c.Step(Second)
+	select {
+	case <-b.C():
+	default:
+		t.Fatalf("timer was stopped through a stale handle")
+	}
+	select {
+	case <-a.C():
+		t.Fatalf("reset timer fired early")
+	default:
+	}
+	c.Step(Second)
+	select {
+	case <-a.C():
+	default:
+		t.Fatalf("reset timer did not fire")
+	}
+}
+
+func TestNewTimerChan(t *testing.T) {
+	c := NewClock()
+	ch := make(chan Time, 2)
+	c.NewTimerChan(Second, ch)
+	c.NewTimerChan(2*Second, ch)
+	c.Step(2 * Second)
+	if len(ch) != 2 {
+		t.Errorf("shared channel received %d values, want 2", len(ch))
+	}
+}
+
+// Test that Timers and Tickers are stopped once their channels are
+// unreachable, but not while the channel is still held.
+func TestCollectUnreferenced(t *testing.T) {
+	c := NewClock()
+	c.SetCollectUnreferenced(true)
+	pending := func() int { return c.TimerStats().Pending }
+
+	ch := c.NewTicker(Second).C()
+	for i := 0; i < 10; i++ {
+		c.After(Second)
+		c.Tick(Second)
+		c.NewTimer(Second)
+	}
+	for i := 0; i < 1000 && pending() > 1; i++ {
+		runtime.GC()
+		runtime.Gosched()
+	}
+	if n := pending(); n != 1 {
+		t.Fatalf("%d timers pending after collection, want 1", n)
+	}
+
+	c.Step(Second)
+	if when := <-ch; when != Time(Second) {
+		t.Errorf("ticker fired at %v, want %v", when, Time(Second))
+	}
+}
+
+func TestTickerMissed(t *testing.T) {
+	c := NewClock()
+	tk := c.NewTicker(Second)
+	defer tk.Stop()
+
+	c.Step(Second)
+	c.Step(Second)     // Dropped, as the first is still pending
+	c.Step(3 * Second) // Skips two, then dropped
+	<-tk.C()
+	if n := tk.Missed(); n != 4 {
+		t.Errorf("Missed() = %d, want 4", n)
+	}
+	if n := tk.Missed(); n != 0 {
+		t.Errorf("Missed() = %d after reset, want 0", n)
+	}
+}
+
+func TestBufferedTicker(t *testing.T) {
+	c := NewClock()
+	tk := c.NewBufferedTicker(Second, 3)
+	defer tk.Stop()
+
+	// Only the last 3 ticks of a step past 5 periods are sent
+	c.Step(5 * Second)
+	for i := 3; i <= 5; i++ {
+		if got, want := <-tk.C(), Time(0).Add(Duration(i)*Second); got != want {
+			t.Errorf("tick %d at %v, want %v", i, got, want)
+		}
+	}
+	if n := tk.Missed(); n != 2 {
+		t.Errorf("Missed() = %d, want 2", n)
+	}
+}
+
+func TestTickFunc(t *testing.T) {
+	c := NewClock()
+	c.SetCallbackLimit(1) // Run callbacks one at a time, in order
+	ch := make(chan struct{}, 3)
+	tk := c.TickFunc(Second, func() { ch <- struct{}{} })
+	if tk.C() != nil {
+		t.Errorf("TickFunc returned a Ticker with a channel")
+	}
+
+	for i := 0; i < 3; i++ {
+		c.Step(Second)
+		<-ch
+	}
+	tk.Stop()
+	c.Step(Second)
+	// Any call of f after Stop would come before this one
+	after := make(chan struct{})
+	c.AfterFunc(Second, func() { close(after) })
+	c.Step(Second)
+	<-after
+	if len(ch) != 0 {
+		t.Errorf("f called after Stop")
+	}
+}
+
+// Test that an AfterFunc callback may use the clock and its own Timer.
+func TestAfterFuncReentrant(t *testing.T) {
+	c := NewClock()
+	done := make(chan int)
+	n := 0
+	var tm *Timer
+	tm = c.AfterFunc(Second, func() {
+		n++
+		if tm.Stop() {
+			t.Errorf("Stop reported a fired timer as active")
+		}
+		c.NewTimer(Second).Stop()
+		if n < 3 {
+			tm.Reset(Second)
+		}
+		done <- n
+	})
+
+	for i := 1; i <= 3; i++ {
+		c.Step(Second)
+		if got := <-done; got != i {
+			t.Fatalf("callback ran %d times, want %d", got, i)
+		}
+	}
+	c.Step(Second)
+	stdtime.Sleep(10 * stdtime.Millisecond)
+	if n != 3 {
+		t.Errorf("callback ran %d times after its last Reset, want 3", n)
+	}
+}
+
+func TestAt(t *testing.T) {
+	c := NewClock()
+	ch := make(chan Time, 1)
+	c.At(Time(3*Second), func(now Time) { ch <- now })
+
+	c.Step(2 * Second)
+	stdtime.Sleep(10 * stdtime.Millisecond)
+	if len(ch) != 0 {
+		t.Fatalf("f called before its time")
+	}
+	c.Step(2 * Second)
+	if got := <-ch; got != Time(4*Second) {
+		t.Errorf("f called with %v, want %v", got, Time(4*Second))
+	}
+
+	// A time already passed fires at once.
+	c.At(Time(Second), func(now Time) { ch <- now })
+	if got := <-ch; got != Time(4*Second) {
+		t.Errorf("f called with %v, want %v", got, Time(4*Second))
+	}
+
+	if tm := c.At(Time(5*Second), func(Time) { ch <- 0 }); !tm.Stop() {
+		t.Errorf("Stop() = false on a pending timer")
+	}
+	c.Step(Second)
+	stdtime.Sleep(10 * stdtime.Millisecond)
+	if len(ch) != 0 {
+		t.Errorf("f called after Stop")
+	}
+}
+
+func TestLanes(t *testing.T) {
+	c := NewClock()
+	c.SetCallbackLimit(1)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var order []Lane
+	for _, lane := range []Lane{2, Normal, Immediate, 1} {
+		lane := lane
+		wg.Add(1)
+		tm := c.AfterFunc(Second, func() {
+			mu.Lock()
+			order = append(order, lane)
+			mu.Unlock()
+			wg.Done()
+		})
+		tm.SetLane(lane)
+	}
+	c.Step(Second)
+	wg.Wait()
+
+	want := []Lane{Immediate, Normal, 1, 2}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("timers fired in lanes %v, want %v", order, want)
+		}
+	}
+}
+
+func TestStepEvents(t *testing.T) {
+	c := NewClock()
+	c.NewTimer(3 * Second).SetTag("timer")
+	tk := c.NewTicker(2 * Second)
+	tk.SetTag("ticker")
+
+	var got []Event
+	c.StepEvents(5 * Second)(func(e Event) bool {
+		if now := c.Now(); now != e.When {
+			t.Errorf("Now() = %v while yielding an event at %v", now, e.When)
+		}
+		if e.Tag == "timer" {
+			// Scheduled within the step, so yielded in turn
+			c.NewTimer(Second).SetTag("later")
+		}
+		got = append(got, e)
+		return true
+	})
+	want := []Event{
+		{When: Time(2 * Second), Periodic: true, Tag: "ticker", ID: 1},
+		{When: Time(3 * Second), Tag: "timer", ID: 2},
+		{When: Time(4 * Second), Periodic: true, Tag: "ticker", ID: 3},
+		{When: Time(4 * Second), Tag: "later", ID: 4},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("StepEvents yielded %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if now := c.Now(); now != Time(5*Second) {
+		t.Errorf("Now() = %v after the step, want %v", now, Time(5*Second))
+	}
+
+	// Stopping early leaves the clock at the last event
+	c.StepEvents(Hour)(func(Event) bool { return false })
+	if now := c.Now(); now != Time(6*Second) {
+		t.Errorf("Now() = %v after stopping early, want %v", now, Time(6*Second))
+	}
+
+	// Stepping past the end while yielding doesn't rewind the clock
+	c.StepEvents(2 * Second)(func(Event) bool {
+		c.Step(10 * Second)
+		return true
+	})
+	if now := c.Now(); now != Time(18*Second) {
+		t.Errorf("Now() = %v after stepping past the end, want %v", now, Time(18*Second))
+	}
+}
+
+func TestCallbackLimit(t *testing.T) {
+	c := NewClock()
+	c.SetCallbackLimit(2)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	running, peak := 0, 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		c.AfterFunc(Second, func() {
+			defer wg.Done()
+			mu.Lock()
+			running++
+			if running > peak {
+				peak = running
+			}
+			mu.Unlock()
+			stdtime.Sleep(stdtime.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		})
+	}
+	c.Step(Second)
+	wg.Wait()
+	if peak > 2 {
+		t.Errorf("%d callbacks ran at once, want at most 2", peak)
+	}
+}
+
+func TestCancelTag(t *testing.T) {
+	c := NewClock()
+	a := c.NewTimer(2 * Second)
+	a.SetTag("session")
+	tk := c.NewTicker(Second)
+	tk.SetTag("session")
+	b := c.NewTimer(Second)
+	b.SetTag("other")
+
+	got, want := c.TimersByTag("session"), []Time{Time(Second), Time(2 * Second)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TimersByTag() = %v, want %v", got, want)
+	}
+	if n := c.CancelTag("session"); n != 2 {
+		t.Errorf("CancelTag() = %d, want 2", n)
+	}
+	c.Step(2 * Second)
+	select {
+	case <-a.C():
+		t.Errorf("canceled timer fired")
+	case <-tk.C():
+		t.Errorf("canceled ticker fired")
+	case <-b.C():
+	}
+
+	// Paused timers are found and cancelled too
+	p := c.NewTimer(Second)
+	p.SetTag("paused")
+	p.Pause()
+	if n := c.CancelTag("paused"); n != 1 {
+		t.Errorf("CancelTag() = %d with a paused timer, want 1", n)
+	}
+	if p.Resume() {
+		t.Errorf("Resume() = true after CancelTag")
+	}
+
+	// Tags that can't be compared are refused at once
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("SetTag() accepted a non-comparable tag")
+			}
+		}()
+		b.SetTag([]int{1})
+	}()
+	if n := c.CancelTag([]int{1}); n != 0 {
+		t.Errorf("CancelTag() = %d with a non-comparable tag, want 0", n)
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+	c := NewClock()
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() { errc <- c.SleepContext(ctx, Hour) }()
+	cancel()
+	if err := <-errc; err != context.Canceled {
+		t.Errorf("SleepContext() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestClose(t *testing.T) {
+	c := NewClock()
+	errc := make(chan error)
+	go func() { errc <- c.SleepContext(context.Background(), Hour) }()
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(Hour)
+		close(done)
+	}()
+	c.Close()
+	if err := <-errc; err != ErrClosed {
+		t.Errorf("SleepContext() = %v, want %v", err, ErrClosed)
+	}
+	<-done
+	c.Sleep(Hour) // Returns immediately once closed
+	if err := c.Close(); err != ErrClosed {
+		t.Errorf("second Close() = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestCloseCancelsTimers(t *testing.T) {
+	c := NewClock()
+	tm := c.NewTimer(Second)
+	tk := c.NewTicker(Second)
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if tm.Stop() {
+		t.Errorf("Stop() = true on a timer cancelled by Close")
+	}
+	later := c.NewTimer(Second)
+	if tm.Reset(Second) {
+		t.Errorf("Reset() = true on a timer cancelled by Close")
+	}
+	c.Step(Hour)
+	select {
+	case <-tm.C():
+		t.Errorf("timer fired after Close")
+	case <-later.C():
+		t.Errorf("timer created after Close fired")
+	case <-tk.C():
+		t.Errorf("ticker fired after Close")
+	default:
+	}
+
+	// Those that can fail say why nothing will fire
+	if _, err := c.TryNewTimer(Second); err != ErrClosed {
+		t.Errorf("TryNewTimer() = %v, want %v", err, ErrClosed)
+	}
+	if _, err := tm.TryReset(Second); err != ErrClosed {
+		t.Errorf("Timer.TryReset() = %v, want %v", err, ErrClosed)
+	}
+	if err := tk.TryReset(Second); err != ErrClosed {
+		t.Errorf("Ticker.TryReset() = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestTimerZeroDuration(t *testing.T) {
+	c := NewClock()
+	for _, d := range []Duration{0, -Second} {
+		select {
+		case <-c.NewTimer(d).C():
+		default:
+			t.Errorf("NewTimer(%v) did not fire without a Step", d)
+		}
+	}
+}
+
+func TestTimerFireNow(t *testing.T) {
+	c := NewClock()
+	tm := c.NewTimer(Hour)
+	if !tm.FireNow() {
+		t.Errorf("FireNow() = false on an active timer")
+	}
+	select {
+	case <-tm.C():
+	default:
+		t.Errorf("FireNow() did not fire the timer")
+	}
+	if tm.FireNow() {
+		t.Errorf("FireNow() = true on an expired timer")
+	}
+}
+
+func TestTimerPause(t *testing.T) {
+	c := NewClock()
+	tm := c.NewTimer(3 * Second)
+	c.Step(Second)
+	if !tm.Pause() {
+		t.Fatalf("Pause() = false on an active timer")
+	}
+	if tm.Pause() {
+		t.Errorf("Pause() = true on a paused timer")
+	}
+	c.Step(Hour)
+	select {
+	case <-tm.C():
+		t.Fatalf("timer fired while paused")
+	default:
+	}
+
+	if !tm.Resume() {
+		t.Fatalf("Resume() = false on a paused timer")
+	}
+	c.Step(Second)
+	select {
+	case <-tm.C():
+		t.Fatalf("timer fired before remaining time elapsed")
+	default:
+	}
+	c.Step(Second)
+	if got, want := <-tm.C(), Time(0).Add(Hour+3*Second); got != want {
+		t.Errorf("timer fired at %v, want %v", got, want)
+	}
+	if tm.Resume() {
+		t.Errorf("Resume() = true on an expired timer")
+	}
+
+	tm.Reset(Second)
+	tm.Pause()
+	if !tm.Stop() {
+		t.Errorf("Stop() = false on a paused timer")
+	}
+	if tm.Resume() {
+		t.Errorf("Resume() = true on a stopped timer")
+	}
+}
+
+// Expired timers are recycled and deliver on their channel directly, so
+// that creating one allocates little besides its channel and handle.
+func BenchmarkNewTimer(b *testing.B) {
+	c := NewClock()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tm := c.NewTimer(Nanosecond)
+		c.Step(Nanosecond)
+		<-tm.C()
+	}
+}
+
+func BenchmarkTimerChurn(b *testing.B) {
+	c := NewClock()
+	ch := make(chan Time, 1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.NewTimerChan(Nanosecond, ch)
+		c.Step(Nanosecond)
+		<-ch
+	}
+}
+
+func TestTickerPause(t *testing.T) {
+	c := NewClock()
+	tk := c.NewTicker(3 * Second)
+	defer tk.Stop()
+
+	c.Step(Second)
+	tk.Pause()
+	c.Step(10 * Second)
+	select {
+	case <-tk.C():
+		t.Fatal("tick while paused")
+	default:
+	}
+
+	tk.Resume()
+	c.Step(Second)
+	select {
+	case <-tk.C():
+		t.Fatal("tick before remaining time elapsed")
+	default:
+	}
+	c.Step(Second)
+	if got, want := <-tk.C(), Time(0).Add(13*Second); got != want {
+		t.Errorf("tick at %v, want %v", got, want)
+	}
+	c.Step(3 * Second)
+	if got, want := <-tk.C(), Time(0).Add(16*Second); got != want {
+		t.Errorf("tick at %v, want %v", got, want)
+	}
+}
+
+func TestTickerSetPeriod(t *testing.T) {
+	c := NewClock()
+	tk := c.NewTicker(3 * Second)
+	defer tk.Stop()
+
+	c.Step(Second)
+	tk.SetPeriod(Second)
+	c.Step(Second)
+	select {
+	case <-tk.C():
+		t.Fatal("tick before the one already due")
+	default:
+	}
+	for _, want := range []Time{Time(0).Add(3 * Second), Time(0).Add(4 * Second)} {
+		c.Step(Second)
+		if got := <-tk.C(); got != want {
+			t.Errorf("tick at %v, want %v", got, want)
+		}
+	}
+}
+
+func TestNewClockAt(t *testing.T) {
+	start := Time(0).Add(Hour)
+	c := NewClockAt(start, WithQueueCapacity(16))
+	if got := c.Now(); got != start {
+		t.Fatalf("Now() = %v, want %v", got, start)
+	}
+	tm := c.NewTimer(Second)
+	c.Step(Second)
+	if got, want := <-tm.C(), start.Add(Second); got != want {
+		t.Errorf("timer fired at %v, want %v", got, want)
+	}
+}
+
+func BenchmarkTimerBurst(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c := NewClock(WithQueueCapacity(1024))
+		for j := 0; j < 1024; j++ {
+			c.AfterFunc(Duration(j+1), func() {})
+		}
+	}
+}
+
+// limited occurs each second until a limit.
+type limited Time
+
+func (l limited) Next(after Time) (Time, bool) {
+	return after + Time(Second), after+Time(Second) <= Time(l)
+}
+
+func TestScheduleFunc(t *testing.T) {
+	c := NewClock()
+	ch := make(chan Time, 4)
+	done := make(chan struct{})
+	c.ScheduleFunc(limited(2*Second), func(at Time) {
+		ch <- at
+		if at == Time(2*Second) {
+			close(done)
+		}
+	})
+	c.Step(5 * Second)
+	<-done
+	if got := len(ch); got != 2 {
+		t.Errorf("f called %d times, want 2", got)
+	}
+
+	// A schedule that fails to advance ends at once.
+	if s := c.ScheduleFunc(limited(0), func(Time) {}); s.Stop() {
+		t.Errorf("Stop() = true on a schedule with no occurrences")
+	}
+}
+
+func TestTickID(t *testing.T) {
+	c := NewClock()
+	tm := c.NewTimer(Second)
+	tk := c.NewTicker(Second)
+	if id := tm.TickID(); id != 0 {
+		t.Errorf("TickID() = %d before firing, want 0", id)
+	}
+
+	// Both fire at the same time, but in a definite order
+	c.Step(Second)
+	a, b := tm.TickID(), tk.TickID()
+	if a == 0 || b == 0 || a == b {
+		t.Fatalf("TickIDs %d and %d for simultaneous firings, want distinct", a, b)
+	}
+	if last := c.LastTickID(); last != 2 || (a != last && b != last) {
+		t.Errorf("LastTickID() = %d, want 2, the later of %d and %d", last, a, b)
+	}
+
+	// A recycled timer keeps the ID of its own firing
+	c.AfterFunc(Second, func() {})
+	c.Step(Second)
+	if id := tm.TickID(); id != a {
+		t.Errorf("TickID() = %d after another timer fired, want %d", id, a)
+	}
+	tm.Reset(Second)
+	c.Step(Second)
+	if id := tm.TickID(); id+1 < c.LastTickID() {
+		t.Errorf("TickID() = %d after firing again, want a recent one", id)
+	}
+
+	// As does one that is never recycled, as it may be collected
+	c.SetCollectUnreferenced(true)
+	kept := c.NewTimer(Second)
+	c.Step(Second)
+	if id := kept.TickID(); id != c.LastTickID() {
+		t.Errorf("TickID() = %d on a collectable timer, want %d", id, c.LastTickID())
+	}
+	c.SetCollectUnreferenced(false)
+
+	// Numbered timers and tickers deliver the TickID along with the time
+	ch := make(chan Firing, 2)
+	c.NewNumberedTimer(Second, ch)
+	ntk := c.NewNumberedTicker(Second, ch)
+	defer ntk.Stop()
+	if ntk.C() != nil {
+		t.Errorf("NewNumberedTicker returned a Ticker with a channel")
+	}
+	c.Step(Second)
+	last := c.LastTickID()
+	for _, want := range []TickID{last - 1, last} {
+		if f := <-ch; f.ID != want || f.When != c.Now() {
+			t.Errorf("got %v, want TickID %d at %v", f, want, c.Now())
+		}
+	}
+	for i := 0; i < 3; i++ {
+		c.Step(Second)
+	}
+	if n := ntk.Missed(); n != 1 {
+		t.Errorf("Missed() = %d with a full channel, want 1", n)
+	}
+}
+
+func TestClockOf(t *testing.T) {
+	type Frames int
+	c := NewClockOf[Frames](20 * Millisecond)
+
+	tk := c.NewTicker(3)
+	defer tk.Stop()
+	tm := c.NewTimer(4)
+	c.Step(2)
+	select {
+	case <-tk.C():
+		t.Fatalf("ticked after 2 of 3 frames")
+	default:
+	}
+	c.Step(1)
+	if got, want := <-tk.C(), TimeOf[Frames](3); got != want {
+		t.Errorf("ticked at frame %d, want %d", got, want)
+	}
+	if got := c.Now(); got != 3 {
+		t.Errorf("Now() = frame %d, want 3", got)
+	}
+	if got, want := c.Clock().Now(), Time(60*Millisecond); got != want {
+		t.Errorf("Clock().Now() = %v, want %v", got, want)
+	}
+	c.Clock().Step(30 * Millisecond)
+	if got, want := <-tm.C(), TimeOf[Frames](4); got != want {
+		t.Errorf("timer fired at frame %d, want %d", got, want)
+	}
+	if got := c.Since(0); got != 4 {
+		t.Errorf("Since(0) = %d frames, want 4", got)
+	}
+
+	if got := c.Count(50 * Millisecond); got != 2 {
+		t.Errorf("Count(50ms) = %d frames, want 2", got)
+	}
+	if got := c.Seconds(1); got != 50 {
+		t.Errorf("Seconds(1) = %d frames, want 50", got)
+	}
+	// Counts beyond the range of Duration are clamped, not wrapped
+	if got, want := c.Duration(Frames(math.MaxInt64)), Duration(math.MaxInt64); got != want {
+		t.Errorf("Duration(MaxInt64) = %v, want %v", got, want)
+	}
+	if got, want := c.Duration(Frames(math.MinInt64)), Duration(math.MinInt64); got != want {
+		t.Errorf("Duration(MinInt64) = %v, want %v", got, want)
+	}
+}
+
+func TestWithMaxTimers(t *testing.T) {
+	c := NewClock(WithMaxTimers(2))
+	tm, err := c.TryNewTimer(Second)
+	if err != nil {
+		t.Fatalf("TryNewTimer() = %v within the limit", err)
+	}
+	if _, err := c.TryNewTicker(Second); err != nil {
+		t.Fatalf("TryNewTicker() = %v within the limit", err)
+	}
+	if _, err := c.TryAfterFunc(Second, func() {}); err != ErrTooManyTimers {
+		t.Errorf("TryAfterFunc() = %v beyond the limit, want %v", err, ErrTooManyTimers)
+	}
+	if _, err := c.TryAt(Time(Second), func(Time) {}); err != ErrTooManyTimers {
+		t.Errorf("TryAt() = %v beyond the limit, want %v", err, ErrTooManyTimers)
+	}
+
+	// Sleepers are not counted, and other methods are never refused
+	go c.Sleep(Hour)
+	for c.TimerStats().Sleeping == 0 {
+		runtime.Gosched()
+	}
+	extra := c.NewTimer(Hour)
+	if got, want := c.TimerStats(), (TimerStats{Pending: 3, Sleeping: 1, Max: 2, Rejected: 2}); got != want {
+		t.Errorf("TimerStats() = %+v, want %+v", got, want)
+	}
+
+	// Nor is restarting a timer through them
+	c.Step(Second)
+	<-tm.C()
+	if _, err := tm.TryReset(Second); err != ErrTooManyTimers {
+		t.Errorf("TryReset() = %v beyond the limit, want %v", err, ErrTooManyTimers)
+	}
+	extra.Stop()
+	if active, err := tm.TryReset(Second); active || err != nil {
+		t.Errorf("TryReset() = %v, %v after making room, want false, nil", active, err)
+	}
+	tm.Pause()
+	c.NewTimer(Second)
+	if _, err := tm.TryResume(); err != ErrTooManyTimers {
+		t.Errorf("TryResume() = %v beyond the limit, want %v", err, ErrTooManyTimers)
+	}
+	c.Close()
+}
+
+func TestTimerOnStop(t *testing.T) {
+	c := NewClock()
+	stopped := make(chan string, 4)
+	onStop := func(name string) func() { return func() { stopped <- name } }
+
+	a, b, f := c.NewTimer(Second), c.NewTimer(Second), c.NewTimer(Millisecond)
+	a.OnStop(onStop("a"))
+	b.OnStop(onStop("b"))
+	f.OnStop(onStop("fired"))
+	p := c.NewTimer(Second)
+	p.OnStop(onStop("paused"))
+	p.Pause()
+
+	c.Step(Millisecond)
+	if a.Stop(); <-stopped != "a" {
+		t.Errorf("OnStop not called by Stop")
+	}
+	a.Stop()
+	f.Stop()
+	c.Close()
+	if got := []string{<-stopped, <-stopped}; !(got[0] == "b" && got[1] == "paused" ||
+		got[0] == "paused" && got[1] == "b") {
+		t.Errorf("OnStop called for %q, want b and paused, cancelled by Close", got)
+	}
+	if p.Resume() {
+		t.Errorf("Resume() = true on a paused timer cancelled by Close")
+	}
+	stdtime.Sleep(10 * stdtime.Millisecond)
+	if len(stopped) != 0 {
+		t.Errorf("OnStop called for %q, which fired or was already stopped", <-stopped)
+	}
+}
+
+func TestAutoStep(t *testing.T) {
+	rc := mocktime.NewClock()
+	c := NewClock(WithRealClock(rc))
+	stop := c.AutoStep(stdtime.Second, 5*Second)
+	for i := 1; i <= 3; i++ {
+		for rc.NextAt().IsZero() {
+			runtime.Gosched()
+		}
+		rc.Set(rc.NextAt())
+		for c.Now() != Time(5*i)*Time(Second) {
+			runtime.Gosched()
+		}
+	}
+	stop()
+	rc.Step(stdtime.Hour)
+	if got, want := c.Now(), Time(15*Second); got != want {
+		t.Errorf("Now() = %v after stop, want %v", got, want)
+	}
+}
+
+func TestStepRandom(t *testing.T) {
+	c := NewClock()
+	rng := rand.New(rand.NewSource(1))
+	const n = 10000
+	var total Duration
+	for i := 0; i < n; i++ {
+		dt := c.StepRandom(rng, Exponential(Second))
+		if dt < 0 {
+			t.Fatalf("StepRandom() = %v, want a non-negative step", dt)
+		}
+		total += dt
+	}
+	if got := c.Now(); got != Time(total) {
+		t.Errorf("Now() = %v, want %v", got, Time(total))
+	}
+	if mean := total / n; mean < 950*Millisecond || mean > 1050*Millisecond {
+		t.Errorf("mean step = %v, want about %v", mean, Second)
+	}
+
+	for i := 0; i < 100; i++ {
+		if dt := c.StepRandom(nil, Uniform(Second, 2*Second)); dt < Second || dt >= 2*Second {
+			t.Fatalf("StepRandom(Uniform(1s, 2s)) = %v", dt)
+		}
+	}
+	if dt := c.StepRandom(rng, Fixed(-Second)); dt != 0 {
+		t.Errorf("StepRandom() = %v for a negative draw, want 0", dt)
+	}
+}
+
+func TestTimerPostpone(t *testing.T) {
+	c := NewClock()
+	tm := c.NewTimer(Second)
+	if !tm.Postpone(Second) {
+		t.Errorf("Postpone() = false for an active timer")
+	}
+	c.Step(Second)
+	select {
+	case <-tm.C():
+		t.Fatalf("Timer fired before its postponed time")
+	default:
+	}
+	if !tm.Advance(2 * Second) {
+		t.Errorf("Advance() = false for an active timer")
+	}
+	select {
+	case now := <-tm.C():
+		if now != Time(Second) {
+			t.Errorf("Timer fired at %v, want %v", now, Time(Second))
+		}
+	default:
+		t.Fatalf("Timer did not fire once advanced past its time")
+	}
+	if tm.Postpone(Second) {
+		t.Errorf("Postpone() = true for an expired timer")
+	}
+
+	tm = c.NewTimer(Second)
+	tm.Pause()
+	tm.Postpone(Second)
+	tm.Resume()
+	c.Step(Second)
+	select {
+	case <-tm.C():
+		t.Fatalf("paused Timer fired before its postponed time")
+	default:
+	}
+	c.Step(Second)
+	<-tm.C()
+}
+
+func TestQueueStats(t *testing.T) {
+	c := NewClock()
+	tk := c.NewTicker(Second)
+	defer tk.Stop()
+	tm := c.NewTimer(Second)
+	c.NewTimer(Second)
+	tm.Reset(2 * Second)
+	tm.Reset(3 * Second)
+
+	c.Step(Second)
+	<-tk.C()
+	c.Step(Second)
+	want := QueueStats{Depth: 2, MaxDepth: 3, Fired: 3, Rescheduled: 2}
+	if got := c.QueueStats(); got != want {
+		t.Errorf("QueueStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSetTrace(t *testing.T) {
+	var b strings.Builder
+	c := NewClock(WithTrace(&b))
+	tm := c.NewTimer(2 * Second)
+	tk := c.NewTicker(Second)
+	tk.SetTag("tick")
+	c.Step(Second)
+	<-tk.C()
+	tm.Reset(3 * Second)
+	tm.Stop()
+	c.SetTrace(nil)
+	tk.Stop()
+
+	want := strings.Join([]string{
+		"t=0s schedule #1 due=2s",
+		"t=0s schedule #2 due=1s period=1s",
+		"t=1s fire #2 due=1s period=1s tag=tick id=1",
+		"t=1s reschedule #1 due=4s",
+		"t=1s cancel #1 due=4s",
+		"",
+	}, "\n")
+	if got := b.String(); got != want {
+		t.Errorf("trace:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package steppedtime
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Where to write a trace, and the last serial number given to a timer.
+type tracer struct {
+	w      io.Writer
+	serial uint64
+}
+
+// WithTrace traces every Timer and Ticker on the Clock to w from its
+// creation. See SetTrace.
+func WithTrace(w io.Writer) Option {
+	return func(c *Clock) {
+		c.trace = &tracer{w: w}
+	}
+}
+
+// SetTrace starts writing a line to w for every Timer and Ticker on c as it
+// is scheduled, rescheduled, fired, or cancelled, making a chronicle of a
+// simulation run for debugging. Each line gives the current time, the
+// event, a serial number identifying the timer for as long as it is in
+// use, and the time at which it is due, along with its period, lane, and
+// tag, where set, and for a firing, its TickID, as in:
+//
+//	t=1s fire #3 due=1s period=1s id=2
+//
+// Times are given as durations since time zero, as with Time.Sub, and a
+// pause shows as a cancel followed by a schedule on resuming. Lines are
+// written while holding the lock on c, so w must not call back into c, and
+// should not block. Errors from w are ignored. If w is nil, tracing stops.
+func (c *Clock) SetTrace(w io.Writer) {
+	c.lock()
+	defer c.unlock()
+	if w == nil {
+		c.trace = nil
+		return
+	}
+	c.trace = &tracer{w: w}
+}
+
+// Write a line to the trace describing event for t, if tracing. Callers
+// must hold the lock.
+func (c *Clock) traceEvent(event string, t *timer) {
+	tr := c.trace
+	if tr == nil {
+		return
+	}
+	if t.serial == 0 {
+		tr.serial++
+		t.serial = tr.serial
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "t=%v %s #%d due=%v", c.load().Sub(0), event, t.serial, t.when.Sub(0))
+	if t.period > 0 {
+		fmt.Fprintf(&b, " period=%v", t.period)
+	}
+	if t.lane != Normal {
+		fmt.Fprintf(&b, " lane=%d", t.lane)
+	}
+	if t.tag != nil {
+		fmt.Fprintf(&b, " tag=%v", t.tag)
+	}
+	if event == "fire" {
+		fmt.Fprintf(&b, " id=%d", t.id)
+	}
+	b.WriteByte('\n')
+	io.WriteString(tr.w, b.String())
+}
@@ -0,0 +1,292 @@
+package steppedtime
+
+import (
+	"math"
+)
+
+// Integer is the set of types that may serve as the Duration of a ClockOf.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// ClockOf is a Clock measuring time in a custom integer Duration type D,
+// such as a count of frames or simulation ticks, each unit of which lasts a
+// fixed Duration on an underlying Clock. Its methods accept and return only
+// values of D and of TimeOf[D], as do its Timers and Tickers, so that a
+// count of units cannot be mistaken for a Duration, or for a count in some
+// other unit, without an explicit conversion. Counts too large to represent
+// as a Duration on the underlying Clock are clamped to the largest that is.
+type ClockOf[D Integer] struct {
+	c    *Clock
+	unit Duration
+}
+
+// TimeOf represents the number of units of D elapsed since the start of a
+// ClockOf[D].
+type TimeOf[D Integer] int64
+
+// NewClockOf returns a new ClockOf measuring time in units of D, each
+// lasting unit on a new underlying Clock configured with opts. The duration
+// unit must be greater than zero; if not, NewClockOf will panic.
+func NewClockOf[D Integer](unit Duration, opts ...Option) *ClockOf[D] {
+	if unit <= 0 {
+		panic("non-positive unit for steppedtime.NewClockOf")
+	}
+	return &ClockOf[D]{c: NewClock(opts...), unit: unit}
+}
+
+// Clock returns the Clock underlying c, on which each unit lasts the
+// Duration passed to NewClockOf.
+func (c *ClockOf[D]) Clock() *Clock {
+	return c.c
+}
+
+// Duration returns the Duration lasted by n units on the underlying Clock.
+func (c *ClockOf[D]) Duration(n D) Duration {
+	if n > 0 && int64(n) > math.MaxInt64/int64(c.unit) {
+		return math.MaxInt64
+	}
+	if n < 0 && int64(n) < math.MinInt64/int64(c.unit) {
+		return math.MinInt64
+	}
+	return Duration(n) * c.unit
+}
+
+// Count returns the number of whole units lasted by d on the underlying
+// Clock, truncated toward zero.
+func (c *ClockOf[D]) Count(d Duration) D {
+	return D(d / c.unit)
+}
+
+// Convert t, a time on the underlying Clock, to whole units.
+func (c *ClockOf[D]) timeOf(t Time) TimeOf[D] {
+	return TimeOf[D](c.Count(Duration(t)))
+}
+
+// Convert t to a time on the underlying Clock.
+func (c *ClockOf[D]) time(t TimeOf[D]) Time {
+	return Time(c.Duration(D(t)))
+}
+
+// Seconds returns the count of units lasting n seconds, rounded to the
+// nearest, allowing c to serve as a reference clock for package
+// relativetime where D has a Seconds method.
+func (c *ClockOf[D]) Seconds(n float64) D {
+	return D(math.Round(n * float64(Second) / float64(c.unit)))
+}
+
+// Now returns the current time, in whole units.
+func (c *ClockOf[D]) Now() TimeOf[D] {
+	return c.timeOf(c.c.Now())
+}
+
+// Since returns the number of whole units elapsed since t.
+func (c *ClockOf[D]) Since(t TimeOf[D]) D {
+	return c.Now().Sub(t)
+}
+
+// Until returns the number of whole units until t.
+func (c *ClockOf[D]) Until(t TimeOf[D]) D {
+	return t.Sub(c.Now())
+}
+
+// Set sets the current time to t, as with Clock.Set.
+func (c *ClockOf[D]) Set(t TimeOf[D]) {
+	c.c.Set(c.time(t))
+}
+
+// Step advances the current time by n units, as with Clock.Step.
+func (c *ClockOf[D]) Step(n D) {
+	c.c.Step(c.Duration(n))
+}
+
+// Sleep pauses the current goroutine for at least n units.
+func (c *ClockOf[D]) Sleep(n D) {
+	c.c.Sleep(c.Duration(n))
+}
+
+// After waits for n units to elapse and then sends the current time on the
+// returned channel. It is equivalent to c.NewTimer(n).C().
+func (c *ClockOf[D]) After(n D) <-chan TimeOf[D] {
+	return c.NewTimer(n).ch
+}
+
+// AfterFunc waits for n units to elapse and then calls f in its own
+// goroutine. It returns a Timer that can be used to cancel the call using
+// its Stop method.
+func (c *ClockOf[D]) AfterFunc(n D, f func()) *TimerOf[D] {
+	return &TimerOf[D]{t: c.c.AfterFunc(c.Duration(n), f), c: c}
+}
+
+// NewTimer creates a new Timer that will send the current time on its
+// channel after at least n units. If n <= 0, it fires at once.
+func (c *ClockOf[D]) NewTimer(n D) *TimerOf[D] {
+	ch := make(chan TimeOf[D], 1)
+	t := c.c.newTimerFunc(c.Duration(n), func(now Time) {
+		select {
+		case ch <- c.timeOf(now):
+		default:
+		}
+	})
+	return &TimerOf[D]{t: t, c: c, ch: ch}
+}
+
+// NewTicker returns a new Ticker sending the current time on its channel
+// every n units, dropping ticks for slow receivers. The count n must be
+// greater than zero; if not, NewTicker will panic. Stop the ticker to
+// release associated resources.
+func (c *ClockOf[D]) NewTicker(n D) *TickerOf[D] {
+	if n <= 0 {
+		panic("non-positive interval for steppedtime.ClockOf.NewTicker")
+	}
+
+	ch := make(chan TimeOf[D], 1)
+	t := c.c.newTickerFunc(c.Duration(n), func(now Time) bool {
+		select {
+		case ch <- c.timeOf(now):
+			return true
+		default:
+			return false
+		}
+	})
+	return &TickerOf[D]{t: t, c: c, ch: ch}
+}
+
+// Tick is a convenience wrapper for NewTicker providing access to the
+// ticking channel only. Unlike NewTicker, Tick will return nil if n <= 0.
+func (c *ClockOf[D]) Tick(n D) <-chan TimeOf[D] {
+	if n <= 0 {
+		return nil
+	}
+	return c.NewTicker(n).ch
+}
+
+// TickFunc calls f in its own goroutine every n units. See Clock.TickFunc.
+func (c *ClockOf[D]) TickFunc(n D, f func()) *TickerOf[D] {
+	if n <= 0 {
+		panic("non-positive interval for steppedtime.ClockOf.TickFunc")
+	}
+
+	return &TickerOf[D]{t: c.c.TickFunc(c.Duration(n), f), c: c}
+}
+
+// TimerOf is a Timer of a ClockOf, delivering its TimeOf.
+type TimerOf[D Integer] struct {
+	t  *Timer
+	c  *ClockOf[D]
+	ch chan TimeOf[D]
+}
+
+// C returns the channel on which the time is delivered, or nil for a Timer
+// created by AfterFunc.
+func (t *TimerOf[D]) C() <-chan TimeOf[D] {
+	return t.ch
+}
+
+// Reset changes the timer to expire after n units, as with Timer.Reset.
+func (t *TimerOf[D]) Reset(n D) bool {
+	return t.t.Reset(t.c.Duration(n))
+}
+
+// Stop prevents the Timer from firing, as with Timer.Stop.
+func (t *TimerOf[D]) Stop() bool {
+	return t.t.Stop()
+}
+
+// Pause suspends the Timer with the time it has left, as with Timer.Pause.
+func (t *TimerOf[D]) Pause() bool {
+	return t.t.Pause()
+}
+
+// Resume restarts a paused Timer, as with Timer.Resume.
+func (t *TimerOf[D]) Resume() bool {
+	return t.t.Resume()
+}
+
+// TickerOf is a Ticker of a ClockOf, delivering its TimeOf.
+type TickerOf[D Integer] struct {
+	t  *Ticker
+	c  *ClockOf[D]
+	ch chan TimeOf[D]
+}
+
+// C returns the channel on which the ticks are delivered, or nil for a
+// Ticker created by TickFunc.
+func (t *TickerOf[D]) C() <-chan TimeOf[D] {
+	return t.ch
+}
+
+// Reset stops the ticker and resets its period to n units, as with
+// Ticker.Reset. The count n must be greater than zero; if not, Reset will
+// panic.
+func (t *TickerOf[D]) Reset(n D) {
+	if n <= 0 {
+		panic("non-positive interval for steppedtime.TickerOf.Reset")
+	}
+
+	t.t.Reset(t.c.Duration(n))
+}
+
+// Stop turns off the ticker, as with Ticker.Stop.
+func (t *TickerOf[D]) Stop() {
+	t.t.Stop()
+}
+
+// Pause suspends the ticker in its current phase, as with Ticker.Pause.
+func (t *TickerOf[D]) Pause() {
+	t.t.Pause()
+}
+
+// Resume restarts a paused ticker, as with Ticker.Resume.
+func (t *TickerOf[D]) Resume() {
+	t.t.Resume()
+}
+
+// Missed returns the number of ticks that were not delivered since the
+// previous call to Missed, as with Ticker.Missed.
+func (t *TickerOf[D]) Missed() int {
+	return t.t.Missed()
+}
+
+// Add returns the time t+n.
+func (t TimeOf[D]) Add(n D) TimeOf[D] {
+	return t + TimeOf[D](n)
+}
+
+// Sub returns the count of units t-u.
+func (t TimeOf[D]) Sub(u TimeOf[D]) D {
+	return D(t - u)
+}
+
+// After reports whether the time instant t is after u.
+func (t TimeOf[D]) After(u TimeOf[D]) bool {
+	return t > u
+}
+
+// Before reports whether the time instant t is before u.
+func (t TimeOf[D]) Before(u TimeOf[D]) bool {
+	return t < u
+}
+
+// Compare compares the time instant t with u. If t is before u, it returns
+// -1; if t is after u, it returns +1; if they're the same, it returns 0.
+func (t TimeOf[D]) Compare(u TimeOf[D]) int {
+	switch {
+	case t < u:
+		return -1
+	case t > u:
+		return 1
+	}
+	return 0
+}
+
+// Equal reports whether t and u represent the same time instant.
+func (t TimeOf[D]) Equal(u TimeOf[D]) bool {
+	return t == u
+}
+
+// IsZero reports whether t represents the zero time instant, the start of
+// the clock.
+func (t TimeOf[D]) IsZero() bool {
+	return t == 0
+}
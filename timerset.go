@@ -0,0 +1,258 @@
+package clock
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// Expiry is sent by a TimerSet when one of its deadlines passes.
+type Expiry[K comparable, T any] struct {
+	Name K // Name of the deadline
+	At   T // Time at which it was due
+}
+
+// A TimerSet manages many named deadlines on a Clock, delivering each as it
+// passes on a single channel, so that an event loop juggling dozens of
+// deadlines may wait on all of them in one select case. Only one Timer on
+// the Clock is used at a time, armed for the earliest deadline. A TimerSet
+// must be created with NewTimerSet.
+type TimerSet[K comparable, T Time[T, D], D Duration] struct {
+	c      Clock[T, D]
+	tm     Timer[T, D] // Armed for the earliest deadline, created lazily
+	queue  deadlines[K, T, D]
+	byName map[K]*deadline[K, T]
+
+	ready   []*deadline[K, T] // Passed, but not yet received
+	ch      chan Expiry[K, T]
+	changed chan struct{} // Closed and replaced when ready is trimmed
+	sending bool          // Whether a goroutine is delivering ready
+	stopped bool
+
+	mu sync.Mutex // Protects all fields but c and ch
+}
+
+type deadline[K comparable, T any] struct {
+	name  K
+	when  T
+	index int
+}
+
+// NewTimerSet returns a new, empty TimerSet on c.
+func NewTimerSet[K comparable, T Time[T, D], D Duration](c Clock[T, D]) *TimerSet[K, T, D] {
+	return &TimerSet[K, T, D]{
+		c:       c,
+		byName:  make(map[K]*deadline[K, T]),
+		ch:      make(chan Expiry[K, T]),
+		changed: make(chan struct{}),
+	}
+}
+
+// C returns the channel on which each deadline is delivered once it has
+// passed, in the order they were due. Deadlines passed while no one is
+// receiving are queued, rather than dropped.
+func (s *TimerSet[K, T, D]) C() <-chan Expiry[K, T] {
+	return s.ch
+}
+
+// Set sets the deadline called name to pass after duration d, replacing any
+// already set by that name, including one that has passed but not yet been
+// received.
+func (s *TimerSet[K, T, D]) Set(name K, d D) {
+	s.SetAt(name, s.c.Now().Add(d))
+}
+
+// SetAt is like Set, but sets the deadline to pass at time t.
+func (s *TimerSet[K, T, D]) SetAt(name K, t T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	s.unready(name)
+	if dl, ok := s.byName[name]; ok {
+		dl.when = t
+		heap.Fix(&s.queue, dl.index)
+	} else {
+		dl = &deadline[K, T]{name: name, when: t}
+		s.byName[name] = dl
+		heap.Push(&s.queue, dl)
+	}
+	s.check()
+}
+
+// Update moves the deadline called name to pass after duration d, but only
+// if it is still pending, and reports whether it was.
+func (s *TimerSet[K, T, D]) Update(name K, d D) bool {
+	t := s.c.Now().Add(d)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dl, ok := s.byName[name]
+	if !ok {
+		return false
+	}
+	dl.when = t
+	heap.Fix(&s.queue, dl.index)
+	s.check()
+	return true
+}
+
+// Cancel removes the deadline called name, and reports whether it was
+// pending or had passed without yet being received. Once Cancel returns, no
+// Expiry for it will be received, unless one was being received at the
+// same moment by another goroutine.
+func (s *TimerSet[K, T, D]) Cancel(name K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.unready(name) {
+		return true
+	}
+	dl, ok := s.byName[name]
+	if !ok {
+		return false
+	}
+	heap.Remove(&s.queue, dl.index)
+	delete(s.byName, name)
+	s.check()
+	return true
+}
+
+// Deadline returns the time at which the deadline called name is due, and
+// whether it is still pending.
+func (s *TimerSet[K, T, D]) Deadline(name K) (t T, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if dl, ok := s.byName[name]; ok {
+		return dl.when, true
+	}
+	return t, false
+}
+
+// Len returns the number of deadlines still pending.
+func (s *TimerSet[K, T, D]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// Stop cancels every deadline, including any passed but not yet received,
+// and releases the underlying Timer. Afterwards, Set and SetAt do nothing.
+func (s *TimerSet[K, T, D]) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	s.queue = nil
+	s.byName = make(map[K]*deadline[K, T])
+	if len(s.ready) > 0 {
+		s.ready = nil
+		s.signal()
+	}
+	if s.tm != nil {
+		s.tm.Stop()
+	}
+}
+
+// Remove the passed deadline called name from the ready queue, reporting
+// whether it was there. Callers must hold the lock.
+func (s *TimerSet[K, T, D]) unready(name K) bool {
+	for i, dl := range s.ready {
+		if dl.name == name {
+			s.ready = append(s.ready[:i], s.ready[i+1:]...)
+			s.signal()
+			return true
+		}
+	}
+	return false
+}
+
+// Wake the delivering goroutine to notice a change in the ready queue.
+// Callers must hold the lock.
+func (s *TimerSet[K, T, D]) signal() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// Move any deadlines that have passed to the ready queue, and arm the Timer
+// for the next. Callers must hold the lock.
+func (s *TimerSet[K, T, D]) check() {
+	now := s.c.Now()
+	for len(s.queue) > 0 && !s.queue[0].when.After(now) {
+		dl := heap.Pop(&s.queue).(*deadline[K, T])
+		delete(s.byName, dl.name)
+		s.ready = append(s.ready, dl)
+	}
+	if len(s.ready) > 0 && !s.sending {
+		s.sending = true
+		go s.deliver()
+	}
+
+	if len(s.queue) == 0 {
+		if s.tm != nil {
+			s.tm.Stop()
+		}
+		return
+	}
+	d := s.queue[0].when.Sub(now)
+	if s.tm == nil {
+		s.tm = s.c.AfterFunc(d, s.fire)
+	} else {
+		s.tm.Reset(d)
+	}
+}
+
+func (s *TimerSet[K, T, D]) fire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.stopped {
+		s.check()
+	}
+}
+
+// Deliver the ready queue, until it is empty.
+func (s *TimerSet[K, T, D]) deliver() {
+	for {
+		s.mu.Lock()
+		if len(s.ready) == 0 {
+			s.sending = false
+			s.mu.Unlock()
+			return
+		}
+		dl, changed := s.ready[0], s.changed
+		s.mu.Unlock()
+
+		select {
+		case s.ch <- Expiry[K, T]{dl.name, dl.when}:
+			s.mu.Lock()
+			if len(s.ready) > 0 && s.ready[0] == dl {
+				s.ready = s.ready[1:]
+			}
+			s.mu.Unlock()
+		case <-changed:
+		}
+	}
+}
+
+// A min-heap of deadlines, for container/heap.
+type deadlines[K comparable, T Time[T, D], D Duration] []*deadline[K, T]
+
+func (q deadlines[K, T, D]) Len() int           { return len(q) }
+func (q deadlines[K, T, D]) Less(i, j int) bool { return q[i].when.Before(q[j].when) }
+func (q deadlines[K, T, D]) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *deadlines[K, T, D]) Push(x any) {
+	dl := x.(*deadline[K, T])
+	dl.index = len(*q)
+	*q = append(*q, dl)
+}
+
+func (q *deadlines[K, T, D]) Pop() any {
+	old := *q
+	n := len(old) - 1
+	dl := old[n]
+	old[n] = nil
+	*q = old[:n]
+	return dl
+}
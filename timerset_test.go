@@ -0,0 +1,61 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/noodlebox/clock"
+	"github.com/noodlebox/clock/mocktime"
+)
+
+func TestTimerSet(t *testing.T) {
+	m := mocktime.NewClock()
+	s := NewTimerSet[string, time.Time, time.Duration](FromMocktime(m))
+	defer s.Stop()
+
+	start := m.Now()
+	s.Set("a", 3*time.Second)
+	s.Set("b", time.Second)
+	s.Set("c", 2*time.Second)
+	s.Set("d", 4*time.Second)
+	if !s.Update("b", 5*time.Second) {
+		t.Errorf("Update(b) = false for a pending deadline")
+	}
+	if !s.Cancel("d") || s.Cancel("d") {
+		t.Errorf("Cancel(d) did not report cancelling exactly once")
+	}
+	if n := s.Len(); n != 3 {
+		t.Errorf("Len() = %d, want 3", n)
+	}
+
+	// Passed deadlines are queued until received, in order
+	m.Step(10 * time.Second)
+	for _, want := range []struct {
+		name string
+		d    time.Duration
+	}{{"c", 2 * time.Second}, {"a", 3 * time.Second}, {"b", 5 * time.Second}} {
+		select {
+		case e := <-s.C():
+			if e.Name != want.name || !e.At.Equal(start.Add(want.d)) {
+				t.Errorf("received %v at %v, want %v at %v", e.Name, e.At.Sub(start), want.name, want.d)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no Expiry received for %v", want.name)
+		}
+	}
+
+	// A passed deadline not yet received may still be cancelled
+	s.Set("e", time.Second)
+	m.Step(time.Second)
+	if !s.Cancel("e") {
+		t.Errorf("Cancel(e) = false for a deadline not yet received")
+	}
+	select {
+	case e := <-s.C():
+		t.Errorf("received %v after cancelling it", e.Name)
+	case <-time.After(10 * time.Millisecond):
+	}
+	if s.Update("e", time.Second) {
+		t.Errorf("Update(e) = true for a cancelled deadline")
+	}
+}